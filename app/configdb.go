@@ -3,31 +3,29 @@ package app
 import (
 	"database/sql"
 	"fmt"
-	"log"
 	"time"
-)
 
-func DBConnection() *sql.DB {
+	"github.com/dimassfeb-09/efilm-api.git/config"
+)
 
-	env := GetEnv()
+func DBConnection(cfg config.Database) (*sql.DB, error) {
 
 	connectionString := fmt.Sprintf("postgres://%v:%v@%v:%v/%v?sslmode=%v",
-		env.DBUser,
-		env.DBPass,
-		env.DBHost,
-		env.DBPort,
-		env.DBName,
-		env.DBSSLMode)
+		cfg.User,
+		cfg.Password,
+		cfg.Host,
+		cfg.Port,
+		cfg.Name,
+		cfg.SSLMode)
 
 	db, err := sql.Open("postgres", connectionString)
 	if err != nil {
-		log.Fatal("Failed to connect DB" + err.Error())
+		return nil, fmt.Errorf("failed to open the database: %w", err)
 	}
 	db.SetMaxOpenConns(50)
 	db.SetMaxIdleConns(5)
 	db.SetConnMaxIdleTime(5 * time.Minute)
 	db.SetConnMaxLifetime(60 * time.Minute)
 
-	log.Println("Successfully connected DB!")
-	return db
+	return db, nil
 }
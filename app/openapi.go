@@ -0,0 +1,135 @@
+package app
+
+import (
+	"github.com/dimassfeb-09/efilm-api.git/docs"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+)
+
+var info = docs.Info{
+	Title:       "eFilm API",
+	Description: "Movies, the people who make them, their genres and nationalities.",
+	Version:     "1.0.0",
+}
+
+var (
+	nameQuery       = docs.Parameter{Name: "name", In: "query", Description: "Part of the name to look for"}
+	nationalIDQuery = docs.Parameter{Name: "national_id", In: "query", Type: "integer", Description: "Only return people of this nationality"}
+	sortQuery       = docs.Parameter{Name: "sort", In: "query", Description: "Only release_date is supported"}
+	orderQuery      = docs.Parameter{Name: "order", In: "query", Description: "asc or desc, newest first by default"}
+	searchQuery     = docs.Parameter{Name: "search", In: "query", Description: "Part of the username or display name to look for"}
+	codeQuery       = docs.Parameter{Name: "code", In: "query", Description: "The code the provider sent back, unless it sent an error"}
+	errorQuery      = docs.Parameter{Name: "error", In: "query", Description: "Why the provider refused the login"}
+	stateQuery      = docs.Parameter{Name: "state", In: "query", Required: true, Description: "The state the provider sent back"}
+	stateCookie     = docs.Parameter{Name: "efilm_oidc_state", In: "cookie", Required: true, Description: "The state the login left in the browser, which has to match"}
+)
+
+type movieCreated struct {
+	MovieID int `json:"movie_id"`
+}
+
+// operations documents every route registered in InitialozedRoute, keyed by
+// docs.Key. Registering a route without an entry here fails TestOpenAPI.
+var operations = map[string]docs.Operation{
+	docs.Key("GET", "/"):                      {Summary: "Check the server is up", ContentType: "text/plain"},
+	docs.Key("GET", "/metrics"):               {Summary: "Prometheus metrics", Tag: "health", ContentType: "text/plain"},
+	docs.Key("GET", "/healthz"):               {Summary: "Check the process is alive", Tag: "health"},
+	docs.Key("GET", "/readyz"):                {Summary: "Check the database, the schema and storage", Tag: "health", Response: web.ReadinessResponse{}, Statuses: []int{503}},
+	docs.Key("GET", "/.well-known/jwks.json"): {Summary: "Public keys that verify tokens", Tag: "auth", ContentType: "application/json"},
+	docs.Key("GET", "/api/openapi.json"):      {Summary: "This document", Tag: "docs", ContentType: "application/json"},
+	docs.Key("GET", "/docs/*any"):             {Summary: "Swagger UI for this document", Tag: "docs", ContentType: "text/html"},
+
+	docs.Key("POST", "/api/auth/register"):        {Summary: "Register a user", Tag: "auth", Request: web.AuthModelRequest{}, Public: true},
+	docs.Key("POST", "/api/auth/login"):           {Summary: "Log in and get a token", Tag: "auth", Request: web.AuthModelRequest{}, Response: web.AuthModelResponse{}, Public: true},
+	docs.Key("POST", "/api/auth/password/forgot"): {Summary: "Send a password reset token to a user", Tag: "auth", Request: web.ForgotPasswordRequest{}, Public: true},
+	docs.Key("POST", "/api/auth/password/reset"):  {Summary: "Choose a new password with a reset token", Tag: "auth", Request: web.ResetPasswordRequest{}, Public: true},
+	docs.Key("GET", "/api/auth/oidc/login"):       {Summary: "Start a login at the OIDC provider, linking it to the user of the token if any", Tag: "auth", Response: web.OIDCLoginResponse{}},
+	docs.Key("GET", "/api/auth/oidc/callback"):    {Summary: "Finish a login at the OIDC provider and get a token", Tag: "auth", Params: []docs.Parameter{codeQuery, stateQuery, errorQuery, stateCookie}, Response: web.AuthModelResponse{}, Public: true},
+	docs.Key("POST", "/api/auth/unlock"):          {Summary: "Forget the failed logins of a username or client IP", Tag: "auth", Request: web.UnlockLoginRequest{}, Role: "admin"},
+	docs.Key("PUT", "/api/users/me/password"):     {Summary: "Change the password of the user of the bearer token", Tag: "users", Request: web.ChangePasswordRequest{}, UserOnly: true},
+	docs.Key("GET", "/api/users/me"):              {Summary: "Get the user of the bearer token", Tag: "users", Response: web.UserResponse{}, UserOnly: true},
+	docs.Key("POST", "/api/users/info"):           {Summary: "Get the user of the bearer token, use GET /api/users/me instead", Tag: "users", Response: web.UserResponse{}, UserOnly: true, Deprecated: true},
+	docs.Key("PATCH", "/api/users/me"):            {Summary: "Update the profile of the user of the bearer token", Tag: "users", Request: web.UserProfileRequest{}, Response: web.UserResponse{}, UserOnly: true},
+	docs.Key("GET", "/api/users"):                 {Summary: "List users", Tag: "users", Params: []docs.Parameter{searchQuery}, Response: []web.UserResponse{}, Role: "admin"},
+	docs.Key("PUT", "/api/users/:id/role"):        {Summary: "Change the role of a user", Tag: "users", Request: web.UserRoleRequest{}, Role: "admin"},
+	docs.Key("POST", "/api/users/:id/disable"):    {Summary: "Disable a user, who can no longer log in", Tag: "users", Role: "admin"},
+	docs.Key("POST", "/api/users/:id/enable"):     {Summary: "Enable a disabled user", Tag: "users", Role: "admin"},
+	docs.Key("DELETE", "/api/users/:id"):          {Summary: "Delete a user", Tag: "users", Role: "admin"},
+
+	docs.Key("POST", "/api/api-keys"):       {Summary: "Create an API key", Tag: "api keys", Request: web.APIKeyRequest{}, Response: web.APIKeyCreatedResponse{}, Role: "admin"},
+	docs.Key("GET", "/api/api-keys"):        {Summary: "List API keys", Tag: "api keys", Response: []web.APIKeyResponse{}, Role: "admin"},
+	docs.Key("DELETE", "/api/api-keys/:id"): {Summary: "Revoke an API key", Tag: "api keys", Role: "admin"},
+
+	docs.Key("POST", "/api/actors"):           {Summary: "Create an actor", Tag: "actors", Request: web.ActorModelRequest{}},
+	docs.Key("GET", "/api/actors"):            {Summary: "List actors", Tag: "actors", Response: []web.ActorModelResponse{}},
+	docs.Key("GET", "/api/actors/search"):     {Summary: "Search actors", Tag: "actors", Params: []docs.Parameter{nameQuery, nationalIDQuery}, Response: []web.ActorModelResponse{}},
+	docs.Key("GET", "/api/actors/:id"):        {Summary: "Get an actor", Tag: "actors", Response: web.ActorModelResponse{}},
+	docs.Key("GET", "/api/actors/:id/movies"): {Summary: "Get the movies of an actor", Tag: "actors", Params: []docs.Parameter{sortQuery, orderQuery}, Response: web.ActorMoviesModelResponse{}},
+	docs.Key("PUT", "/api/actors/:id"):        {Summary: "Update an actor", Tag: "actors", Request: web.ActorModelRequest{}},
+	docs.Key("DELETE", "/api/actors/:id"):     {Summary: "Delete an actor", Tag: "actors"},
+
+	docs.Key("POST", "/api/people"):            {Summary: "Create a person", Tag: "people", Request: web.PersonModelRequest{}},
+	docs.Key("GET", "/api/people"):             {Summary: "List people", Tag: "people", Response: []web.PersonModelResponse{}},
+	docs.Key("GET", "/api/people/:id"):         {Summary: "Get a person", Tag: "people", Response: web.PersonModelResponse{}},
+	docs.Key("GET", "/api/people/:id/credits"): {Summary: "Get the cast and crew credits of a person", Tag: "people", Response: web.PersonCreditsModelResponse{}},
+	docs.Key("PUT", "/api/people/:id"):         {Summary: "Update a person", Tag: "people", Request: web.PersonModelRequest{}},
+	docs.Key("DELETE", "/api/people/:id"):      {Summary: "Delete a person", Tag: "people"},
+
+	docs.Key("POST", "/api/directors"):           {Summary: "Create a director", Tag: "directors", Request: web.DirectorModelRequest{}},
+	docs.Key("GET", "/api/directors"):            {Summary: "List directors", Tag: "directors", Response: []web.DirectorModelResponse{}},
+	docs.Key("GET", "/api/directors/search"):     {Summary: "Search directors", Tag: "directors", Params: []docs.Parameter{nameQuery, nationalIDQuery}, Response: []web.DirectorModelResponse{}},
+	docs.Key("GET", "/api/directors/:id"):        {Summary: "Get a director", Tag: "directors", Response: web.DirectorModelResponse{}},
+	docs.Key("GET", "/api/directors/:id/movies"): {Summary: "Get the movies of a director", Tag: "directors", Params: []docs.Parameter{sortQuery, orderQuery}, Response: web.DirectorMoviesModelResponse{}},
+	docs.Key("PUT", "/api/directors/:id"):        {Summary: "Update a director", Tag: "directors", Request: web.DirectorModelRequest{}},
+	docs.Key("DELETE", "/api/directors/:id"):     {Summary: "Delete a director", Tag: "directors"},
+
+	docs.Key("POST", "/api/nationals"):       {Summary: "Create a national", Tag: "nationals", Request: web.NationalModelRequest{}},
+	docs.Key("GET", "/api/nationals"):        {Summary: "List nationals", Tag: "nationals", Response: []web.NationalModelResponse{}},
+	docs.Key("GET", "/api/nationals/search"): {Summary: "Search nationals", Tag: "nationals", Params: []docs.Parameter{{Name: "name", In: "query", Required: true}}, Response: []web.NationalModelResponse{}},
+	docs.Key("GET", "/api/nationals/:id"):    {Summary: "Get a national", Tag: "nationals", Response: web.NationalModelResponse{}},
+	docs.Key("PUT", "/api/nationals/:id"):    {Summary: "Update a national", Tag: "nationals", Request: web.NationalModelRequest{}},
+	docs.Key("DELETE", "/api/nationals/:id"): {Summary: "Delete a national", Tag: "nationals"},
+
+	docs.Key("POST", "/api/movies/:movie_id/upload_poster"): {Summary: "Upload the poster of a movie", Tag: "movies", File: "poster_file"},
+	docs.Key("POST", "/api/movies"):                         {Summary: "Create a movie", Tag: "movies", Request: web.MovieModelRequest{}, Response: movieCreated{}},
+	docs.Key("GET", "/api/movies"):                          {Summary: "List movies", Tag: "movies", Response: []web.MovieModelResponse{}},
+	docs.Key("GET", "/api/movies/search"):                   {Summary: "Search movies", Tag: "movies", Params: []docs.Parameter{{Name: "title", In: "query", Description: "Part of the title to look for"}}, Response: []web.MovieModelResponse{}},
+	docs.Key("GET", "/api/movies/:movie_id"):                {Summary: "Get a movie", Tag: "movies", Response: web.MovieModelResponse{}},
+	docs.Key("PUT", "/api/movies/:movie_id"):                {Summary: "Update a movie", Tag: "movies", Request: web.MovieModelRequest{}},
+	docs.Key("DELETE", "/api/movies/:movie_id"):             {Summary: "Delete a movie", Tag: "movies"},
+
+	docs.Key("GET", "/api/movies/recommendation"):              {Summary: "List recommended movies", Tag: "recommendation", Response: []web.RecommendationMovieModelResponse{}},
+	docs.Key("POST", "/api/movies/recommendation"):             {Summary: "Recommend a movie", Tag: "recommendation", Request: web.RecommendationMovieModelRequest{}},
+	docs.Key("DELETE", "/api/movies/recommendation/:movie_id"): {Summary: "Stop recommending a movie", Tag: "recommendation"},
+
+	docs.Key("POST", "/api/genres"):           {Summary: "Create a genre", Tag: "genres", Request: web.GenreModelRequest{}},
+	docs.Key("GET", "/api/genres"):            {Summary: "List genres", Tag: "genres", Response: []web.GenreModelResponse{}},
+	docs.Key("GET", "/api/genres/search"):     {Summary: "Search genres", Tag: "genres", Params: []docs.Parameter{{Name: "name", In: "query", Required: true}}, Response: []web.GenreModelResponse{}},
+	docs.Key("GET", "/api/genres/:id"):        {Summary: "Get a genre", Tag: "genres", Response: web.GenreModelResponse{}},
+	docs.Key("GET", "/api/genres/:id/movies"): {Summary: "Get the movies of a genre", Tag: "genres", Response: web.MoviesGenreResponse{}},
+	docs.Key("PUT", "/api/genres/:id"):        {Summary: "Update a genre", Tag: "genres", Request: web.GenreModelRequest{}},
+	docs.Key("DELETE", "/api/genres/:id"):     {Summary: "Delete a genre", Tag: "genres"},
+
+	docs.Key("POST", "/api/movies/:movie_id/actors"):             {Summary: "Add an actor to a movie", Tag: "movie actors", Request: web.MovieActorModelRequestPost{}},
+	docs.Key("GET", "/api/movies/:movie_id/actors"):              {Summary: "Get the cast of a movie", Tag: "movie actors", Response: web.MovieActorModelResponse{}},
+	docs.Key("PUT", "/api/movies/:movie_id/actors"):              {Summary: "Replace the cast of a movie", Tag: "movie actors", Request: web.MovieActorModelRequestBatch{}, Response: web.MovieActorModelResponse{}},
+	docs.Key("PUT", "/api/movies/:movie_id/actors/:actor_id"):    {Summary: "Update the credit of an actor", Tag: "movie actors", Request: web.MovieActorModelRequestPut{}},
+	docs.Key("DELETE", "/api/movies/:movie_id/actors/:actor_id"): {Summary: "Remove an actor from a movie", Tag: "movie actors"},
+
+	docs.Key("POST", "/api/movies/:movie_id/directors"):                {Summary: "Add a director to a movie", Tag: "movie directors", Request: web.MovieDirectorModelRequestPost{}},
+	docs.Key("GET", "/api/movies/:movie_id/directors"):                 {Summary: "Get the directors of a movie", Tag: "movie directors", Response: web.MovieDirectorModelResponse{}},
+	docs.Key("DELETE", "/api/movies/:movie_id/directors/:director_id"): {Summary: "Remove a director from a movie", Tag: "movie directors"},
+
+	docs.Key("POST", "/api/movies/:movie_id/crew"): {Summary: "Add a crew member to a movie", Tag: "movie crew", Request: web.MovieCrewModelRequestPost{}},
+	docs.Key("GET", "/api/movies/:movie_id/crew"):  {Summary: "Get the crew of a movie", Tag: "movie crew", Params: []docs.Parameter{{Name: "job", In: "query", Description: "Only return this job"}}, Response: web.MovieCrewModelResponse{}},
+	docs.Key("DELETE", "/api/movies/:movie_id/crew/:person_id/:job"): {
+		Summary: "Remove a crew member from a movie",
+		Tag:     "movie crew",
+		Params:  []docs.Parameter{{Name: "job", In: "path", Type: "string"}},
+	},
+	docs.Key("GET", "/api/crew/jobs"):       {Summary: "List crew jobs", Tag: "movie crew", Response: []web.CrewJobResponse{}},
+	docs.Key("GET", "/api/crew/:person_id"): {Summary: "Get the crew credits of a person", Tag: "movie crew", Response: web.PersonCrewModelResponse{}},
+
+	docs.Key("POST", "/api/movies/:movie_id/genres"):             {Summary: "Add a genre to a movie", Tag: "movie genres", Request: web.MovieGenreModelRequestPost{}},
+	docs.Key("GET", "/api/movies/:movie_id/genres"):              {Summary: "Get the genres of a movie", Tag: "movie genres", Response: web.MovieGenreModelResponse{}},
+	docs.Key("DELETE", "/api/movies/:movie_id/genres/:genre_id"): {Summary: "Remove a genre from a movie", Tag: "movie genres"},
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/dimassfeb-09/efilm-api.git/config"
+	"github.com/dimassfeb-09/efilm-api.git/docs"
+	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/dimassfeb-09/efilm-api.git/metrics"
+	"github.com/dimassfeb-09/efilm-api.git/notify"
+	"github.com/dimassfeb-09/efilm-api.git/ratelimit"
+	"github.com/dimassfeb-09/efilm-api.git/repository/memory"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	store := memory.NewStore()
+	keys, err := helpers.NewJWTKeys("test-secret", "", nil)
+	assert.NoError(t, err)
+	r := InitialozedRoute(gin.New(), config.Default(), keys, store, memory.NewRepositories(store), metrics.New(), ratelimit.NewMemoryStore(), notify.NewLogNotifier(logging.Discard()), logging.Discard())
+
+	_, err = docs.Generate(info, r.Routes(), operations)
+	assert.NoError(t, err, "every route needs an entry in operations")
+}
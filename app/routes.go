@@ -1,17 +1,35 @@
 package app
 
 import (
-	"database/sql"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"time"
 
+	"github.com/dimassfeb-09/efilm-api.git/config"
 	"github.com/dimassfeb-09/efilm-api.git/controller"
+	"github.com/dimassfeb-09/efilm-api.git/docs"
+	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/metrics"
 	"github.com/dimassfeb-09/efilm-api.git/middlewares"
+	"github.com/dimassfeb-09/efilm-api.git/notify"
+	"github.com/dimassfeb-09/efilm-api.git/oidc"
+	"github.com/dimassfeb-09/efilm-api.git/ratelimit"
 	"github.com/dimassfeb-09/efilm-api.git/repository"
 	"github.com/dimassfeb-09/efilm-api.git/services"
 	"github.com/gin-gonic/gin"
 )
 
-func InitialozedRoute(r *gin.Engine, db *sql.DB) *gin.Engine {
+func InitialozedRoute(r *gin.Engine, cfg *config.Config, keys *helpers.JWTKeys, db repository.DB, repositories repository.Repositories, m *metrics.Metrics, limiter ratelimit.Store, notifier notify.Notifier, logger *slog.Logger) *gin.Engine {
+
+	r.Use(middlewares.Tracing(), middlewares.RequestID(), middlewares.Logger(logger), middlewares.Recovery(logger), middlewares.Metrics(m), middlewares.CORS(cfg.CORS),
+		middlewares.LimitBody(int64(cfg.Server.MaxBodyBytes), int64(cfg.Server.MaxUploadBytes)))
+	r.GET("/metrics", gin.WrapH(m.Handler()))
+
+	contract := &docs.Contract{}
+	if cfg.ContractValidation {
+		r.Use(middlewares.ValidateContract(contract, int64(cfg.Server.MaxBodyBytes), gin.Mode() != gin.ReleaseMode, logger))
+	}
 
 	// Index
 	r.GET("/", func(c *gin.Context) {
@@ -19,43 +37,106 @@ func InitialozedRoute(r *gin.Engine, db *sql.DB) *gin.Engine {
 		c.Writer.Write([]byte("Server ON!"))
 	})
 
+	healthService := services.NewHealthService(db, repositories.Migration, cfg.Firebase, logger)
+	healthController := controller.NewHealthControllerImpl(healthService)
+
+	r.GET("/healthz", healthController.Liveness)
+	r.GET("/readyz", healthController.Readiness)
+
+	// Public keys for other services to verify tokens with.
+	r.GET("/.well-known/jwks.json", func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, keys.JWKS())
+	})
+
 	api := r.Group("/api")
 
-	authRepository := repository.NewAuthRepository()
-	authService := services.NewAuthService(db, authRepository)
-	authController := controller.NewAuthControllerImpl(authService)
+	apiKeyService := services.NewAPIKeyService(db, repositories.APIKey)
+	apiKeyController := controller.NewAPIKeyControllerImpl(apiKeyService)
 
-	api.POST("/auth/register", authController.Register)
-	api.POST("/auth/login", authController.Login)
+	authRepository := repositories.Auth
+	userService := services.NewUserService(db, authRepository, repositories.Genre, repositories.PasswordReset, repositories.LoginAttempt, repositories.Identity)
 
-	actorRepository := repository.NewActorRepository()
-	actorService := services.NewActorService(db, actorRepository)
+	api.Use(middlewares.Authenticate(keys, apiKeyService, userService))
+	api.Use(middlewares.RateLimit(limiter, "api", cfg.RateLimit.Default, middlewares.ClientKey, logger))
+	authLimit := middlewares.RateLimit(limiter, "auth", cfg.RateLimit.Auth, middlewares.ClientKey, logger)
+
+	authService := services.NewAuthService(db, authRepository, repositories.LoginAttempt, repositories.PasswordReset, keys, cfg.Login, cfg.Password, notifier, m)
+	authController := controller.NewAuthControllerImpl(authService)
+
+	api.POST("/auth/register", authLimit, authController.Register)
+	api.POST("/auth/login", authLimit, authController.Login)
+	api.POST("/auth/password/forgot", authLimit, authController.ForgotPassword)
+	api.POST("/auth/password/reset", authLimit, authController.ResetPassword)
+	admin := middlewares.RequireRole("admin")
+	api.POST("/auth/unlock", admin, authController.Unlock)
+
+	// The routes answer that OIDC is not configured until it is.
+	var provider *oidc.Provider
+	if cfg.OIDC.Enabled() {
+		provider = oidc.New(cfg.OIDC, &http.Client{Timeout: 10 * time.Second})
+	}
+	oidcService := services.NewOIDCService(db, authRepository, repositories.Identity, repositories.OIDCLogin, provider, keys, cfg.OIDC, cfg.Password, m)
+	oidcController := controller.NewOIDCControllerImpl(oidcService, cfg.OIDC)
+
+	api.GET("/auth/oidc/login", authLimit, middlewares.OptionalUser(), oidcController.Login)
+	api.GET("/auth/oidc/callback", authLimit, oidcController.Callback)
+
+	api.POST("/api-keys", admin, apiKeyController.Create)
+	api.GET("/api-keys", admin, apiKeyController.FindAll)
+	api.DELETE("/api-keys/:id", admin, apiKeyController.Delete)
+
+	actorRepository := repositories.Actor
+	actorService := services.NewActorService(db, actorRepository, repositories.MovieActor)
 	actorController := controller.NewActorControllerImpl(actorService)
 
-	userController := controller.NewUserController()
-	api.POST("/users/info", userController.GetUserInfo)
+	userController := controller.NewUserController(authService, userService)
+	user := middlewares.RequireUser()
+	api.GET("/users/me", user, userController.Me)
+	api.POST("/users/info", user, middlewares.Deprecated("/api/users/me"), userController.Me)
+	api.PATCH("/users/me", user, userController.UpdateMe)
+	api.PUT("/users/me/password", user, userController.ChangePassword)
+
+	api.GET("/users", admin, userController.FindAll)
+	api.PUT("/users/:id/role", admin, userController.ChangeRole)
+	api.POST("/users/:id/disable", admin, userController.Disable)
+	api.POST("/users/:id/enable", admin, userController.Enable)
+	api.DELETE("/users/:id", admin, userController.Delete)
 
-	api.Use(middlewares.MiddlewareToken)
+	api.Use(middlewares.MiddlewareToken())
 
 	api.POST("/actors", actorController.Save)
 	api.GET("/actors", actorController.FindAll)
 	api.GET("/actors/search", actorController.FindBySearch)
 	api.GET("/actors/:id", actorController.FindByID)
+	api.GET("/actors/:id/movies", actorController.FindMovies)
 	api.PUT("/actors/:id", actorController.Update)
 	api.DELETE("/actors/:id", actorController.Delete)
 
-	directorRepository := repository.NewDirectorRepository()
-	directorService := services.NewDirectorService(db, directorRepository)
+	personRepository := repositories.Person
+	personService := services.NewPersonService(db, personRepository)
+	personController := controller.NewPersonControllerImpl(personService)
+
+	api.POST("/people", personController.Save)
+	api.GET("/people", personController.FindAll)
+	api.GET("/people/:id", personController.FindByID)
+	api.GET("/people/:id/credits", personController.FindCredits)
+	api.PUT("/people/:id", personController.Update)
+	api.DELETE("/people/:id", personController.Delete)
+
+	directorRepository := repositories.Director
+	directorService := services.NewDirectorService(db, directorRepository, repositories.MovieDirector)
 	directorController := controller.NewDirectorControllerImpl(directorService)
 
 	api.POST("/directors", directorController.Save)
 	api.GET("/directors", directorController.FindAll)
 	api.GET("/directors/search", directorController.FindBySearch)
 	api.GET("/directors/:id", directorController.FindByID)
+	api.GET("/directors/:id/movies", directorController.FindMovies)
 	api.PUT("/directors/:id", directorController.Update)
 	api.DELETE("/directors/:id", directorController.Delete)
 
-	nationalRepository := repository.NewNationalRepository()
+	nationalRepository := repositories.National
 	nationalService := services.NewNationalService(db, nationalRepository)
 	nationalController := controller.NewNationalControllerImpl(nationalService)
 
@@ -66,8 +147,8 @@ func InitialozedRoute(r *gin.Engine, db *sql.DB) *gin.Engine {
 	api.PUT("/nationals/:id", nationalController.Update)
 	api.DELETE("/nationals/:id", nationalController.Delete)
 
-	movieRepository := repository.NewMovieRepository()
-	movieService := services.NewMovieService(db, movieRepository)
+	movieRepository := repositories.Movie
+	movieService := services.NewMovieService(db, movieRepository, repositories.MovieGenre, repositories.MovieCrew, authRepository, cfg.Firebase, m)
 	movieController := controller.NewMovieControllerImpl(movieService)
 
 	api.POST("/movies/:movie_id/upload_poster", movieController.UploadPoster)
@@ -78,7 +159,7 @@ func InitialozedRoute(r *gin.Engine, db *sql.DB) *gin.Engine {
 	api.PUT("/movies/:movie_id", movieController.Update)
 	api.DELETE("/movies/:movie_id", movieController.Delete)
 
-	recommendationMovieRepo := repository.NewRecommendationMovieRepositoryImpl()
+	recommendationMovieRepo := repositories.Recommendation
 	recommendationMovieService := services.NewRecommendationMovieService(db, recommendationMovieRepo)
 	recommendationMovieController := controller.NewRecommendationMovieControllerImpl(recommendationMovieService)
 
@@ -86,7 +167,7 @@ func InitialozedRoute(r *gin.Engine, db *sql.DB) *gin.Engine {
 	api.POST("/movies/recommendation", recommendationMovieController.Save)
 	api.DELETE("/movies/recommendation/:movie_id", recommendationMovieController.Delete)
 
-	genreRepository := repository.NewGenreRepository()
+	genreRepository := repositories.Genre
 	genreService := services.NewGenreService(db, genreRepository, movieService)
 	genreController := controller.NewGenreControllerImpl(genreService)
 
@@ -98,24 +179,35 @@ func InitialozedRoute(r *gin.Engine, db *sql.DB) *gin.Engine {
 	api.PUT("/genres/:id", genreController.Update)
 	api.DELETE("/genres/:id", genreController.Delete)
 
-	movieActorsRepository := repository.NewMovieActorRepository()
-	movieActorsService := services.NewMovieActorService(db, movieActorsRepository)
+	movieActorsRepository := repositories.MovieActor
+	movieActorsService := services.NewMovieActorService(db, movieActorsRepository, repositories.Person, repositories.Movie)
 	movieActorsController := controller.NewMovieActorControllerImpl(movieActorsService)
 
 	api.POST("/movies/:movie_id/actors", movieActorsController.Save)
 	api.GET("/movies/:movie_id/actors", movieActorsController.FindByID)
+	api.PUT("/movies/:movie_id/actors", movieActorsController.ReplaceCast)
 	api.PUT("/movies/:movie_id/actors/:actor_id", movieActorsController.Update)
 	api.DELETE("/movies/:movie_id/actors/:actor_id", movieActorsController.Delete)
 
-	movieDirectorsRepository := repository.NewMovieDirectorRepository()
-	movieDirectorsService := services.NewMovieDirectorService(db, movieDirectorsRepository)
+	movieDirectorsRepository := repositories.MovieDirector
+	movieDirectorsService := services.NewMovieDirectorService(db, movieDirectorsRepository, repositories.Director, repositories.Movie)
 	movieDirectorsController := controller.NewMovieDirectorControllerImpl(movieDirectorsService)
 
 	api.POST("/movies/:movie_id/directors", movieDirectorsController.Save)
 	api.GET("/movies/:movie_id/directors", movieDirectorsController.FindByID)
 	api.DELETE("/movies/:movie_id/directors/:director_id", movieDirectorsController.Delete)
 
-	movieGenresRepository := repository.NewMovieGenreRepository()
+	movieCrewRepository := repositories.MovieCrew
+	movieCrewService := services.NewMovieCrewService(db, movieCrewRepository, repositories.Person, repositories.Movie)
+	movieCrewController := controller.NewMovieCrewControllerImpl(movieCrewService)
+
+	api.POST("/movies/:movie_id/crew", movieCrewController.Save)
+	api.GET("/movies/:movie_id/crew", movieCrewController.FindByID)
+	api.DELETE("/movies/:movie_id/crew/:person_id/:job", movieCrewController.Delete)
+	api.GET("/crew/jobs", movieCrewController.FindAllJobs)
+	api.GET("/crew/:person_id", movieCrewController.FindByPerson)
+
+	movieGenresRepository := repositories.MovieGenre
 	movieGenresService := services.NewMovieGenreService(db, movieGenresRepository)
 	movieGenresController := controller.NewMovieGenreControllerImpl(movieGenresService)
 
@@ -123,5 +215,18 @@ func InitialozedRoute(r *gin.Engine, db *sql.DB) *gin.Engine {
 	api.GET("/movies/:movie_id/genres", movieGenresController.FindByID)
 	api.DELETE("/movies/:movie_id/genres/:genre_id", movieGenresController.Delete)
 
+	var document map[string]any
+	r.GET("/api/openapi.json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, document)
+	})
+	r.GET("/docs/*any", docs.SwaggerUI("/api/openapi.json"))
+
+	// Undocumented routes are still served; TestOpenAPI catches them.
+	document, _ = docs.Generate(info, r.Routes(), operations)
+	if err := contract.Load(document); err != nil {
+		// The document is generated from the code, so this is a bug.
+		panic(fmt.Sprintf("failed to load the OpenAPI document: %v", err))
+	}
+
 	return r
 }
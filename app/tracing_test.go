@@ -0,0 +1,45 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dimassfeb-09/efilm-api.git/config"
+	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/dimassfeb-09/efilm-api.git/metrics"
+	"github.com/dimassfeb-09/efilm-api.git/notify"
+	"github.com/dimassfeb-09/efilm-api.git/ratelimit"
+	"github.com/dimassfeb-09/efilm-api.git/repository/memory"
+	"github.com/dimassfeb-09/efilm-api.git/tracing"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+func TestRequestsAreTraced(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(tracing.NewProvider(sdktrace.WithSyncer(exporter)))
+
+	store := memory.NewStore()
+	keys, err := helpers.NewJWTKeys("test-secret", "", nil)
+	assert.NoError(t, err)
+	r := InitialozedRoute(gin.New(), config.Default(), keys, store, memory.NewRepositories(store), metrics.New(), ratelimit.NewMemoryStore(), notify.NewLogNotifier(logging.Discard()), logging.Discard())
+
+	for _, path := range []string{"/api/genres/1", "/healthz"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 2) {
+		service, server := spans[0], spans[1]
+		assert.Equal(t, "GenreService.FindByID", service.Name)
+		assert.Equal(t, "/api/genres/:id", server.Name)
+		assert.Equal(t, server.SpanContext.SpanID(), service.Parent.SpanID())
+		assert.Contains(t, server.Resource.Attributes(), semconv.ServiceName(tracing.ServiceName))
+	}
+}
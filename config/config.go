@@ -0,0 +1,678 @@
+// Package config loads the settings of the API once at startup so nothing
+// below main reads the environment on its own.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/ratelimit"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Port string `yaml:"port"`
+	// Mode is the gin mode: debug, release or test. Release refuses settings
+	// that are only safe on a developer's machine.
+	Mode     string   `yaml:"mode"`
+	Server   Server   `yaml:"server"`
+	Database Database `yaml:"database"`
+	JWT      JWT      `yaml:"jwt"`
+	Login    Login    `yaml:"login"`
+	Password Password `yaml:"password"`
+	OIDC     OIDC     `yaml:"oidc"`
+	Notifier Notifier `yaml:"notifier"`
+	Firebase Firebase `yaml:"firebase"`
+	Log      Log      `yaml:"log"`
+	Tracing  Tracing  `yaml:"tracing"`
+
+	RateLimit RateLimit `yaml:"rate_limit"`
+	CORS      CORS      `yaml:"cors"`
+
+	// ContractValidation checks requests, and outside release mode responses,
+	// against the OpenAPI document.
+	ContractValidation bool `yaml:"contract_validation"`
+}
+
+// Server holds the timeouts of the HTTP server. ShutdownTimeout bounds how
+// long a stopping server waits for in-flight requests and background work.
+type Server struct {
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`
+
+	// ClientIPHeader names the header the platform in front of the server
+	// puts the client IP in, such as Fly-Client-IP. X-Forwarded-For is not
+	// trusted, so without it the client IP is the address of the peer.
+	ClientIPHeader string `yaml:"client_ip_header"`
+
+	// MaxBodyBytes caps request bodies, and MaxUploadBytes multipart ones
+	// such as poster uploads. Larger requests are answered 413.
+	MaxBodyBytes   int `yaml:"max_body_bytes"`
+	MaxUploadBytes int `yaml:"max_upload_bytes"`
+}
+
+type Database struct {
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	Name     string `yaml:"name"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	SSLMode  string `yaml:"ssl_mode"`
+}
+
+// JWT signs tokens with SigningKey, the ID of one of Keys, or with HS256 and
+// Secret when SigningKey is empty. Keys are PEM files: RSA or Ed25519 private
+// keys sign and verify, public keys of retired signing keys only verify.
+// While Secret is set, HS256 tokens are still accepted.
+type JWT struct {
+	Secret     string   `yaml:"secret"`
+	SigningKey string   `yaml:"signing_key"`
+	Keys       []JWTKey `yaml:"keys"`
+}
+
+type JWTKey struct {
+	ID   string `yaml:"id"`
+	File string `yaml:"file"`
+}
+
+// Login throttles password guessing. After FreeFailures failed logins a
+// username has to wait a second before the next try, twice as long after
+// every further failure, and after MaxFailures it is locked for
+// LockoutDuration. A client IP is locked after IPMaxFailures. Failures older
+// than LockoutDuration are forgotten.
+type Login struct {
+	FreeFailures    int           `yaml:"free_failures"`
+	MaxFailures     int           `yaml:"max_failures"`
+	IPMaxFailures   int           `yaml:"ip_max_failures"`
+	LockoutDuration time.Duration `yaml:"lockout_duration"`
+}
+
+// Password sets the rules for new passwords: at least MinLength characters
+// from at least MinClasses of lowercase letters, uppercase letters, digits and
+// symbols. Passwords are hashed with bcrypt at HashCost, and hashes of another
+// cost are redone at the next login. Reset tokens expire after ResetTokenTTL.
+type Password struct {
+	MinLength     int           `yaml:"min_length"`
+	MinClasses    int           `yaml:"min_classes"`
+	HashCost      int           `yaml:"hash_cost"`
+	ResetTokenTTL time.Duration `yaml:"reset_token_ttl"`
+}
+
+// OIDC lets users log in at an OpenID Connect provider, discovered from
+// Issuer, with the authorization code flow and PKCE. The provider sends users
+// back to RedirectURL; ClientSecret stays empty for public clients. A login
+// has to be finished within LoginTTL. Logins are off while Issuer is empty.
+type OIDC struct {
+	Issuer       string        `yaml:"issuer"`
+	ClientID     string        `yaml:"client_id"`
+	ClientSecret string        `yaml:"client_secret"`
+	RedirectURL  string        `yaml:"redirect_url"`
+	LoginTTL     time.Duration `yaml:"login_ttl"`
+}
+
+// Enabled reports whether OpenID Connect logins are configured.
+func (oidc OIDC) Enabled() bool {
+	return oidc.Issuer != ""
+}
+
+// Notifier delivers messages, such as password reset tokens, to users. Kind
+// none sends nothing, which turns password resets off; log writes messages to
+// the log and file appends them to File as JSON lines, both for local use
+// only.
+type Notifier struct {
+	Kind string `yaml:"kind"`
+	File string `yaml:"file"`
+}
+
+type Firebase struct {
+	CredentialsFile string `yaml:"credentials_file"`
+	Bucket          string `yaml:"bucket"`
+}
+
+// Log sets the lowest level written: debug, info, warn or error.
+type Log struct {
+	Level string `yaml:"level"`
+}
+
+// Tracing exports spans over OTLP/HTTP to Endpoint, e.g.
+// http://localhost:4318. Tracing is off when it is empty.
+type Tracing struct {
+	Endpoint string `yaml:"endpoint"`
+}
+
+// RateLimit throttles each client on every /api route, and on the auth routes
+// with a stricter limit on top. Limits read like 10/1m; 0 turns one off.
+type RateLimit struct {
+	Default ratelimit.Limit `yaml:"default"`
+	Auth    ratelimit.Limit `yaml:"auth"`
+}
+
+// CORS lets browsers on other origins call the API. Routes overrides the
+// policy for each key and the paths below it, so /api/movies covers
+// /api/movies/1 but not /api/moviesx, the longest key winning.
+type CORS struct {
+	CORSPolicy `yaml:",inline"`
+	Routes     map[string]CORSPolicy `yaml:"routes"`
+}
+
+// RoutePolicy returns the policy of Routes under prefix, with the lists and
+// credentials it leaves out and a zero max age taken from the policy of
+// every route.
+func (cors CORS) RoutePolicy(prefix string) CORSPolicy {
+	route := cors.Routes[prefix]
+	if route.AllowedOrigins == nil {
+		route.AllowedOrigins = cors.AllowedOrigins
+	}
+	if route.AllowedMethods == nil {
+		route.AllowedMethods = cors.AllowedMethods
+	}
+	if route.AllowedHeaders == nil {
+		route.AllowedHeaders = cors.AllowedHeaders
+	}
+	if route.ExposedHeaders == nil {
+		route.ExposedHeaders = cors.ExposedHeaders
+	}
+	if route.AllowCredentials == nil {
+		route.AllowCredentials = cors.AllowCredentials
+	}
+	if route.MaxAge == 0 {
+		route.MaxAge = cors.MaxAge
+	}
+	return route
+}
+
+// CORSPolicy lists the origins allowed, exactly as https://efilm.example.com,
+// by subdomain as https://*.efilm.example.com, or all as *. Browsers cache
+// the answers to their preflight requests for MaxAge. AllowCredentials is
+// left nil on a route to inherit it.
+type CORSPolicy struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins"`
+	AllowedMethods   []string      `yaml:"allowed_methods"`
+	AllowedHeaders   []string      `yaml:"allowed_headers"`
+	ExposedHeaders   []string      `yaml:"exposed_headers"`
+	AllowCredentials *bool         `yaml:"allow_credentials"`
+	MaxAge           time.Duration `yaml:"max_age"`
+}
+
+// Credentials tells whether the policy allows credentials, false when
+// AllowCredentials is unset.
+func (cors CORSPolicy) Credentials() bool {
+	return cors.AllowCredentials != nil && *cors.AllowCredentials
+}
+
+// Default returns the settings used for anything left unset.
+func Default() *Config {
+	return &Config{
+		Port: "8080",
+		Mode: "release",
+		Server: Server{
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       60 * time.Second,
+			ShutdownTimeout:   15 * time.Second,
+			MaxBodyBytes:      1 << 20,
+			MaxUploadBytes:    10 << 20,
+		},
+		Database: Database{
+			Port:    "5432",
+			SSLMode: "require",
+		},
+		Login: Login{
+			FreeFailures:    3,
+			MaxFailures:     10,
+			IPMaxFailures:   100,
+			LockoutDuration: 15 * time.Minute,
+		},
+		Password: Password{
+			MinLength:     10,
+			MinClasses:    2,
+			HashCost:      12,
+			ResetTokenTTL: time.Hour,
+		},
+		OIDC: OIDC{
+			LoginTTL: 10 * time.Minute,
+		},
+		Notifier: Notifier{
+			Kind: "none",
+		},
+		Firebase: Firebase{
+			CredentialsFile: "firebase-admin-sdk.json",
+		},
+		Log: Log{
+			Level: "info",
+		},
+		RateLimit: RateLimit{
+			Default: ratelimit.Limit{Requests: 300, Per: time.Minute},
+			Auth:    ratelimit.Limit{Requests: 10, Per: time.Minute},
+		},
+		CORS: CORS{
+			CORSPolicy: CORSPolicy{
+				AllowedOrigins: []string{"*"},
+				AllowedMethods: []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"},
+				AllowedHeaders: []string{"Authorization", "Content-Type", "X-API-Key", "X-Request-ID"},
+				ExposedHeaders: []string{"X-Request-ID", "Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"},
+				MaxAge:         10 * time.Minute,
+			},
+		},
+	}
+}
+
+// Load builds the configuration from, in increasing order of precedence, the
+// defaults, the YAML file named by CONFIG_FILE and the environment. Variables
+// in envFile are added to the environment unless already set there; a missing
+// envFile is not an error.
+func Load(envFile string) (*Config, error) {
+	err := godotenv.Load(envFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read %s: %w", envFile, err)
+	}
+
+	config := Default()
+
+	if file := os.Getenv("CONFIG_FILE"); file != "" {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(raw))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+	}
+
+	for _, variable := range []struct {
+		name  string
+		value *string
+	}{
+		{"APP_PORT", &config.Port},
+		{"GIN_MODE", &config.Mode},
+		{"DB_HOST", &config.Database.Host},
+		{"DB_PORT", &config.Database.Port},
+		{"DB_NAME", &config.Database.Name},
+		{"DB_USER", &config.Database.User},
+		{"DB_PASS", &config.Database.Password},
+		{"DB_SSL_MODE", &config.Database.SSLMode},
+		{"SECRET_KEY_JWT", &config.JWT.Secret},
+		{"JWT_SIGNING_KEY", &config.JWT.SigningKey},
+		{"FIREBASE_CREDENTIALS_FILE", &config.Firebase.CredentialsFile},
+		{"BUCKET_NAME_FIREBASE", &config.Firebase.Bucket},
+		{"LOG_LEVEL", &config.Log.Level},
+		{"OTEL_EXPORTER_OTLP_ENDPOINT", &config.Tracing.Endpoint},
+		{"SERVER_CLIENT_IP_HEADER", &config.Server.ClientIPHeader},
+		{"NOTIFIER_KIND", &config.Notifier.Kind},
+		{"NOTIFIER_FILE", &config.Notifier.File},
+		{"OIDC_ISSUER", &config.OIDC.Issuer},
+		{"OIDC_CLIENT_ID", &config.OIDC.ClientID},
+		{"OIDC_CLIENT_SECRET", &config.OIDC.ClientSecret},
+		{"OIDC_REDIRECT_URL", &config.OIDC.RedirectURL},
+	} {
+		if value, ok := os.LookupEnv(variable.name); ok && value != "" {
+			*variable.value = value
+		}
+	}
+
+	for _, variable := range []struct {
+		name  string
+		value *time.Duration
+	}{
+		{"SERVER_READ_HEADER_TIMEOUT", &config.Server.ReadHeaderTimeout},
+		{"SERVER_READ_TIMEOUT", &config.Server.ReadTimeout},
+		{"SERVER_WRITE_TIMEOUT", &config.Server.WriteTimeout},
+		{"SERVER_IDLE_TIMEOUT", &config.Server.IdleTimeout},
+		{"SERVER_SHUTDOWN_TIMEOUT", &config.Server.ShutdownTimeout},
+		{"LOGIN_LOCKOUT_DURATION", &config.Login.LockoutDuration},
+		{"PASSWORD_RESET_TOKEN_TTL", &config.Password.ResetTokenTTL},
+		{"OIDC_LOGIN_TTL", &config.OIDC.LoginTTL},
+		{"CORS_MAX_AGE", &config.CORS.MaxAge},
+	} {
+		if value := os.Getenv(variable.name); value != "" {
+			*variable.value, err = time.ParseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be a duration such as 10s, got %q", variable.name, value)
+			}
+		}
+	}
+
+	for _, variable := range []struct {
+		name  string
+		value *int
+	}{
+		{"SERVER_MAX_BODY_BYTES", &config.Server.MaxBodyBytes},
+		{"SERVER_MAX_UPLOAD_BYTES", &config.Server.MaxUploadBytes},
+		{"LOGIN_FREE_FAILURES", &config.Login.FreeFailures},
+		{"LOGIN_MAX_FAILURES", &config.Login.MaxFailures},
+		{"LOGIN_IP_MAX_FAILURES", &config.Login.IPMaxFailures},
+		{"PASSWORD_MIN_LENGTH", &config.Password.MinLength},
+		{"PASSWORD_MIN_CLASSES", &config.Password.MinClasses},
+		{"PASSWORD_HASH_COST", &config.Password.HashCost},
+	} {
+		if value := os.Getenv(variable.name); value != "" {
+			*variable.value, err = strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be a whole number, got %q", variable.name, value)
+			}
+		}
+	}
+
+	for _, variable := range []struct {
+		name  string
+		value *ratelimit.Limit
+	}{
+		{"RATE_LIMIT_DEFAULT", &config.RateLimit.Default},
+		{"RATE_LIMIT_AUTH", &config.RateLimit.Auth},
+	} {
+		if value, ok := os.LookupEnv(variable.name); ok {
+			*variable.value, err = ratelimit.ParseLimit(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s must look like 10/1m or be 0, got %q", variable.name, value)
+			}
+		}
+	}
+
+	for _, variable := range []struct {
+		name  string
+		value *[]string
+	}{
+		{"CORS_ALLOWED_ORIGINS", &config.CORS.AllowedOrigins},
+		{"CORS_ALLOWED_METHODS", &config.CORS.AllowedMethods},
+		{"CORS_ALLOWED_HEADERS", &config.CORS.AllowedHeaders},
+		{"CORS_EXPOSED_HEADERS", &config.CORS.ExposedHeaders},
+	} {
+		if value, ok := os.LookupEnv(variable.name); ok {
+			*variable.value = splitList(value)
+		}
+	}
+
+	if value := os.Getenv("JWT_KEYS"); value != "" {
+		config.JWT.Keys, err = parseJWTKeys(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, variable := range []struct {
+		name  string
+		value *bool
+	}{
+		{"CONTRACT_VALIDATION", &config.ContractValidation},
+	} {
+		if value := os.Getenv(variable.name); value != "" {
+			*variable.value, err = strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be true or false, got %q", variable.name, value)
+			}
+		}
+	}
+
+	if value := os.Getenv("CORS_ALLOW_CREDENTIALS"); value != "" {
+		credentials, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("CORS_ALLOW_CREDENTIALS must be true or false, got %q", value)
+		}
+		config.CORS.AllowCredentials = &credentials
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+var (
+	sslModes  = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	logLevels = []string{"debug", "info", "warn", "error"}
+	modes     = []string{"debug", "release", "test"}
+	notifiers = []string{"none", "log", "file"}
+)
+
+// bcrypt only reads the first 72 bytes of a password and takes costs from 4
+// to 31.
+const (
+	maxPasswordLength = 72
+	minHashCost       = 4
+	maxHashCost       = 31
+)
+
+// Validate reports every missing or malformed setting at once, naming both the
+// environment variable and the YAML key that set it.
+func (config *Config) Validate() error {
+	var problems []string
+
+	if !isPort(config.Port) {
+		problems = append(problems, fmt.Sprintf("APP_PORT (port) must be a port number, got %q", config.Port))
+	}
+
+	for _, required := range []struct {
+		name  string
+		value string
+	}{
+		{"DB_HOST (database.host)", config.Database.Host},
+		{"DB_NAME (database.name)", config.Database.Name},
+		{"DB_USER (database.user)", config.Database.User},
+	} {
+		if required.value == "" {
+			problems = append(problems, required.name+" is required")
+		}
+	}
+
+	problems = append(problems, config.JWT.validate()...)
+
+	for _, timeout := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"SERVER_READ_HEADER_TIMEOUT (server.read_header_timeout)", config.Server.ReadHeaderTimeout},
+		{"SERVER_READ_TIMEOUT (server.read_timeout)", config.Server.ReadTimeout},
+		{"SERVER_WRITE_TIMEOUT (server.write_timeout)", config.Server.WriteTimeout},
+		{"SERVER_IDLE_TIMEOUT (server.idle_timeout)", config.Server.IdleTimeout},
+		{"SERVER_SHUTDOWN_TIMEOUT (server.shutdown_timeout)", config.Server.ShutdownTimeout},
+		{"LOGIN_LOCKOUT_DURATION (login.lockout_duration)", config.Login.LockoutDuration},
+		{"PASSWORD_RESET_TOKEN_TTL (password.reset_token_ttl)", config.Password.ResetTokenTTL},
+		{"OIDC_LOGIN_TTL (oidc.login_ttl)", config.OIDC.LoginTTL},
+	} {
+		if timeout.value <= 0 {
+			problems = append(problems, fmt.Sprintf("%s must be positive, got %s", timeout.name, timeout.value))
+		}
+	}
+
+	if config.Server.MaxBodyBytes <= 0 || config.Server.MaxUploadBytes <= 0 {
+		problems = append(problems, fmt.Sprintf("SERVER_MAX_BODY_BYTES (server.max_body_bytes) and SERVER_MAX_UPLOAD_BYTES (server.max_upload_bytes) must be positive, got %d and %d",
+			config.Server.MaxBodyBytes, config.Server.MaxUploadBytes))
+	}
+
+	if config.Login.FreeFailures < 0 || config.Login.MaxFailures <= config.Login.FreeFailures || config.Login.IPMaxFailures <= 0 {
+		problems = append(problems, fmt.Sprintf("LOGIN_FREE_FAILURES (login.free_failures) must be below LOGIN_MAX_FAILURES (login.max_failures) and LOGIN_IP_MAX_FAILURES (login.ip_max_failures) positive, got %d, %d and %d",
+			config.Login.FreeFailures, config.Login.MaxFailures, config.Login.IPMaxFailures))
+	}
+
+	if config.Password.MinLength < 1 || config.Password.MinLength > maxPasswordLength {
+		problems = append(problems, fmt.Sprintf("PASSWORD_MIN_LENGTH (password.min_length) must be from 1 to %d, got %d", maxPasswordLength, config.Password.MinLength))
+	}
+
+	if config.Password.MinClasses < 0 || config.Password.MinClasses > 4 {
+		problems = append(problems, fmt.Sprintf("PASSWORD_MIN_CLASSES (password.min_classes) must be from 0 to 4, got %d", config.Password.MinClasses))
+	}
+
+	if config.Password.HashCost < minHashCost || config.Password.HashCost > maxHashCost {
+		problems = append(problems, fmt.Sprintf("PASSWORD_HASH_COST (password.hash_cost) must be from %d to %d, got %d", minHashCost, maxHashCost, config.Password.HashCost))
+	}
+
+	if !contains(modes, config.Mode) {
+		problems = append(problems, fmt.Sprintf("GIN_MODE (mode) must be one of %s, got %q", strings.Join(modes, ", "), config.Mode))
+	}
+
+	if !contains(notifiers, config.Notifier.Kind) {
+		problems = append(problems, fmt.Sprintf("NOTIFIER_KIND (notifier.kind) must be one of %s, got %q", strings.Join(notifiers, ", "), config.Notifier.Kind))
+	} else if config.Mode == "release" && config.Notifier.Kind != "none" {
+		problems = append(problems, fmt.Sprintf("NOTIFIER_KIND (notifier.kind) cannot be %s when GIN_MODE (mode) is release, it hands reset tokens to whoever reads it", config.Notifier.Kind))
+	} else if config.Notifier.Kind == "file" && config.Notifier.File == "" {
+		problems = append(problems, "NOTIFIER_FILE (notifier.file) is required when NOTIFIER_KIND is file")
+	}
+
+	if !isPort(config.Database.Port) {
+		problems = append(problems, fmt.Sprintf("DB_PORT (database.port) must be a port number, got %q", config.Database.Port))
+	}
+
+	if !contains(sslModes, config.Database.SSLMode) {
+		problems = append(problems, fmt.Sprintf("DB_SSL_MODE (database.ssl_mode) must be one of %s, got %q", strings.Join(sslModes, ", "), config.Database.SSLMode))
+	}
+
+	if !contains(logLevels, config.Log.Level) {
+		problems = append(problems, fmt.Sprintf("LOG_LEVEL (log.level) must be one of %s, got %q", strings.Join(logLevels, ", "), config.Log.Level))
+	}
+
+	if config.OIDC.Enabled() {
+		if !isHTTPURL(config.OIDC.Issuer) {
+			problems = append(problems, fmt.Sprintf("OIDC_ISSUER (oidc.issuer) must be an http or https URL, got %q", config.OIDC.Issuer))
+		}
+		if config.OIDC.ClientID == "" {
+			problems = append(problems, "OIDC_CLIENT_ID (oidc.client_id) is required when OIDC_ISSUER is set")
+		}
+		if !isHTTPURL(config.OIDC.RedirectURL) {
+			problems = append(problems, fmt.Sprintf("OIDC_REDIRECT_URL (oidc.redirect_url) must be an http or https URL when OIDC_ISSUER is set, got %q", config.OIDC.RedirectURL))
+		}
+	}
+
+	problems = append(problems, config.CORS.validate("CORS_", "cors")...)
+	prefixes := make([]string, 0, len(config.CORS.Routes))
+	for prefix := range config.CORS.Routes {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		if !strings.HasPrefix(prefix, "/") {
+			problems = append(problems, fmt.Sprintf("cors.routes keys must be paths, got %q", prefix))
+		}
+		problems = append(problems, config.CORS.RoutePolicy(prefix).validate("", "cors.routes."+prefix)...)
+	}
+
+	if config.Tracing.Endpoint != "" && !isHTTPURL(config.Tracing.Endpoint) {
+		problems = append(problems, fmt.Sprintf("OTEL_EXPORTER_OTLP_ENDPOINT (tracing.endpoint) must be an http or https URL, got %q", config.Tracing.Endpoint))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func (jwt JWT) validate() []string {
+	var problems []string
+
+	if jwt.Secret == "" && jwt.SigningKey == "" {
+		problems = append(problems, "SECRET_KEY_JWT (jwt.secret) or JWT_SIGNING_KEY (jwt.signing_key) is required")
+	}
+
+	ids := map[string]bool{}
+	for _, key := range jwt.Keys {
+		if key.ID == "" || key.File == "" {
+			problems = append(problems, fmt.Sprintf("JWT_KEYS (jwt.keys) entries need an id and a file, got %q and %q", key.ID, key.File))
+		}
+		if ids[key.ID] {
+			problems = append(problems, fmt.Sprintf("JWT_KEYS (jwt.keys) lists key %q twice", key.ID))
+		}
+		ids[key.ID] = true
+	}
+
+	if jwt.SigningKey != "" && !ids[jwt.SigningKey] {
+		problems = append(problems, fmt.Sprintf("JWT_SIGNING_KEY (jwt.signing_key) must be the id of one of JWT_KEYS (jwt.keys), got %q", jwt.SigningKey))
+	}
+
+	return problems
+}
+
+// parseJWTKeys reads JWT_KEYS, a comma separated list of id=file.
+func parseJWTKeys(value string) ([]JWTKey, error) {
+	var keys []JWTKey
+	for _, entry := range strings.Split(value, ",") {
+		id, file, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("JWT_KEYS must look like id=file,id=file, got %q", value)
+		}
+		keys = append(keys, JWTKey{ID: id, File: file})
+	}
+	return keys, nil
+}
+
+func isPort(value string) bool {
+	port, err := strconv.Atoi(value)
+	return err == nil && port > 0 && port < 65536
+}
+
+// validate names the settings of a policy with the variable prefix, if they
+// can be set from the environment, and the YAML key.
+func (cors CORSPolicy) validate(variable, key string) []string {
+	name := func(setting string) string {
+		if variable == "" {
+			return key + "." + setting
+		}
+		return fmt.Sprintf("%s%s (%s.%s)", variable, strings.ToUpper(setting), key, setting)
+	}
+
+	var problems []string
+	for _, origin := range cors.AllowedOrigins {
+		if origin == "*" {
+			if cors.Credentials() {
+				problems = append(problems, fmt.Sprintf("%s cannot be * with %s, browsers refuse it", name("allowed_origins"), name("allow_credentials")))
+			}
+		} else if !isOrigin(origin) {
+			problems = append(problems, fmt.Sprintf("%s must be *, or origins such as https://efilm.example.com or https://*.example.com, got %q", name("allowed_origins"), origin))
+		}
+	}
+	if cors.MaxAge < 0 {
+		problems = append(problems, fmt.Sprintf("%s must not be negative, got %s", name("max_age"), cors.MaxAge))
+	}
+	return problems
+}
+
+// isOrigin reports whether value is a scheme and a host, with an optional
+// port, and a host starting with *. for its subdomains.
+func isOrigin(value string) bool {
+	scheme, host, _ := strings.Cut(value, "://")
+	u, err := url.Parse(scheme + "://" + strings.TrimPrefix(host, "*."))
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" &&
+		u.User == nil && u.Path == "" && u.RawQuery == "" && u.Fragment == "" && !strings.ContainsAny(u.Host, "*")
+}
+
+// splitList splits a comma-separated list, dropping the spaces around items
+// and empty items.
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func isHTTPURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+var variables = []string{
+	"APP_PORT", "GIN_MODE", "DB_HOST", "DB_PORT", "DB_NAME", "DB_USER", "DB_PASS", "DB_SSL_MODE",
+	"SECRET_KEY_JWT", "JWT_SIGNING_KEY", "JWT_KEYS", "FIREBASE_CREDENTIALS_FILE", "BUCKET_NAME_FIREBASE", "LOG_LEVEL", "OTEL_EXPORTER_OTLP_ENDPOINT",
+	"SERVER_CLIENT_IP_HEADER", "RATE_LIMIT_DEFAULT", "RATE_LIMIT_AUTH",
+	"LOGIN_FREE_FAILURES", "LOGIN_MAX_FAILURES", "LOGIN_IP_MAX_FAILURES", "LOGIN_LOCKOUT_DURATION",
+	"PASSWORD_MIN_LENGTH", "PASSWORD_MIN_CLASSES", "PASSWORD_HASH_COST", "PASSWORD_RESET_TOKEN_TTL",
+	"NOTIFIER_KIND", "NOTIFIER_FILE",
+	"OIDC_ISSUER", "OIDC_CLIENT_ID", "OIDC_CLIENT_SECRET", "OIDC_REDIRECT_URL", "OIDC_LOGIN_TTL",
+	"CORS_ALLOWED_ORIGINS", "CORS_ALLOWED_METHODS", "CORS_ALLOWED_HEADERS", "CORS_EXPOSED_HEADERS",
+	"CORS_ALLOW_CREDENTIALS", "CORS_MAX_AGE",
+	"SERVER_READ_HEADER_TIMEOUT", "SERVER_READ_TIMEOUT", "SERVER_WRITE_TIMEOUT",
+	"SERVER_IDLE_TIMEOUT", "SERVER_SHUTDOWN_TIMEOUT", "SERVER_MAX_BODY_BYTES", "SERVER_MAX_UPLOAD_BYTES",
+	"CONTRACT_VALIDATION", "CONFIG_FILE",
+}
+
+// clearEnv unsets every variable Load reads for the duration of the test.
+func clearEnv(t *testing.T) {
+	for _, name := range variables {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	clearEnv(t)
+
+	t.Setenv("CONFIG_FILE", writeFile(t, "config.yaml", `
+port: "9000"
+server:
+  write_timeout: 2m
+database:
+  host: yaml-host
+  name: efilm
+  user: efilm
+  ssl_mode: disable
+jwt:
+  secret: yaml-secret
+rate_limit:
+  default: 100/1m
+  auth: 5/30s
+cors:
+  allowed_origins: ["https://efilm.example.com"]
+  routes:
+    /api/openapi.json:
+      allowed_origins: ["*"]
+      allow_credentials: false
+    /api/movies:
+      allowed_origins: ["https://partner.example.com"]
+`))
+	envFile := writeFile(t, ".env", "DB_HOST=dotenv-host\nSECRET_KEY_JWT=dotenv-secret\n")
+	t.Setenv("SECRET_KEY_JWT", "env-secret")
+	t.Setenv("SERVER_SHUTDOWN_TIMEOUT", "25s")
+	t.Setenv("SERVER_MAX_UPLOAD_BYTES", "2097152")
+	t.Setenv("RATE_LIMIT_DEFAULT", "0")
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://efilm.example.com, https://*.preview.example.com")
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+
+	config, err := Load(envFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "9000", config.Port)
+	assert.Equal(t, "dotenv-host", config.Database.Host)
+	assert.Equal(t, "env-secret", config.JWT.Secret)
+	assert.Equal(t, 2*time.Minute, config.Server.WriteTimeout)
+	assert.Equal(t, 25*time.Second, config.Server.ShutdownTimeout)
+	assert.Equal(t, 5*time.Second, config.Server.ReadHeaderTimeout)
+	assert.Equal(t, 1<<20, config.Server.MaxBodyBytes)
+	assert.Equal(t, 2<<20, config.Server.MaxUploadBytes)
+	assert.Equal(t, "5432", config.Database.Port)
+	assert.Equal(t, "disable", config.Database.SSLMode)
+	assert.Equal(t, "firebase-admin-sdk.json", config.Firebase.CredentialsFile)
+	assert.False(t, config.RateLimit.Default.Enabled())
+	assert.Equal(t, ratelimit.Limit{Requests: 5, Per: 30 * time.Second}, config.RateLimit.Auth)
+	assert.Equal(t, []string{"https://efilm.example.com", "https://*.preview.example.com"}, config.CORS.AllowedOrigins)
+	assert.True(t, config.CORS.Credentials())
+	openapi := config.CORS.RoutePolicy("/api/openapi.json")
+	assert.Equal(t, []string{"*"}, openapi.AllowedOrigins)
+	assert.Equal(t, config.CORS.AllowedMethods, openapi.AllowedMethods)
+	assert.False(t, openapi.Credentials())
+	assert.True(t, config.CORS.RoutePolicy("/api/movies").Credentials())
+}
+
+func TestLoadMissingEnvFile(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("DB_NAME", "efilm")
+	t.Setenv("DB_USER", "efilm")
+	t.Setenv("SECRET_KEY_JWT", "secret")
+	t.Setenv("CONTRACT_VALIDATION", "true")
+
+	config, err := Load(filepath.Join(t.TempDir(), ".env"))
+	assert.NoError(t, err)
+	assert.True(t, config.ContractValidation)
+}
+
+func TestLoadErrors(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("APP_PORT", "http")
+	t.Setenv("DB_SSL_MODE", "on")
+	t.Setenv("LOG_LEVEL", "verbose")
+	t.Setenv("SERVER_MAX_BODY_BYTES", "0")
+	t.Setenv("LOGIN_MAX_FAILURES", "3")
+	t.Setenv("PASSWORD_HASH_COST", "40")
+	t.Setenv("NOTIFIER_KIND", "file")
+	t.Setenv("OIDC_ISSUER", "https://accounts.example.com")
+	t.Setenv("OIDC_REDIRECT_URL", "/callback")
+	t.Setenv("CORS_ALLOWED_ORIGINS", "*,efilm.example.com")
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "collector:4318")
+
+	_, err := Load(filepath.Join(t.TempDir(), ".env"))
+	assert.EqualError(t, err, `invalid configuration: APP_PORT (port) must be a port number, got "http"; `+
+		`DB_HOST (database.host) is required; DB_NAME (database.name) is required; DB_USER (database.user) is required; `+
+		`SECRET_KEY_JWT (jwt.secret) or JWT_SIGNING_KEY (jwt.signing_key) is required; `+
+		`SERVER_MAX_BODY_BYTES (server.max_body_bytes) and SERVER_MAX_UPLOAD_BYTES (server.max_upload_bytes) must be positive, got 0 and 10485760; `+
+		`LOGIN_FREE_FAILURES (login.free_failures) must be below LOGIN_MAX_FAILURES (login.max_failures) and LOGIN_IP_MAX_FAILURES (login.ip_max_failures) positive, got 3, 3 and 100; `+
+		`PASSWORD_HASH_COST (password.hash_cost) must be from 4 to 31, got 40; `+
+		`NOTIFIER_KIND (notifier.kind) cannot be file when GIN_MODE (mode) is release, it hands reset tokens to whoever reads it; `+
+		`DB_SSL_MODE (database.ssl_mode) must be one of disable, allow, prefer, require, verify-ca, verify-full, got "on"; `+
+		`LOG_LEVEL (log.level) must be one of debug, info, warn, error, got "verbose"; `+
+		`OIDC_CLIENT_ID (oidc.client_id) is required when OIDC_ISSUER is set; `+
+		`OIDC_REDIRECT_URL (oidc.redirect_url) must be an http or https URL when OIDC_ISSUER is set, got "/callback"; `+
+		`CORS_ALLOWED_ORIGINS (cors.allowed_origins) cannot be * with CORS_ALLOW_CREDENTIALS (cors.allow_credentials), browsers refuse it; `+
+		`CORS_ALLOWED_ORIGINS (cors.allowed_origins) must be *, or origins such as https://efilm.example.com or https://*.example.com, got "efilm.example.com"; `+
+		`OTEL_EXPORTER_OTLP_ENDPOINT (tracing.endpoint) must be an http or https URL, got "collector:4318"`)
+
+	t.Setenv("CONFIG_FILE", writeFile(t, "config.yaml", "databse:\n  host: typo\n"))
+	_, err = Load(filepath.Join(t.TempDir(), ".env"))
+	assert.ErrorContains(t, err, "field databse not found")
+}
+
+func TestLoadNotifier(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("DB_NAME", "efilm")
+	t.Setenv("DB_USER", "efilm")
+	t.Setenv("SECRET_KEY_JWT", "secret")
+
+	config, err := Load(filepath.Join(t.TempDir(), ".env"))
+	assert.NoError(t, err)
+	assert.Equal(t, "release", config.Mode)
+	assert.Equal(t, "none", config.Notifier.Kind)
+
+	// The local notifiers are fine outside release mode.
+	t.Setenv("GIN_MODE", "debug")
+	t.Setenv("NOTIFIER_KIND", "log")
+	_, err = Load(filepath.Join(t.TempDir(), ".env"))
+	assert.NoError(t, err)
+
+	t.Setenv("NOTIFIER_KIND", "file")
+	_, err = Load(filepath.Join(t.TempDir(), ".env"))
+	assert.EqualError(t, err, "invalid configuration: NOTIFIER_FILE (notifier.file) is required when NOTIFIER_KIND is file")
+
+	t.Setenv("GIN_MODE", "production")
+	t.Setenv("NOTIFIER_KIND", "none")
+	_, err = Load(filepath.Join(t.TempDir(), ".env"))
+	assert.EqualError(t, err, `invalid configuration: GIN_MODE (mode) must be one of debug, release, test, got "production"`)
+}
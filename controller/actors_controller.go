@@ -1,9 +1,10 @@
 package controller
 
 import (
-	"context"
 	"fmt"
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
 	"github.com/dimassfeb-09/efilm-api.git/services"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -17,6 +18,7 @@ type ActorController interface {
 	FindByID(ctx *gin.Context)
 	FindBySearch(ctx *gin.Context)
 	FindAll(ctx *gin.Context)
+	FindMovies(ctx *gin.Context)
 }
 
 type ActorControllerImpl struct {
@@ -32,19 +34,21 @@ func (c *ActorControllerImpl) Save(gc *gin.Context) {
 	err := gc.ShouldBind(&r)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
 
-	err = c.ActorService.Save(context.Background(), &r)
+	err = c.ActorService.Save(gc.Request.Context(), &r)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -61,9 +65,10 @@ func (c *ActorControllerImpl) Update(gc *gin.Context) {
 	ID, err := strconv.Atoi(gc.Param("id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -72,9 +77,10 @@ func (c *ActorControllerImpl) Update(gc *gin.Context) {
 	err = gc.ShouldBind(&r)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -83,9 +89,10 @@ func (c *ActorControllerImpl) Update(gc *gin.Context) {
 	err = c.ActorService.Update(gc.Request.Context(), &r)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -102,9 +109,10 @@ func (c *ActorControllerImpl) Delete(gc *gin.Context) {
 	ID, err := strconv.Atoi(gc.Param("id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -112,9 +120,10 @@ func (c *ActorControllerImpl) Delete(gc *gin.Context) {
 	err = c.ActorService.Delete(gc.Request.Context(), ID)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -131,9 +140,10 @@ func (c *ActorControllerImpl) FindByID(gc *gin.Context) {
 	id, err := strconv.Atoi(gc.Param("id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -141,9 +151,10 @@ func (c *ActorControllerImpl) FindByID(gc *gin.Context) {
 	result, err := c.ActorService.FindByID(gc.Request.Context(), id)
 	if err != nil {
 		gc.JSON(http.StatusOK, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -168,9 +179,10 @@ func (c *ActorControllerImpl) FindBySearch(gc *gin.Context) {
 		idInt, err := strconv.Atoi(id)
 		if err != nil {
 			gc.JSON(http.StatusBadRequest, web.ResponseError{
-				Code:    http.StatusBadRequest,
-				Status:  "Status Bad Request",
-				Message: "Invalid format ID",
+				Code:      http.StatusBadRequest,
+				Status:    "Status Bad Request",
+				Message:   "Invalid format ID",
+				RequestID: logging.RequestID(gc.Request.Context()),
 			})
 			return
 		}
@@ -178,9 +190,10 @@ func (c *ActorControllerImpl) FindBySearch(gc *gin.Context) {
 		result, err := c.ActorService.FindByNational(gc.Request.Context(), idInt)
 		if err != nil {
 			gc.JSON(http.StatusOK, web.ResponseError{
-				Code:    http.StatusBadRequest,
-				Status:  "Status Bad Request",
-				Message: err.Error(),
+				Code:      http.StatusBadRequest,
+				Status:    "Status Bad Request",
+				Message:   err.Error(),
+				RequestID: logging.RequestID(gc.Request.Context()),
 			})
 			return
 		}
@@ -191,9 +204,10 @@ func (c *ActorControllerImpl) FindBySearch(gc *gin.Context) {
 		result, err := c.ActorService.FindByName(gc.Request.Context(), name)
 		if err != nil {
 			gc.JSON(http.StatusOK, web.ResponseError{
-				Code:    http.StatusBadRequest,
-				Status:  "Status Bad Request",
-				Message: err.Error(),
+				Code:      http.StatusBadRequest,
+				Status:    "Status Bad Request",
+				Message:   err.Error(),
+				RequestID: logging.RequestID(gc.Request.Context()),
 			})
 			return
 		}
@@ -217,9 +231,10 @@ func (c *ActorControllerImpl) FindAll(gc *gin.Context) {
 	results, err := c.ActorService.FindAll(gc.Request.Context())
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Failed get all data actors",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Failed get all data actors",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -242,3 +257,55 @@ func (c *ActorControllerImpl) FindAll(gc *gin.Context) {
 		Data:    responses,
 	})
 }
+
+func (c *ActorControllerImpl) FindMovies(gc *gin.Context) {
+	ID, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	if sort := gc.Query("sort"); sort != "" && sort != "release_date" {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   fmt.Sprintf("Cannot sort by %s, only release_date", sort),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	ascending, err := helpers.ParseSortOrder(gc.Query("order"), false)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	result, err := c.ActorService.FindMovies(gc.Request.Context(), ID, ascending)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	gc.JSON(http.StatusOK, web.ResponseSuccessWithData{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Success get data movies by actor",
+		Data:    result,
+	})
+}
@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/dimassfeb-09/efilm-api.git/middlewares"
+	"github.com/dimassfeb-09/efilm-api.git/services"
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyController interface {
+	Create(c *gin.Context)
+	FindAll(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type APIKeyControllerImpl struct {
+	APIKeyService services.APIKeyService
+}
+
+func NewAPIKeyControllerImpl(apiKeyService services.APIKeyService) APIKeyController {
+	return &APIKeyControllerImpl{APIKeyService: apiKeyService}
+}
+
+func (controller *APIKeyControllerImpl) Create(c *gin.Context) {
+	// RequireRole has authenticated the user already.
+	user, _ := middlewares.CurrentUser(c)
+
+	var r web.APIKeyRequest
+	err := c.ShouldBind(&r)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return
+	}
+
+	response, err := controller.APIKeyService.Create(c.Request.Context(), user.UserID, &r)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, web.ResponseSuccessWithData{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Successfully created API key, store it now as it is not shown again",
+		Data:    response,
+	})
+}
+
+func (controller *APIKeyControllerImpl) FindAll(c *gin.Context) {
+	responses, err := controller.APIKeyService.FindAll(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Failed get all API keys",
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, web.ResponseSuccessWithData{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Success get data",
+		Data:    responses,
+	})
+}
+
+func (controller *APIKeyControllerImpl) Delete(c *gin.Context) {
+	ID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return
+	}
+
+	err = controller.APIKeyService.Delete(c.Request.Context(), ID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, web.ResponseSuccess{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: fmt.Sprintf("Success delete API key with ID %d", ID),
+	})
+}
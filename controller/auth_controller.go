@@ -1,11 +1,14 @@
 package controller
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
 	"github.com/dimassfeb-09/efilm-api.git/services"
 	"github.com/gin-gonic/gin"
 )
@@ -13,6 +16,9 @@ import (
 type AuthController interface {
 	Register(gc *gin.Context)
 	Login(ctx *gin.Context)
+	Unlock(gc *gin.Context)
+	ForgotPassword(gc *gin.Context)
+	ResetPassword(gc *gin.Context)
 }
 
 type AuthControllerImpl struct {
@@ -28,19 +34,21 @@ func (c *AuthControllerImpl) Register(gc *gin.Context) {
 	err := gc.ShouldBind(&r)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
 
-	err = c.AuthService.Register(context.Background(), &r)
+	err = c.AuthService.Register(gc.Request.Context(), &r)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -58,19 +66,33 @@ func (c *AuthControllerImpl) Login(gc *gin.Context) {
 	err := gc.ShouldBind(&r)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
 
-	token, err := c.AuthService.Login(gc.Request.Context(), &r)
+	token, err := c.AuthService.Login(gc.Request.Context(), &r, gc.ClientIP())
+	var locked *services.LockedError
+	if errors.As(err, &locked) {
+		retryAfter := int(math.Ceil(locked.RetryAfter.Seconds()))
+		gc.Header("Retry-After", strconv.Itoa(retryAfter))
+		gc.JSON(http.StatusTooManyRequests, web.ResponseError{
+			Code:      http.StatusTooManyRequests,
+			Status:    "Status Too Many Requests",
+			Message:   fmt.Sprintf("Too many failed logins, try again in %d seconds", retryAfter),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -86,3 +108,115 @@ func (c *AuthControllerImpl) Login(gc *gin.Context) {
 		},
 	})
 }
+
+func (c *AuthControllerImpl) Unlock(gc *gin.Context) {
+	var r web.UnlockLoginRequest
+	err := gc.ShouldBind(&r)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	err = c.AuthService.Unlock(gc.Request.Context(), &r)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	gc.JSON(http.StatusOK, web.ResponseSuccess{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Successfully unlocked login",
+	})
+}
+
+func (c *AuthControllerImpl) ForgotPassword(gc *gin.Context) {
+	var r web.ForgotPasswordRequest
+	err := gc.ShouldBind(&r)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	err = c.AuthService.ForgotPassword(gc.Request.Context(), &r)
+	if errors.Is(err, services.ErrNoNotifier) {
+		gc.JSON(http.StatusNotImplemented, web.ResponseError{
+			Code:      http.StatusNotImplemented,
+			Status:    "Status Not Implemented",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	// Unknown usernames get the same answer, so it tells nobody which exist.
+	gc.JSON(http.StatusOK, web.ResponseSuccess{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "If the user exists, a reset token was sent",
+	})
+}
+
+func (c *AuthControllerImpl) ResetPassword(gc *gin.Context) {
+	var r web.ResetPasswordRequest
+	err := gc.ShouldBind(&r)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	err = c.AuthService.ResetPassword(gc.Request.Context(), &r)
+	if errors.Is(err, services.ErrNoNotifier) {
+		gc.JSON(http.StatusNotImplemented, web.ResponseError{
+			Code:      http.StatusNotImplemented,
+			Status:    "Status Not Implemented",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	gc.JSON(http.StatusOK, web.ResponseSuccess{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Successfully reset password",
+	})
+}
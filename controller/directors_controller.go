@@ -1,9 +1,10 @@
 package controller
 
 import (
-	"context"
 	"fmt"
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
 	"github.com/dimassfeb-09/efilm-api.git/services"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -17,6 +18,7 @@ type DirectorController interface {
 	FindByID(ctx *gin.Context)
 	FindBySearch(ctx *gin.Context)
 	FindAll(ctx *gin.Context)
+	FindMovies(ctx *gin.Context)
 }
 
 type DirectorControllerImpl struct {
@@ -32,19 +34,21 @@ func (c *DirectorControllerImpl) Save(gc *gin.Context) {
 	err := gc.ShouldBind(&r)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
 
-	err = c.DirectorService.Save(context.Background(), &r)
+	err = c.DirectorService.Save(gc.Request.Context(), &r)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -61,9 +65,10 @@ func (c *DirectorControllerImpl) Update(gc *gin.Context) {
 	ID, err := strconv.Atoi(gc.Param("id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -72,9 +77,10 @@ func (c *DirectorControllerImpl) Update(gc *gin.Context) {
 	err = gc.ShouldBind(&r)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -83,9 +89,10 @@ func (c *DirectorControllerImpl) Update(gc *gin.Context) {
 	err = c.DirectorService.Update(gc.Request.Context(), &r)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -101,9 +108,10 @@ func (c *DirectorControllerImpl) Delete(gc *gin.Context) {
 	ID, err := strconv.Atoi(gc.Param("id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -111,9 +119,10 @@ func (c *DirectorControllerImpl) Delete(gc *gin.Context) {
 	err = c.DirectorService.Delete(gc.Request.Context(), ID)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -129,9 +138,10 @@ func (c *DirectorControllerImpl) FindByID(gc *gin.Context) {
 	id, err := strconv.Atoi(gc.Param("id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -139,9 +149,10 @@ func (c *DirectorControllerImpl) FindByID(gc *gin.Context) {
 	result, err := c.DirectorService.FindByID(gc.Request.Context(), id)
 	if err != nil {
 		gc.JSON(http.StatusOK, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -166,9 +177,10 @@ func (c *DirectorControllerImpl) FindBySearch(gc *gin.Context) {
 		result, err := c.DirectorService.FindByName(gc.Request.Context(), name)
 		if err != nil {
 			gc.JSON(http.StatusOK, web.ResponseError{
-				Code:    http.StatusBadRequest,
-				Status:  "Status Bad Request",
-				Message: err.Error(),
+				Code:      http.StatusBadRequest,
+				Status:    "Status Bad Request",
+				Message:   err.Error(),
+				RequestID: logging.RequestID(gc.Request.Context()),
 			})
 			return
 		}
@@ -179,9 +191,10 @@ func (c *DirectorControllerImpl) FindBySearch(gc *gin.Context) {
 		idInt, err := strconv.Atoi(id)
 		if err != nil {
 			gc.JSON(http.StatusBadRequest, web.ResponseError{
-				Code:    http.StatusBadRequest,
-				Status:  "Status Bad Request",
-				Message: "Invalid format ID",
+				Code:      http.StatusBadRequest,
+				Status:    "Status Bad Request",
+				Message:   "Invalid format ID",
+				RequestID: logging.RequestID(gc.Request.Context()),
 			})
 			return
 		}
@@ -189,9 +202,10 @@ func (c *DirectorControllerImpl) FindBySearch(gc *gin.Context) {
 		result, err := c.DirectorService.FindByID(gc.Request.Context(), idInt)
 		if err != nil {
 			gc.JSON(http.StatusOK, web.ResponseError{
-				Code:    http.StatusBadRequest,
-				Status:  "Status Bad Request",
-				Message: err.Error(),
+				Code:      http.StatusBadRequest,
+				Status:    "Status Bad Request",
+				Message:   err.Error(),
+				RequestID: logging.RequestID(gc.Request.Context()),
 			})
 			return
 		}
@@ -215,9 +229,10 @@ func (c *DirectorControllerImpl) FindAll(gc *gin.Context) {
 	results, err := c.DirectorService.FindAll(gc.Request.Context())
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Failed get all data directors",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Failed get all data directors",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -240,3 +255,55 @@ func (c *DirectorControllerImpl) FindAll(gc *gin.Context) {
 		Data:    responses,
 	})
 }
+
+func (c *DirectorControllerImpl) FindMovies(gc *gin.Context) {
+	ID, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	if sort := gc.Query("sort"); sort != "" && sort != "release_date" {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   fmt.Sprintf("Cannot sort by %s, only release_date", sort),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	ascending, err := helpers.ParseSortOrder(gc.Query("order"), false)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	result, err := c.DirectorService.FindMovies(gc.Request.Context(), ID, ascending)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	gc.JSON(http.StatusOK, web.ResponseSuccessWithData{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Success get data movies by director",
+		Data:    result,
+	})
+}
@@ -1,9 +1,9 @@
 package controller
 
 import (
-	"context"
 	"fmt"
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
 	"github.com/dimassfeb-09/efilm-api.git/services"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -33,27 +33,30 @@ func (controller *GenreControllerImpl) Save(c *gin.Context) {
 	err := c.ShouldBind(&r)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
 
-	err = controller.GenreService.Save(context.Background(), &r)
+	err = controller.GenreService.Save(c.Request.Context(), &r)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, web.ResponseError{
-		Code:    http.StatusOK,
-		Status:  "OK",
-		Message: "Successfully create data genre",
+		Code:      http.StatusOK,
+		Status:    "OK",
+		Message:   "Successfully create data genre",
+		RequestID: logging.RequestID(c.Request.Context()),
 	})
 	return
 
@@ -63,9 +66,10 @@ func (controller *GenreControllerImpl) Update(c *gin.Context) {
 	ID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -74,9 +78,10 @@ func (controller *GenreControllerImpl) Update(c *gin.Context) {
 	err = c.ShouldBind(&r)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -85,9 +90,10 @@ func (controller *GenreControllerImpl) Update(c *gin.Context) {
 	err = controller.GenreService.Update(c.Request.Context(), &r)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -103,9 +109,10 @@ func (controller *GenreControllerImpl) Delete(c *gin.Context) {
 	ID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -113,9 +120,10 @@ func (controller *GenreControllerImpl) Delete(c *gin.Context) {
 	err = controller.GenreService.Delete(c.Request.Context(), ID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -131,9 +139,10 @@ func (controller *GenreControllerImpl) FindByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -141,9 +150,10 @@ func (controller *GenreControllerImpl) FindByID(c *gin.Context) {
 	result, err := controller.GenreService.FindByID(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusOK, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -167,18 +177,20 @@ func (controller *GenreControllerImpl) FindBySearch(c *gin.Context) {
 		result, err := controller.GenreService.FindByName(c.Request.Context(), name)
 		if err != nil {
 			c.JSON(http.StatusOK, web.ResponseError{
-				Code:    http.StatusBadRequest,
-				Status:  "Status Bad Request",
-				Message: err.Error(),
+				Code:      http.StatusBadRequest,
+				Status:    "Status Bad Request",
+				Message:   err.Error(),
+				RequestID: logging.RequestID(c.Request.Context()),
 			})
 			return
 		}
 		genres = append(genres, result)
 	} else {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Query name is required",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Query name is required",
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -200,9 +212,10 @@ func (controller *GenreControllerImpl) FindAll(c *gin.Context) {
 	results, err := controller.GenreService.FindAll(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Failed get all data genres",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Failed get all data genres",
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -226,9 +239,10 @@ func (controller *GenreControllerImpl) FindAllMoviesByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -236,9 +250,10 @@ func (controller *GenreControllerImpl) FindAllMoviesByID(c *gin.Context) {
 	responses, err := controller.GenreService.FindAllMoviesByID(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Failed get all data genres",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Failed get all data genres",
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/services"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthController interface {
+	Liveness(c *gin.Context)
+	Readiness(c *gin.Context)
+}
+
+type HealthControllerImpl struct {
+	HealthService services.HealthService
+}
+
+func NewHealthControllerImpl(healthService services.HealthService) HealthController {
+	return &HealthControllerImpl{HealthService: healthService}
+}
+
+// Liveness answers as long as the process can serve requests at all.
+func (controller *HealthControllerImpl) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, web.ResponseSuccess{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Alive",
+	})
+}
+
+func (controller *HealthControllerImpl) Readiness(c *gin.Context) {
+	result, ready := controller.HealthService.Readiness(c.Request.Context())
+	if !ready {
+		c.JSON(http.StatusServiceUnavailable, web.ResponseSuccessWithData{
+			Code:    http.StatusServiceUnavailable,
+			Status:  "Status Service Unavailable",
+			Message: "Not ready",
+			Data:    result,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, web.ResponseSuccessWithData{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Ready",
+		Data:    result,
+	})
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
 	"github.com/dimassfeb-09/efilm-api.git/services"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -12,6 +13,7 @@ type MovieActorController interface {
 	Save(gc *gin.Context)
 	Update(ctx *gin.Context)
 	Delete(ctx *gin.Context)
+	ReplaceCast(ctx *gin.Context)
 	FindByID(ctx *gin.Context)
 }
 
@@ -27,9 +29,10 @@ func (controller *MovieActorControllerImpl) Save(gc *gin.Context) {
 	movieID, err := strconv.Atoi(gc.Param("movie_id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format Movie ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format Movie ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -38,9 +41,10 @@ func (controller *MovieActorControllerImpl) Save(gc *gin.Context) {
 	err = gc.ShouldBind(&movieActor)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format Movie ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format Movie ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -49,17 +53,19 @@ func (controller *MovieActorControllerImpl) Save(gc *gin.Context) {
 	err = controller.MovieActorService.Save(gc.Request.Context(), &movieActor)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
 
 	gc.JSON(http.StatusOK, web.ResponseError{
-		Code:    http.StatusOK,
-		Status:  "OK",
-		Message: "Successfully created actors at movie",
+		Code:      http.StatusOK,
+		Status:    "OK",
+		Message:   "Successfully created actors at movie",
+		RequestID: logging.RequestID(gc.Request.Context()),
 	})
 	return
 }
@@ -69,9 +75,10 @@ func (controller *MovieActorControllerImpl) Update(gc *gin.Context) {
 	movieID, err := strconv.Atoi(gc.Param("movie_id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format Movie ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format Movie ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -79,9 +86,10 @@ func (controller *MovieActorControllerImpl) Update(gc *gin.Context) {
 	actorID, err := strconv.Atoi(gc.Param("actor_id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format Actor ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format Actor ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -90,9 +98,10 @@ func (controller *MovieActorControllerImpl) Update(gc *gin.Context) {
 	err = gc.ShouldBind(&movieActor)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -102,17 +111,19 @@ func (controller *MovieActorControllerImpl) Update(gc *gin.Context) {
 	err = controller.MovieActorService.Update(gc.Request.Context(), &movieActor)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
 
 	gc.JSON(http.StatusOK, web.ResponseError{
-		Code:    http.StatusOK,
-		Status:  "OK",
-		Message: "Successfully update actors from movie",
+		Code:      http.StatusOK,
+		Status:    "OK",
+		Message:   "Successfully update actors from movie",
+		RequestID: logging.RequestID(gc.Request.Context()),
 	})
 	return
 }
@@ -121,9 +132,10 @@ func (controller *MovieActorControllerImpl) Delete(gc *gin.Context) {
 	actorID, err := strconv.Atoi(gc.Param("actor_id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -131,17 +143,64 @@ func (controller *MovieActorControllerImpl) Delete(gc *gin.Context) {
 	err = controller.MovieActorService.Delete(gc.Request.Context(), actorID)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
 
 	gc.JSON(http.StatusOK, web.ResponseError{
+		Code:      http.StatusOK,
+		Status:    "OK",
+		Message:   "Successfully deleted actors from movie",
+		RequestID: logging.RequestID(gc.Request.Context()),
+	})
+	return
+}
+
+func (controller *MovieActorControllerImpl) ReplaceCast(gc *gin.Context) {
+	movieID, err := strconv.Atoi(gc.Param("movie_id"))
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format Movie ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	var cast web.MovieActorModelRequestBatch
+	err = gc.ShouldBind(&cast)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	cast.MovieID = movieID
+	result, err := controller.MovieActorService.ReplaceCast(gc.Request.Context(), &cast)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	gc.JSON(http.StatusOK, web.ResponseSuccessWithData{
 		Code:    http.StatusOK,
 		Status:  "OK",
-		Message: "Successfully deleted actors from movie",
+		Message: "Successfully replaced actors at movie",
+		Data:    result,
 	})
 	return
 }
@@ -150,9 +209,10 @@ func (controller *MovieActorControllerImpl) FindByID(gc *gin.Context) {
 	movieID, err := strconv.Atoi(gc.Param("movie_id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -160,9 +220,10 @@ func (controller *MovieActorControllerImpl) FindByID(gc *gin.Context) {
 	result, err := controller.MovieActorService.FindByID(gc.Request.Context(), movieID)
 	if err != nil {
 		gc.JSON(http.StatusOK, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
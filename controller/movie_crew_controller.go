@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/dimassfeb-09/efilm-api.git/services"
+	"github.com/gin-gonic/gin"
+)
+
+type MovieCrewController interface {
+	Save(gc *gin.Context)
+	Delete(gc *gin.Context)
+	FindByID(gc *gin.Context)
+	FindByPerson(gc *gin.Context)
+	FindAllJobs(gc *gin.Context)
+}
+
+type MovieCrewControllerImpl struct {
+	MovieCrewService services.MovieCrewService
+}
+
+func NewMovieCrewControllerImpl(movieCrewService services.MovieCrewService) MovieCrewController {
+	return &MovieCrewControllerImpl{MovieCrewService: movieCrewService}
+}
+
+func (controller *MovieCrewControllerImpl) Save(gc *gin.Context) {
+	movieID, err := strconv.Atoi(gc.Param("movie_id"))
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format movie_id",
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	var movieCrew web.MovieCrewModelRequestPost
+	err = gc.ShouldBind(&movieCrew)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	movieCrew.MovieID = movieID
+	err = controller.MovieCrewService.Save(gc.Request.Context(), &movieCrew)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	gc.JSON(http.StatusOK, web.ResponseSuccess{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Successfully created crew at movie",
+	})
+}
+
+func (controller *MovieCrewControllerImpl) Delete(gc *gin.Context) {
+	movieID, err := strconv.Atoi(gc.Param("movie_id"))
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format movie_id",
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	personID, err := strconv.Atoi(gc.Param("person_id"))
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format person_id",
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	err = controller.MovieCrewService.Delete(gc.Request.Context(), movieID, personID, gc.Param("job"))
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	gc.JSON(http.StatusOK, web.ResponseSuccess{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Successfully deleted crew from movie",
+	})
+}
+
+func (controller *MovieCrewControllerImpl) FindByID(gc *gin.Context) {
+	movieID, err := strconv.Atoi(gc.Param("movie_id"))
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format movie_id",
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	result, err := controller.MovieCrewService.FindByID(gc.Request.Context(), movieID, gc.Query("job"))
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	gc.JSON(http.StatusOK, web.ResponseSuccessWithData{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Success get data crew from movie",
+		Data:    result,
+	})
+}
+
+func (controller *MovieCrewControllerImpl) FindByPerson(gc *gin.Context) {
+	personID, err := strconv.Atoi(gc.Param("person_id"))
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format person_id",
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	result, err := controller.MovieCrewService.FindByPerson(gc.Request.Context(), personID)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	gc.JSON(http.StatusOK, web.ResponseSuccessWithData{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Success get crew credits by person",
+		Data:    result,
+	})
+}
+
+func (controller *MovieCrewControllerImpl) FindAllJobs(gc *gin.Context) {
+	results, err := controller.MovieCrewService.FindAllJobs(gc.Request.Context())
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Failed get all crew jobs",
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	gc.JSON(http.StatusOK, web.ResponseSuccessWithData{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Success get data",
+		Data:    results,
+	})
+}
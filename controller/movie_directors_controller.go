@@ -2,6 +2,7 @@ package controller
 
 import (
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
 	"github.com/dimassfeb-09/efilm-api.git/services"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -27,9 +28,10 @@ func (controller *MovieDirectorControllerImpl) Save(gc *gin.Context) {
 	movieID, err := strconv.Atoi(gc.Param("movie_id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format movie_id",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format movie_id",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -38,9 +40,10 @@ func (controller *MovieDirectorControllerImpl) Save(gc *gin.Context) {
 	err = gc.ShouldBind(&movieActor)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -50,17 +53,19 @@ func (controller *MovieDirectorControllerImpl) Save(gc *gin.Context) {
 	err = controller.MovieDirectorService.Save(gc.Request.Context(), &movieActor)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
 
 	gc.JSON(http.StatusOK, web.ResponseError{
-		Code:    http.StatusOK,
-		Status:  "OK",
-		Message: "Successfully created directors at movie",
+		Code:      http.StatusOK,
+		Status:    "OK",
+		Message:   "Successfully created directors at movie",
+		RequestID: logging.RequestID(gc.Request.Context()),
 	})
 	return
 }
@@ -69,9 +74,10 @@ func (controller *MovieDirectorControllerImpl) Delete(gc *gin.Context) {
 	movieID, err := strconv.Atoi(gc.Param("movie_id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format movie_id",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format movie_id",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -79,9 +85,10 @@ func (controller *MovieDirectorControllerImpl) Delete(gc *gin.Context) {
 	directorID, err := strconv.Atoi(gc.Param("director_id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format director_id",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format director_id",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -89,17 +96,19 @@ func (controller *MovieDirectorControllerImpl) Delete(gc *gin.Context) {
 	err = controller.MovieDirectorService.Delete(gc.Request.Context(), movieID, directorID)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
 
 	gc.JSON(http.StatusOK, web.ResponseError{
-		Code:    http.StatusOK,
-		Status:  "OK",
-		Message: "Successfully deleted directors from movie",
+		Code:      http.StatusOK,
+		Status:    "OK",
+		Message:   "Successfully deleted directors from movie",
+		RequestID: logging.RequestID(gc.Request.Context()),
 	})
 	return
 }
@@ -108,9 +117,10 @@ func (controller *MovieDirectorControllerImpl) FindByID(gc *gin.Context) {
 	movieID, err := strconv.Atoi(gc.Param("movie_id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -118,9 +128,10 @@ func (controller *MovieDirectorControllerImpl) FindByID(gc *gin.Context) {
 	result, err := controller.MovieDirectorService.FindByID(gc.Request.Context(), movieID)
 	if err != nil {
 		gc.JSON(http.StatusOK, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
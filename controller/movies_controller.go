@@ -1,10 +1,11 @@
 package controller
 
 import (
-	"context"
 	"fmt"
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
 	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/dimassfeb-09/efilm-api.git/middlewares"
 	"github.com/dimassfeb-09/efilm-api.git/services"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -34,19 +35,21 @@ func (controller *MovieControllerImpl) Save(c *gin.Context) {
 	err := c.ShouldBind(&r)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
 
-	id, err := controller.MovieService.Save(context.Background(), &r)
+	id, err := controller.MovieService.Save(c.Request.Context(), &r)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -68,9 +71,10 @@ func (controller *MovieControllerImpl) Update(c *gin.Context) {
 	ID, err := strconv.Atoi(c.Param("movie_id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -79,9 +83,10 @@ func (controller *MovieControllerImpl) Update(c *gin.Context) {
 	err = c.ShouldBind(&r)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -90,9 +95,10 @@ func (controller *MovieControllerImpl) Update(c *gin.Context) {
 	err = controller.MovieService.Update(c.Request.Context(), &r)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -110,19 +116,30 @@ func (controller *MovieControllerImpl) UploadPoster(c *gin.Context) {
 	ID, err := strconv.Atoi(c.Param("movie_id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format movie ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format movie ID",
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
 
 	fileHeader, err := c.FormFile("poster_file")
+	if limit, ok := middlewares.TooLarge(err); ok {
+		c.JSON(http.StatusRequestEntityTooLarge, web.ResponseError{
+			Code:      http.StatusRequestEntityTooLarge,
+			Status:    "Status Request Entity Too Large",
+			Message:   fmt.Sprintf("Poster file is larger than %d bytes", limit),
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Cannot process file.",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Cannot process file.",
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -131,9 +148,10 @@ func (controller *MovieControllerImpl) UploadPoster(c *gin.Context) {
 	isValid := helpers.VerfiyFileType(contentType)
 	if isValid == false {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: fmt.Sprintf("File %s not accept, only image/png, image/jpg, image/jpeg", contentType),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   fmt.Sprintf("File %s not accept, only image/png, image/jpg, image/jpeg", contentType),
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -141,9 +159,10 @@ func (controller *MovieControllerImpl) UploadPoster(c *gin.Context) {
 	err = controller.MovieService.UploadFile(c.Request.Context(), ID, fileHeader)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -160,9 +179,10 @@ func (controller *MovieControllerImpl) Delete(c *gin.Context) {
 	ID, err := strconv.Atoi(c.Param("movie_id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -170,9 +190,10 @@ func (controller *MovieControllerImpl) Delete(c *gin.Context) {
 	err = controller.MovieService.Delete(c.Request.Context(), ID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -190,19 +211,22 @@ func (controller *MovieControllerImpl) FindByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("movie_id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
 
-	result, err := controller.MovieService.FindByID(c.Request.Context(), id)
+	user, _ := middlewares.CurrentUser(c)
+	result, err := controller.MovieService.FindByID(c.Request.Context(), id, user.UserID)
 	if err != nil {
 		c.JSON(http.StatusOK, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -226,9 +250,10 @@ func (controller *MovieControllerImpl) FindBySearch(c *gin.Context) {
 		result, err := controller.MovieService.FindByTitle(c.Request.Context(), title)
 		if err != nil {
 			c.JSON(http.StatusOK, web.ResponseError{
-				Code:    http.StatusBadRequest,
-				Status:  "Status Bad Request",
-				Message: err.Error(),
+				Code:      http.StatusBadRequest,
+				Status:    "Status Bad Request",
+				Message:   err.Error(),
+				RequestID: logging.RequestID(c.Request.Context()),
 			})
 			return
 		}
@@ -249,12 +274,14 @@ func (controller *MovieControllerImpl) FindBySearch(c *gin.Context) {
 func (controller *MovieControllerImpl) FindAll(c *gin.Context) {
 
 	var responses []*web.MovieModelResponse
-	results, err := controller.MovieService.FindAll(c.Request.Context())
+	user, _ := middlewares.CurrentUser(c)
+	results, err := controller.MovieService.FindAll(c.Request.Context(), user.UserID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Failed get all data movies",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Failed get all data movies",
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
@@ -272,6 +299,7 @@ func (controller *MovieControllerImpl) FindAll(c *gin.Context) {
 			NationalID:  result.NationalID,
 			CreatedAt:   result.CreatedAt,
 			UpdatedAt:   result.UpdatedAt,
+			Preferred:   result.Preferred,
 		}
 		responses = append(responses, &response)
 	}
@@ -1,9 +1,9 @@
 package controller
 
 import (
-	"context"
 	"fmt"
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
 	"github.com/dimassfeb-09/efilm-api.git/services"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -32,27 +32,30 @@ func (c *NationalControllerImpl) Save(gc *gin.Context) {
 	err := gc.ShouldBind(&r)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
 
-	err = c.NationalService.Save(context.Background(), &r)
+	err = c.NationalService.Save(gc.Request.Context(), &r)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
 
 	gc.JSON(http.StatusOK, web.ResponseError{
-		Code:    http.StatusOK,
-		Status:  "OK",
-		Message: "Successfully create data national",
+		Code:      http.StatusOK,
+		Status:    "OK",
+		Message:   "Successfully create data national",
+		RequestID: logging.RequestID(gc.Request.Context()),
 	})
 	return
 
@@ -62,9 +65,10 @@ func (c *NationalControllerImpl) Update(gc *gin.Context) {
 	ID, err := strconv.Atoi(gc.Param("id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -73,9 +77,10 @@ func (c *NationalControllerImpl) Update(gc *gin.Context) {
 	err = gc.ShouldBind(&r)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -84,9 +89,10 @@ func (c *NationalControllerImpl) Update(gc *gin.Context) {
 	err = c.NationalService.Update(gc.Request.Context(), &r)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -102,9 +108,10 @@ func (c *NationalControllerImpl) Delete(gc *gin.Context) {
 	ID, err := strconv.Atoi(gc.Param("id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -112,9 +119,10 @@ func (c *NationalControllerImpl) Delete(gc *gin.Context) {
 	err = c.NationalService.Delete(gc.Request.Context(), ID)
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -130,9 +138,10 @@ func (c *NationalControllerImpl) FindByID(gc *gin.Context) {
 	id, err := strconv.Atoi(gc.Param("id"))
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Invalid format ID",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -140,9 +149,10 @@ func (c *NationalControllerImpl) FindByID(gc *gin.Context) {
 	result, err := c.NationalService.FindByID(gc.Request.Context(), id)
 	if err != nil {
 		gc.JSON(http.StatusOK, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -166,18 +176,20 @@ func (c *NationalControllerImpl) FindBySearch(gc *gin.Context) {
 		result, err := c.NationalService.FindByName(gc.Request.Context(), name)
 		if err != nil {
 			gc.JSON(http.StatusOK, web.ResponseError{
-				Code:    http.StatusBadRequest,
-				Status:  "Status Bad Request",
-				Message: err.Error(),
+				Code:      http.StatusBadRequest,
+				Status:    "Status Bad Request",
+				Message:   err.Error(),
+				RequestID: logging.RequestID(gc.Request.Context()),
 			})
 			return
 		}
 		nationals = append(nationals, result)
 	} else {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Query name is required",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Query name is required",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
@@ -199,9 +211,10 @@ func (c *NationalControllerImpl) FindAll(gc *gin.Context) {
 	results, err := c.NationalService.FindAll(gc.Request.Context())
 	if err != nil {
 		gc.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    http.StatusBadRequest,
-			Status:  "Status Bad Request",
-			Message: "Failed get all data nationals",
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Failed get all data nationals",
+			RequestID: logging.RequestID(gc.Request.Context()),
 		})
 		return
 	}
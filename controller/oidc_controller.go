@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/dimassfeb-09/efilm-api.git/config"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/dimassfeb-09/efilm-api.git/middlewares"
+	"github.com/dimassfeb-09/efilm-api.git/services"
+	"github.com/gin-gonic/gin"
+)
+
+// OIDCController logs users in at an OpenID Connect provider. Login takes an
+// optional bearer token, with middlewares.OptionalUser, to link the identity
+// to the user of the token instead. Login leaves the state in a cookie, and
+// Callback only finishes logins whose state matches it, so a login started in
+// one browser cannot be finished in another.
+type OIDCController interface {
+	Login(c *gin.Context)
+	Callback(c *gin.Context)
+}
+
+type OIDCControllerImpl struct {
+	OIDCService services.OIDCService
+	config      config.OIDC
+}
+
+func NewOIDCControllerImpl(oidcService services.OIDCService, config config.OIDC) OIDCController {
+	return &OIDCControllerImpl{OIDCService: oidcService, config: config}
+}
+
+// oidcStateCookie holds the state of the login the browser started.
+const (
+	oidcStateCookie     = "efilm_oidc_state"
+	oidcStateCookiePath = "/api/auth/oidc"
+)
+
+// setStateCookie keeps state in the browser for maxAge seconds, or forgets it
+// when maxAge is negative.
+func (controller *OIDCControllerImpl) setStateCookie(c *gin.Context, state string, maxAge int) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, state, maxAge, oidcStateCookiePath, "", strings.HasPrefix(controller.config.RedirectURL, "https://"), true)
+}
+
+func (controller *OIDCControllerImpl) Login(c *gin.Context) {
+	user, _ := middlewares.CurrentUser(c)
+
+	authorizationURL, state, err := controller.OIDCService.Start(c.Request.Context(), user.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return
+	}
+
+	controller.setStateCookie(c, state, int(controller.config.LoginTTL.Seconds()))
+	c.JSON(http.StatusOK, web.ResponseSuccessWithData{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Send the user to the authorization URL",
+		Data:    web.OIDCLoginResponse{AuthorizationURL: authorizationURL},
+	})
+}
+
+func (controller *OIDCControllerImpl) Callback(c *gin.Context) {
+	kept, _ := c.Cookie(oidcStateCookie)
+	controller.setStateCookie(c, "", -1)
+
+	// Providers send users back with an error when they cancel the login.
+	if reason := c.Query("error"); reason != "" {
+		c.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "The provider refused the login: " + reason,
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return
+	}
+
+	state := c.Query("state")
+	if kept == "" || subtle.ConstantTimeCompare([]byte(kept), []byte(state)) != 1 {
+		c.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "This login was not started in this browser",
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return
+	}
+
+	token, err := controller.OIDCService.Finish(c.Request.Context(), state, c.Query("code"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, web.ResponseSuccessWithData{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Success login with the OIDC provider",
+		Data:    web.AuthModelResponse{Token: token},
+	})
+}
@@ -0,0 +1,215 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/dimassfeb-09/efilm-api.git/services"
+	"github.com/gin-gonic/gin"
+)
+
+type PersonController interface {
+	Save(gc *gin.Context)
+	Update(gc *gin.Context)
+	Delete(gc *gin.Context)
+	FindByID(gc *gin.Context)
+	FindAll(gc *gin.Context)
+	FindCredits(gc *gin.Context)
+}
+
+type PersonControllerImpl struct {
+	PersonService services.PersonService
+}
+
+func NewPersonControllerImpl(personService services.PersonService) PersonController {
+	return &PersonControllerImpl{PersonService: personService}
+}
+
+func (c *PersonControllerImpl) Save(gc *gin.Context) {
+	var r web.PersonModelRequest
+	err := gc.ShouldBind(&r)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	err = c.PersonService.Save(gc.Request.Context(), &r)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	gc.JSON(http.StatusOK, web.ResponseSuccess{
+		Code:    http.StatusOK,
+		Status:  "Ok",
+		Message: "Successfully created person",
+	})
+}
+
+func (c *PersonControllerImpl) Update(gc *gin.Context) {
+	ID, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	var r web.PersonModelRequest
+	err = gc.ShouldBind(&r)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	r.ID = ID
+	err = c.PersonService.Update(gc.Request.Context(), &r)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	gc.JSON(http.StatusOK, web.ResponseSuccess{
+		Code:    http.StatusOK,
+		Status:  "Ok",
+		Message: fmt.Sprintf("Success update person with ID %d", ID),
+	})
+}
+
+func (c *PersonControllerImpl) Delete(gc *gin.Context) {
+	ID, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	err = c.PersonService.Delete(gc.Request.Context(), ID)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	gc.JSON(http.StatusOK, web.ResponseSuccess{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: fmt.Sprintf("Success delete data with ID %d", ID),
+	})
+}
+
+func (c *PersonControllerImpl) FindByID(gc *gin.Context) {
+	ID, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	result, err := c.PersonService.FindByID(gc.Request.Context(), ID)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	gc.JSON(http.StatusOK, web.ResponseSuccessWithData{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Success get data person by id",
+		Data:    result,
+	})
+}
+
+func (c *PersonControllerImpl) FindAll(gc *gin.Context) {
+	results, err := c.PersonService.FindAll(gc.Request.Context())
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Failed get all data people",
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	gc.JSON(http.StatusOK, web.ResponseSuccessWithData{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Success get data",
+		Data:    results,
+	})
+}
+
+func (c *PersonControllerImpl) FindCredits(gc *gin.Context) {
+	ID, err := strconv.Atoi(gc.Param("id"))
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	result, err := c.PersonService.FindCredits(gc.Request.Context(), ID)
+	if err != nil {
+		gc.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(gc.Request.Context()),
+		})
+		return
+	}
+
+	gc.JSON(http.StatusOK, web.ResponseSuccessWithData{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Success get credits by person",
+		Data:    result,
+	})
+}
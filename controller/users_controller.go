@@ -1,48 +1,234 @@
 package controller
 
 import (
+	"fmt"
+	"net/http"
+	"strconv"
+
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
-	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/dimassfeb-09/efilm-api.git/middlewares"
+	"github.com/dimassfeb-09/efilm-api.git/services"
 	"github.com/gin-gonic/gin"
-	"net/http"
-	"strings"
 )
 
+// UsersController serves the user of the bearer token, with routes that need
+// middlewares.RequireUser, and the administration of users, with routes that
+// need middlewares.RequireRole for admins.
 type UsersController interface {
-	GetUserInfo(c *gin.Context)
+	Me(c *gin.Context)
+	UpdateMe(c *gin.Context)
+	ChangePassword(c *gin.Context)
+	FindAll(c *gin.Context)
+	ChangeRole(c *gin.Context)
+	Disable(c *gin.Context)
+	Enable(c *gin.Context)
+	Delete(c *gin.Context)
 }
 
 type UsersControllerImpl struct {
+	AuthService services.AuthService
+	UserService services.UserService
+}
+
+func NewUserController(authService services.AuthService, userService services.UserService) UsersController {
+	return &UsersControllerImpl{AuthService: authService, UserService: userService}
+}
+
+func (controller *UsersControllerImpl) Me(c *gin.Context) {
+	user, _ := middlewares.CurrentUser(c)
+
+	// The principal holds no profile.
+	response, err := controller.UserService.FindByID(c.Request.Context(), user.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      400,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, web.ResponseSuccessWithData{
+		Code:    200,
+		Status:  "Status OK",
+		Message: "Success to get user info",
+		Data:    response,
+	})
 }
 
-func NewUserController() UsersController {
-	return &UsersControllerImpl{}
+func (controller *UsersControllerImpl) UpdateMe(c *gin.Context) {
+	user, _ := middlewares.CurrentUser(c)
+
+	var r web.UserProfileRequest
+	err := c.ShouldBind(&r)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return
+	}
+
+	response, err := controller.UserService.UpdateProfile(c.Request.Context(), user.UserID, &r)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, web.ResponseSuccessWithData{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Successfully updated profile",
+		Data:    response,
+	})
 }
 
-func (controller *UsersControllerImpl) GetUserInfo(c *gin.Context) {
-	authorization := c.Request.Header.Get("Authorization")
-	bearers := strings.Split(authorization, "Bearer")
-	token := strings.TrimSpace(bearers[1])
-	isValid, userInfo, err := helpers.ValidateTokenJWT(token)
+func (controller *UsersControllerImpl) ChangePassword(c *gin.Context) {
+	user, _ := middlewares.CurrentUser(c)
+
+	var r web.ChangePasswordRequest
+	err := c.ShouldBind(&r)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return
+	}
+
+	err = controller.AuthService.ChangePassword(c.Request.Context(), user.UserID, &r)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, web.ResponseError{
-			Code:    400,
-			Status:  "Status Bad Request",
-			Message: err.Error(),
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
 
-	if isValid {
-		c.JSON(http.StatusOK, web.ResponseSuccessWithData{
-			Code:    200,
-			Status:  "Status OK",
-			Message: "Success to get user info",
-			Data: web.UserInfoResponse{
-				UserID:   userInfo.UserID,
-				Username: userInfo.Username,
-			},
+	c.JSON(http.StatusOK, web.ResponseSuccess{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Successfully changed password",
+	})
+}
+
+func (controller *UsersControllerImpl) FindAll(c *gin.Context) {
+	responses, err := controller.UserService.FindAll(c.Request.Context(), c.Query("search"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Failed get all users",
+			RequestID: logging.RequestID(c.Request.Context()),
 		})
 		return
 	}
+
+	c.JSON(http.StatusOK, web.ResponseSuccessWithData{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: "Success get data",
+		Data:    responses,
+	})
+}
+
+func (controller *UsersControllerImpl) ChangeRole(c *gin.Context) {
+	admin, _ := middlewares.CurrentUser(c)
+	ID, ok := userID(c)
+	if !ok {
+		return
+	}
+
+	var r web.UserRoleRequest
+	err := c.ShouldBind(&r)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return
+	}
+
+	err = controller.UserService.ChangeRole(c.Request.Context(), admin.UserID, ID, &r)
+	respondToUserChange(c, err, fmt.Sprintf("Success change role of user with ID %d", ID))
+}
+
+func (controller *UsersControllerImpl) Disable(c *gin.Context) {
+	admin, _ := middlewares.CurrentUser(c)
+	ID, ok := userID(c)
+	if !ok {
+		return
+	}
+
+	err := controller.UserService.Disable(c.Request.Context(), admin.UserID, ID)
+	respondToUserChange(c, err, fmt.Sprintf("Success disable user with ID %d", ID))
+}
+
+func (controller *UsersControllerImpl) Enable(c *gin.Context) {
+	ID, ok := userID(c)
+	if !ok {
+		return
+	}
+
+	err := controller.UserService.Enable(c.Request.Context(), ID)
+	respondToUserChange(c, err, fmt.Sprintf("Success enable user with ID %d", ID))
+}
+
+func (controller *UsersControllerImpl) Delete(c *gin.Context) {
+	admin, _ := middlewares.CurrentUser(c)
+	ID, ok := userID(c)
+	if !ok {
+		return
+	}
+
+	err := controller.UserService.Delete(c.Request.Context(), admin.UserID, ID)
+	respondToUserChange(c, err, fmt.Sprintf("Success delete user with ID %d", ID))
+}
+
+// userID reads the id path parameter, answering 400 when it is no number.
+func userID(c *gin.Context) (int, bool) {
+	ID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   "Invalid format ID",
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return 0, false
+	}
+	return ID, true
+}
+
+func respondToUserChange(c *gin.Context, err error, message string) {
+	if err != nil {
+		c.JSON(http.StatusBadRequest, web.ResponseError{
+			Code:      http.StatusBadRequest,
+			Status:    "Status Bad Request",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, web.ResponseSuccess{
+		Code:    http.StatusOK,
+		Status:  "OK",
+		Message: message,
+	})
 }
@@ -0,0 +1,286 @@
+package docs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Contract checks requests and responses against a document built by
+// Generate. The zero value accepts everything until Load is called, so it can
+// be handed to a middleware before the routes, and the document, exist.
+type Contract struct {
+	paths   map[string]any
+	schemas map[string]any
+}
+
+// Load replaces the document the contract checks against. It must be called
+// before the server starts handling requests.
+func (contract *Contract) Load(document map[string]any) error {
+	// The generated document holds Go values such as []string and int;
+	// round-tripping it leaves only what a JSON client would see.
+	raw, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+
+	var decoded struct {
+		Paths      map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return err
+	}
+
+	contract.paths = decoded.Paths
+	contract.schemas = decoded.Components.Schemas
+	return nil
+}
+
+// operation returns the documented operation of a gin route, or nil.
+func (contract *Contract) operation(method, route string) map[string]any {
+	path, _ := contract.paths[pathParam.ReplaceAllString(route, "{$1}")].(map[string]any)
+	operation, _ := path[strings.ToLower(method)].(map[string]any)
+	return operation
+}
+
+// ValidateRequest checks the parameters and the body of r, which was routed
+// to route, e.g. "/api/movies/:movie_id". Params holds the path parameters.
+func (contract *Contract) ValidateRequest(r *http.Request, route string, params map[string]string, body []byte) error {
+	operation := contract.operation(r.Method, route)
+	if operation == nil {
+		return nil
+	}
+
+	var violations []string
+
+	parameters, _ := operation["parameters"].([]any)
+	for _, item := range parameters {
+		parameter, _ := item.(map[string]any)
+		name, _ := parameter["name"].(string)
+		schema, _ := parameter["schema"].(map[string]any)
+
+		var value string
+		var present bool
+		switch parameter["in"] {
+		case "path":
+			value, present = params[name]
+		case "query":
+			present = r.URL.Query().Has(name)
+			value = r.URL.Query().Get(name)
+		default:
+			continue
+		}
+
+		if !present {
+			if required, _ := parameter["required"].(bool); required {
+				violations = append(violations, fmt.Sprintf("%s parameter %s is required", parameter["in"], name))
+			}
+			continue
+		}
+		if schema["type"] == "integer" {
+			if _, err := strconv.Atoi(value); err != nil {
+				violations = append(violations, fmt.Sprintf("%s parameter %s must be an integer", parameter["in"], name))
+			}
+		}
+	}
+
+	if requestBody, ok := operation["requestBody"].(map[string]any); ok {
+		content, _ := requestBody["content"].(map[string]any)
+		violations = append(violations, contract.validateRequestBody(r.Header.Get("Content-Type"), content, body)...)
+	}
+
+	return violationError(violations)
+}
+
+func (contract *Contract) validateRequestBody(contentType string, content map[string]any, body []byte) []string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	media, ok := content[mediaType].(map[string]any)
+	if !ok {
+		var expected []string
+		for documented := range content {
+			expected = append(expected, documented)
+		}
+		sort.Strings(expected)
+		return []string{fmt.Sprintf("content type %q is not one of %v", mediaType, expected)}
+	}
+
+	// Multipart bodies are left to the handler, which checks the file itself.
+	if mediaType != "application/json" {
+		return nil
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []string{"body is not valid JSON"}
+	}
+
+	schema, _ := media["schema"].(map[string]any)
+	return contract.validate(schema, value, "body")
+}
+
+// ValidateResponse checks a response the route answered with.
+func (contract *Contract) ValidateResponse(method, route string, status int, contentType string, body []byte) error {
+	operation := contract.operation(method, route)
+	if operation == nil {
+		return nil
+	}
+
+	responses, _ := operation["responses"].(map[string]any)
+	response, ok := responses[strconv.Itoa(status)].(map[string]any)
+	if !ok {
+		return fmt.Errorf("status %d is not documented", status)
+	}
+
+	content, _ := response["content"].(map[string]any)
+	media, ok := content["application/json"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != "application/json" {
+		return fmt.Errorf("content type %q is not application/json", mediaType)
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return errors.New("body is not valid JSON")
+	}
+
+	schema, _ := media["schema"].(map[string]any)
+	return violationError(contract.validate(schema, value, "body"))
+}
+
+func violationError(violations []string) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(violations, "; "))
+}
+
+// validate checks value against the subset of JSON schema that Generate
+// produces and returns one message per violation.
+func (contract *Contract) validate(schema map[string]any, value any, at string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		resolved, _ := contract.schemas[strings.TrimPrefix(ref, "#/components/schemas/")].(map[string]any)
+		return contract.validate(resolved, value, at)
+	}
+
+	var violations []string
+	if allOf, ok := schema["allOf"].([]any); ok {
+		for _, item := range allOf {
+			part, _ := item.(map[string]any)
+			violations = append(violations, contract.validate(part, value, at)...)
+		}
+	}
+
+	if value == nil {
+		if nullable, _ := schema["nullable"].(bool); nullable || schema["type"] == nil {
+			return violations
+		}
+		return append(violations, fmt.Sprintf("%s must not be null", at))
+	}
+
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return append(violations, fmt.Sprintf("%s must be an object", at))
+		}
+
+		required, _ := schema["required"].([]any)
+		for _, name := range required {
+			if _, ok := object[name.(string)]; !ok {
+				violations = append(violations, fmt.Sprintf("%s.%s is required", at, name))
+			}
+		}
+
+		properties, _ := schema["properties"].(map[string]any)
+		additional, _ := schema["additionalProperties"].(map[string]any)
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, ok := properties[name].(map[string]any)
+			if !ok {
+				property = additional
+			}
+			violations = append(violations, contract.validate(property, object[name], at+"."+name)...)
+		}
+
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			return append(violations, fmt.Sprintf("%s must be an array", at))
+		}
+		items, _ := schema["items"].(map[string]any)
+		for i, item := range array {
+			violations = append(violations, contract.validate(items, item, fmt.Sprintf("%s[%d]", at, i))...)
+		}
+
+	case "integer", "number":
+		number, ok := value.(float64)
+		if !ok {
+			return append(violations, fmt.Sprintf("%s must be a number", at))
+		}
+		if schema["type"] == "integer" && number != math.Trunc(number) {
+			return append(violations, fmt.Sprintf("%s must be an integer", at))
+		}
+		if minimum, ok := schema["minimum"].(float64); ok && number < minimum {
+			violations = append(violations, fmt.Sprintf("%s must be at least %v", at, minimum))
+		}
+		if maximum, ok := schema["maximum"].(float64); ok && number > maximum {
+			violations = append(violations, fmt.Sprintf("%s must be at most %v", at, maximum))
+		}
+
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return append(violations, fmt.Sprintf("%s must be a string", at))
+		}
+		if schema["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339, text); err != nil {
+				violations = append(violations, fmt.Sprintf("%s must be a date-time", at))
+			}
+		}
+		if minLength, ok := schema["minLength"].(float64); ok && float64(utf8.RuneCountInString(text)) < minLength {
+			violations = append(violations, fmt.Sprintf("%s must be at least %v characters", at, minLength))
+		}
+		if maxLength, ok := schema["maxLength"].(float64); ok && float64(utf8.RuneCountInString(text)) > maxLength {
+			violations = append(violations, fmt.Sprintf("%s must be at most %v characters", at, maxLength))
+		}
+
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return append(violations, fmt.Sprintf("%s must be a boolean", at))
+		}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !containsValue(enum, value) {
+		violations = append(violations, fmt.Sprintf("%s must be one of %v", at, enum))
+	}
+
+	return violations
+}
+
+func containsValue(values []any, value any) bool {
+	for _, candidate := range values {
+		if fmt.Sprint(candidate) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package docs
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type contractRequest struct {
+	Name  string `json:"name" binding:"required,max=5"`
+	Grade string `json:"grade" binding:"oneof=a b"`
+}
+
+type contractResponse struct {
+	ID   int      `json:"id"`
+	Tags []string `json:"tags"`
+}
+
+func newTestContract(t *testing.T) *Contract {
+	routes := gin.RoutesInfo{
+		{Method: "POST", Path: "/api/things"},
+		{Method: "GET", Path: "/api/things/:id"},
+	}
+	document, err := Generate(Info{}, routes, map[string]Operation{
+		Key("POST", "/api/things"):    {Request: contractRequest{}},
+		Key("GET", "/api/things/:id"): {Params: []Parameter{{Name: "q", In: "query", Required: true}}, Response: contractResponse{}},
+	})
+	assert.NoError(t, err)
+
+	contract := &Contract{}
+	assert.NoError(t, contract.Load(document))
+	return contract
+}
+
+func TestContractValidateRequest(t *testing.T) {
+	contract := newTestContract(t)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		err         string
+	}{
+		{name: "valid", contentType: "application/json", body: `{"name":"abc","grade":"a"}`},
+		{name: "missing required", contentType: "application/json", body: `{"grade":"a"}`, err: "body.name is required"},
+		{name: "wrong type", contentType: "application/json", body: `{"name":1}`, err: "body.name must be a string"},
+		{name: "too long", contentType: "application/json", body: `{"name":"abcdef"}`, err: "body.name must be at most 5 characters"},
+		{name: "not in enum", contentType: "application/json", body: `{"name":"abc","grade":"c"}`, err: "body.grade must be one of [a b]"},
+		{name: "invalid json", contentType: "application/json", body: `{`, err: "body is not valid JSON"},
+		{name: "wrong content type", contentType: "text/plain", body: `name`, err: `content type "text/plain" is not one of [application/json]`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/api/things", strings.NewReader(test.body))
+			r.Header.Set("Content-Type", test.contentType)
+
+			err := contract.ValidateRequest(r, "/api/things", nil, []byte(test.body))
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+
+	t.Run("parameters", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/things/x", nil)
+		err := contract.ValidateRequest(r, "/api/things/:id", map[string]string{"id": "x"}, nil)
+		assert.EqualError(t, err, "query parameter q is required; path parameter id must be an integer")
+	})
+}
+
+func TestContractValidateResponse(t *testing.T) {
+	contract := newTestContract(t)
+
+	err := contract.ValidateResponse("GET", "/api/things/:id", 200, "application/json; charset=utf-8",
+		[]byte(`{"code":200,"status":"OK","message":"ok","data":{"id":1,"tags":null}}`))
+	assert.NoError(t, err)
+
+	err = contract.ValidateResponse("GET", "/api/things/:id", 200, "application/json",
+		[]byte(`{"code":200,"status":"OK","message":"ok","data":{"id":"1","tags":[2]}}`))
+	assert.EqualError(t, err, "body.data.id must be a number; body.data.tags[0] must be a string")
+
+	err = contract.ValidateResponse("GET", "/api/things/:id", 500, "application/json", []byte(`{}`))
+	assert.EqualError(t, err, "status 500 is not documented")
+
+	err = contract.ValidateResponse("GET", "/unknown", 500, "text/plain", nil)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,414 @@
+// Package docs generates the OpenAPI document of the API from the routes
+// registered on gin and the request and response structs in entity/web.
+package docs
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/gin-gonic/gin"
+)
+
+// Parameter documents a query or path parameter. Path parameters are added
+// automatically, as integers or as strings for catch-all parameters, so they
+// only need listing when that is wrong.
+type Parameter struct {
+	Name        string
+	In          string
+	Type        string
+	Description string
+	Required    bool
+}
+
+// Operation documents one route. Request is the body the handler binds and
+// Response the value it puts in the data field of the response; leave them
+// nil when there is none.
+type Operation struct {
+	Summary  string
+	Tag      string
+	Params   []Parameter
+	Request  any
+	Response any
+	// File is the form field of a multipart file upload.
+	File string
+	// Public marks writes that do not need a bearer token.
+	Public bool
+	// Role is the role the user of the bearer token must have, whatever the
+	// method.
+	Role string
+	// UserOnly marks routes that take the bearer token of a user but no API
+	// key. Routes with a Role are always user only.
+	UserOnly bool
+	// Statuses lists statuses other than 200 that answer with the same body,
+	// such as 503 from a failing readiness check.
+	Statuses []int
+	// ContentType is set for routes that answer with something other than
+	// the JSON envelope, such as the index page or this document.
+	ContentType string
+	// Deprecated marks routes kept only for old clients.
+	Deprecated bool
+}
+
+// Info is the top level description of the document.
+type Info struct {
+	Title       string
+	Description string
+	Version     string
+}
+
+// Key identifies an operation the way gin registers it, e.g. "GET /api/movies/:movie_id".
+func Key(method, path string) string {
+	return method + " " + path
+}
+
+var pathParam = regexp.MustCompile(`[:*]([^/]+)`)
+
+// Generate builds the OpenAPI 3 document for routes. It returns an error
+// naming every registered route that has no operation, and every operation
+// whose route is not registered, but still documents the rest.
+func Generate(info Info, routes gin.RoutesInfo, operations map[string]Operation) (map[string]any, error) {
+	g := &generator{schemas: map[string]any{}}
+
+	paths := map[string]map[string]any{}
+	registered := map[string]bool{}
+	var undocumented []string
+	for _, route := range routes {
+		key := Key(route.Method, route.Path)
+		registered[key] = true
+
+		operation, ok := operations[key]
+		if !ok {
+			undocumented = append(undocumented, key)
+			continue
+		}
+
+		path := pathParam.ReplaceAllString(route.Path, "{$1}")
+		if paths[path] == nil {
+			paths[path] = map[string]any{}
+		}
+		paths[path][strings.ToLower(route.Method)] = g.operation(route, operation)
+	}
+
+	var unknown []string
+	for key := range operations {
+		if !registered[key] {
+			unknown = append(unknown, key)
+		}
+	}
+
+	document := map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       info.Title,
+			"description": info.Description,
+			"version":     info.Version,
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": g.schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+				"apiKeyAuth": map[string]any{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+			},
+		},
+	}
+
+	if len(undocumented) > 0 || len(unknown) > 0 {
+		sort.Strings(undocumented)
+		sort.Strings(unknown)
+		return document, fmt.Errorf("undocumented routes %v, operations without a route %v", undocumented, unknown)
+	}
+
+	return document, nil
+}
+
+type generator struct {
+	schemas map[string]any
+}
+
+func (g *generator) operation(route gin.RouteInfo, operation Operation) map[string]any {
+	result := map[string]any{
+		"summary":     operation.Summary,
+		"operationId": operationID(route.Method, route.Path),
+	}
+	if operation.Tag != "" {
+		result["tags"] = []string{operation.Tag}
+	}
+	if operation.Deprecated {
+		result["deprecated"] = true
+	}
+
+	documented := map[string]bool{}
+	var parameters []any
+	for _, param := range operation.Params {
+		documented[param.Name] = true
+		parameters = append(parameters, parameter(param))
+	}
+	for _, match := range pathParam.FindAllStringSubmatch(route.Path, -1) {
+		if !documented[match[1]] {
+			param := Parameter{Name: match[1], In: "path"}
+			if strings.HasPrefix(match[0], "*") {
+				param.Type = "string"
+			}
+			parameters = append(parameters, parameter(param))
+		}
+	}
+	if len(parameters) > 0 {
+		result["parameters"] = parameters
+	}
+
+	switch {
+	case operation.File != "":
+		result["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				"multipart/form-data": map[string]any{
+					"schema": map[string]any{
+						"type":     "object",
+						"required": []string{operation.File},
+						"properties": map[string]any{
+							operation.File: map[string]any{"type": "string", "format": "binary"},
+						},
+					},
+				},
+			},
+		}
+	case operation.Request != nil:
+		result["requestBody"] = map[string]any{
+			"required": true,
+			"content":  jsonContent(g.schema(reflect.TypeOf(operation.Request))),
+		}
+	}
+
+	if operation.ContentType != "" {
+		result["responses"] = map[string]any{
+			"200": map[string]any{
+				"description": http.StatusText(http.StatusOK),
+				"content":     map[string]any{operation.ContentType: map[string]any{"schema": map[string]any{}}},
+			},
+		}
+		return result
+	}
+
+	success := g.schema(reflect.TypeOf(web.ResponseSuccess{}))
+	if operation.Response != nil {
+		success = map[string]any{
+			"allOf": []any{
+				success,
+				map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"data": g.schema(reflect.TypeOf(operation.Response)),
+					},
+				},
+			},
+		}
+	}
+
+	failure := g.schema(reflect.TypeOf(web.ResponseError{}))
+	responses := map[string]any{
+		"200": map[string]any{"description": http.StatusText(http.StatusOK), "content": jsonContent(success)},
+		"400": map[string]any{"description": http.StatusText(http.StatusBadRequest), "content": jsonContent(failure)},
+	}
+	for _, status := range operation.Statuses {
+		responses[strconv.Itoa(status)] = map[string]any{"description": http.StatusText(status), "content": jsonContent(success)}
+	}
+
+	userOnly := operation.UserOnly || operation.Role != ""
+	switch {
+	case userOnly || (route.Method != http.MethodGet && strings.HasPrefix(route.Path, "/api/") && !operation.Public):
+		security := []any{map[string]any{"bearerAuth": []string{}}}
+		if !userOnly {
+			// Read keys are refused with 403.
+			security = append(security, map[string]any{"apiKeyAuth": []string{}})
+			responses["403"] = map[string]any{"description": http.StatusText(http.StatusForbidden), "content": jsonContent(failure)}
+		}
+		result["security"] = security
+		responses["401"] = map[string]any{"description": http.StatusText(http.StatusUnauthorized), "content": jsonContent(failure)}
+	case route.Method == http.MethodGet && strings.HasPrefix(route.Path, "/api/"):
+		// Reads are open, but a bad API key or bearer token is still refused.
+		result["security"] = []any{map[string]any{}, map[string]any{"bearerAuth": []string{}}, map[string]any{"apiKeyAuth": []string{}}}
+		responses["401"] = map[string]any{"description": http.StatusText(http.StatusUnauthorized), "content": jsonContent(failure)}
+	}
+	if operation.Role != "" {
+		result["description"] = "Only users with the " + operation.Role + " role may call this."
+		responses["403"] = map[string]any{"description": http.StatusText(http.StatusForbidden), "content": jsonContent(failure)}
+	}
+	if strings.HasPrefix(route.Path, "/api/") {
+		responses["429"] = map[string]any{
+			"description": http.StatusText(http.StatusTooManyRequests),
+			"headers": map[string]any{
+				"Retry-After": map[string]any{"description": "Seconds until the next request is allowed", "schema": map[string]any{"type": "integer"}},
+			},
+			"content": jsonContent(failure),
+		}
+	}
+	result["responses"] = responses
+
+	return result
+}
+
+func operationID(method, path string) string {
+	id := strings.ToLower(method)
+	for _, segment := range strings.Split(path, "/") {
+		segment = strings.TrimLeft(segment, ":*")
+		if segment != "" {
+			id += "_" + strings.ReplaceAll(segment, ".", "_")
+		}
+	}
+	return id
+}
+
+func parameter(param Parameter) map[string]any {
+	if param.Type == "" {
+		param.Type = "string"
+		if param.In == "path" {
+			param.Type = "integer"
+		}
+	}
+
+	result := map[string]any{
+		"name":     param.Name,
+		"in":       param.In,
+		"required": param.Required || param.In == "path",
+		"schema":   map[string]any{"type": param.Type},
+	}
+	if param.Description != "" {
+		result["description"] = param.Description
+	}
+	return result
+}
+
+func jsonContent(schema any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schema returns the JSON schema of t. Named structs are added to the
+// components once and referenced from then on.
+func (g *generator) schema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		if _, ok := g.schemas[t.Name()]; !ok {
+			g.schemas[t.Name()] = nil
+			g.schemas[t.Name()] = g.object(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return g.object(t)
+	// encoding/json writes nil slices and maps as null.
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": g.schema(t.Elem()), "nullable": true}
+	case reflect.Array:
+		return map[string]any{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schema(t.Elem()), "nullable": true}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	default:
+		return map[string]any{}
+	}
+}
+
+func (g *generator) object(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.schema(field.Type)
+		if _, isRef := property["$ref"]; !isRef {
+			applyBinding(property, field.Tag.Get("binding"))
+			if example := field.Tag.Get("example"); example != "" {
+				property["example"] = exampleValue(property, example)
+			}
+		}
+		if hasRule(field.Tag.Get("binding"), "required") {
+			required = append(required, name)
+		}
+
+		properties[name] = property
+	}
+
+	result := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		result["required"] = required
+	}
+	return result
+}
+
+func hasRule(binding, rule string) bool {
+	for _, r := range strings.Split(binding, ",") {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// applyBinding carries the validator rules that have an OpenAPI equivalent
+// over to the schema.
+func applyBinding(property map[string]any, binding string) {
+	for _, rule := range strings.Split(binding, ",") {
+		name, value, _ := strings.Cut(rule, "=")
+		switch name {
+		case "oneof":
+			property["enum"] = strings.Fields(value)
+		case "min", "max":
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			key := map[string]string{"min": "minimum", "max": "maximum"}[name]
+			if property["type"] == "string" {
+				key = map[string]string{"min": "minLength", "max": "maxLength"}[name]
+			}
+			property[key] = number
+		}
+	}
+}
+
+func exampleValue(property map[string]any, example string) any {
+	if property["type"] == "integer" {
+		if number, err := strconv.Atoi(example); err == nil {
+			return number
+		}
+	}
+	return example
+}
@@ -0,0 +1,52 @@
+package docs
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+const swaggerInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: %q,
+    dom_id: '#swagger-ui',
+    deepLinking: true,
+    presets: [
+      SwaggerUIBundle.presets.apis,
+      SwaggerUIStandalonePreset
+    ],
+    plugins: [
+      SwaggerUIBundle.plugins.DownloadUrl
+    ],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+// SwaggerUI serves the embedded Swagger UI pointed at specURL. Register it on
+// a catch-all route named any, e.g. r.GET("/docs/*any", docs.SwaggerUI(url)).
+func SwaggerUI(specURL string) gin.HandlerFunc {
+	initializer := fmt.Sprintf(swaggerInitializer, specURL)
+	files := http.FileServer(http.FS(swaggerFiles.FS))
+
+	return func(c *gin.Context) {
+		file := strings.TrimPrefix(c.Param("any"), "/")
+		if file == "swagger-initializer.js" {
+			c.Data(http.StatusOK, "application/javascript; charset=utf-8", []byte(initializer))
+			return
+		}
+
+		// http.FileServer answers "/" with index.html and redirects requests
+		// for index.html by name back to "/"
+		if file == "index.html" {
+			file = ""
+		}
+
+		req := c.Request.Clone(c.Request.Context())
+		req.URL.Path = "/" + file
+		files.ServeHTTP(c.Writer, req)
+	}
+}
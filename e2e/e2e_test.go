@@ -0,0 +1,734 @@
+// Package e2e drives the full router from app.InitialozedRoute over HTTP,
+// backed by the in-memory repositories, and compares every response with a
+// golden file in testdata. Run with -update to rewrite the golden files after
+// an intended change.
+package e2e
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/app"
+	"github.com/dimassfeb-09/efilm-api.git/config"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/dimassfeb-09/efilm-api.git/metrics"
+	"github.com/dimassfeb-09/efilm-api.git/notify"
+	"github.com/dimassfeb-09/efilm-api.git/oidc/oidctest"
+	"github.com/dimassfeb-09/efilm-api.git/ratelimit"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+
+	stop, err := startDatabase()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
+
+var cfg = &config.Config{
+	Server:             config.Default().Server,
+	JWT:                config.JWT{Secret: "e2e-secret"},
+	Login:              config.Default().Login,
+	Password:           config.Password{MinLength: 10, MinClasses: 2, HashCost: bcrypt.MinCost, ResetTokenTTL: time.Hour},
+	ContractValidation: true,
+}
+
+// keys signs with a fixed Ed25519 key, so the JWKS golden file is stable,
+// and still accepts HS256 tokens made with the secret.
+var keys = func() *helpers.JWTKeys {
+	key := ed25519.NewKeyFromSeed(bytes.Repeat([]byte("e2e"), 11)[:ed25519.SeedSize])
+	keys, err := helpers.NewJWTKeys(cfg.JWT.Secret, "e2e", []helpers.JWTKey{{ID: "e2e", Key: key}})
+	if err != nil {
+		panic(err)
+	}
+	return keys
+}()
+
+// newServer builds the router on a freshly seeded database, logging to
+// logger. Every route that handles a request is recorded in covered, see
+// routeKey.
+func newServer(t *testing.T, covered map[string]bool, logger *slog.Logger) *gin.Engine {
+	store, repositories := newDatabase(t)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Next()
+		if c.FullPath() != "" {
+			covered[routeKey(c.Request.Method, c.FullPath())] = true
+		}
+	})
+
+	return app.InitialozedRoute(r, cfg, keys, store, repositories, metrics.New(), ratelimit.NewMemoryStore(), notify.NewLogNotifier(logger), logger)
+}
+
+type routeCase struct {
+	name      string
+	method    string
+	path      string
+	body      string
+	anonymous bool
+	// role picks the seeded user of the bearer token: the admin by default,
+	// or hyejin for user.
+	role string
+	// apiKey is sent in X-API-Key instead of a bearer token.
+	apiKey string
+	// authorization is sent as is in Authorization instead of a bearer token.
+	authorization string
+	status        int
+	// volatile bodies change between runs, so only the status is compared.
+	volatile bool
+}
+
+var cases = []routeCase{
+	{name: "index", method: http.MethodGet, path: "/", status: http.StatusOK},
+	{name: "metrics", method: http.MethodGet, path: "/metrics", anonymous: true, status: http.StatusOK, volatile: true},
+	{name: "healthz", method: http.MethodGet, path: "/healthz", anonymous: true, status: http.StatusOK},
+	{name: "readyz", method: http.MethodGet, path: "/readyz", anonymous: true, status: http.StatusOK},
+	{name: "jwks", method: http.MethodGet, path: "/.well-known/jwks.json", anonymous: true, status: http.StatusOK},
+	{name: "openapi", method: http.MethodGet, path: "/api/openapi.json", anonymous: true, status: http.StatusOK},
+	{name: "docs_initializer", method: http.MethodGet, path: "/docs/swagger-initializer.js", anonymous: true, status: http.StatusOK},
+
+	{name: "auth_register", method: http.MethodPost, path: "/api/auth/register", body: `{"username":"jieun","password":"secret-password"}`, anonymous: true, status: http.StatusOK},
+	{name: "auth_register_weak_password", method: http.MethodPost, path: "/api/auth/register", body: `{"username":"jieun","password":"secret"}`, anonymous: true, status: http.StatusBadRequest},
+	{name: "auth_register_duplicate", method: http.MethodPost, path: "/api/auth/register", body: `{"username":"admin","password":"secret-password"}`, anonymous: true, status: http.StatusBadRequest},
+	{name: "auth_login", method: http.MethodPost, path: "/api/auth/login", body: `{"username":"admin","password":"secret"}`, anonymous: true, status: http.StatusOK},
+	{name: "auth_login_wrong_password", method: http.MethodPost, path: "/api/auth/login", body: `{"username":"admin","password":"wrong"}`, anonymous: true, status: http.StatusBadRequest},
+	{name: "auth_login_unknown_user", method: http.MethodPost, path: "/api/auth/login", body: `{"username":"nobody","password":"wrong"}`, anonymous: true, status: http.StatusBadRequest},
+	{name: "auth_password_forgot", method: http.MethodPost, path: "/api/auth/password/forgot", body: `{"username":"admin"}`, anonymous: true, status: http.StatusOK},
+	{name: "auth_password_reset_invalid", method: http.MethodPost, path: "/api/auth/password/reset", body: `{"token":"not-a-token","password":"Another-passw0rd"}`, anonymous: true, status: http.StatusBadRequest},
+	{name: "users_change_password", method: http.MethodPut, path: "/api/users/me/password", body: `{"current_password":"secret","new_password":"Another-passw0rd"}`, status: http.StatusOK},
+	{name: "users_change_password_wrong", method: http.MethodPut, path: "/api/users/me/password", body: `{"current_password":"wrong","new_password":"Another-passw0rd"}`, status: http.StatusBadRequest},
+	{name: "api_keys_create", method: http.MethodPost, path: "/api/api-keys", body: `{"name":"recommendation-service","scope":"read"}`, status: http.StatusOK},
+	{name: "api_keys_create_bad_scope", method: http.MethodPost, path: "/api/api-keys", body: `{"name":"recommendation-service","scope":"admin"}`, status: http.StatusBadRequest},
+	{name: "api_keys_list", method: http.MethodGet, path: "/api/api-keys", status: http.StatusOK},
+	{name: "api_keys_delete", method: http.MethodDelete, path: "/api/api-keys/1", status: http.StatusOK},
+	{name: "api_keys_need_user", method: http.MethodGet, path: "/api/api-keys", apiKey: fixtureWriteKey, status: http.StatusUnauthorized},
+	{name: "api_key_read", method: http.MethodGet, path: "/api/genres", apiKey: fixtureReadKey, status: http.StatusOK},
+	{name: "api_key_read_only", method: http.MethodPost, path: "/api/genres", body: `{"name":"Horror"}`, apiKey: fixtureReadKey, status: http.StatusForbidden},
+	{name: "api_key_write", method: http.MethodPost, path: "/api/genres", body: `{"name":"Horror"}`, apiKey: fixtureWriteKey, status: http.StatusOK},
+	{name: "api_key_expired", method: http.MethodGet, path: "/api/genres", apiKey: fixtureExpiredKey, status: http.StatusUnauthorized},
+	{name: "api_key_unknown", method: http.MethodGet, path: "/api/genres", apiKey: "efk_unknown", status: http.StatusUnauthorized},
+	{name: "oidc_login_not_configured", method: http.MethodGet, path: "/api/auth/oidc/login", anonymous: true, status: http.StatusBadRequest},
+	{name: "oidc_callback_without_cookie", method: http.MethodGet, path: "/api/auth/oidc/callback?code=code&state=state", anonymous: true, status: http.StatusBadRequest},
+	{name: "oidc_callback_provider_error", method: http.MethodGet, path: "/api/auth/oidc/callback?error=access_denied&state=state", anonymous: true, status: http.StatusBadRequest},
+	{name: "auth_unlock", method: http.MethodPost, path: "/api/auth/unlock", body: `{"username":"admin"}`, status: http.StatusOK},
+	{name: "auth_unlock_forbidden", method: http.MethodPost, path: "/api/auth/unlock", body: `{"username":"admin"}`, role: "user", status: http.StatusForbidden},
+	{name: "users_me", method: http.MethodGet, path: "/api/users/me", status: http.StatusOK},
+	{name: "users_info", method: http.MethodPost, path: "/api/users/info", status: http.StatusOK},
+	{name: "users_me_update", method: http.MethodPatch, path: "/api/users/me", body: `{"display_name":"Admin","preferred_languages":["ko","en"],"preferred_genres":[1,2]}`, status: http.StatusOK},
+	{name: "users_me_update_unknown_genre", method: http.MethodPatch, path: "/api/users/me", body: `{"preferred_genres":[99]}`, status: http.StatusBadRequest},
+	{name: "users_me_update_bad_avatar", method: http.MethodPatch, path: "/api/users/me", body: `{"avatar_url":"javascript:alert(1)"}`, status: http.StatusBadRequest},
+	{name: "users_list", method: http.MethodGet, path: "/api/users", status: http.StatusOK},
+	{name: "users_search", method: http.MethodGet, path: "/api/users?search=hye-jin", status: http.StatusOK},
+	{name: "users_list_forbidden", method: http.MethodGet, path: "/api/users", role: "user", status: http.StatusForbidden},
+	{name: "users_change_role", method: http.MethodPut, path: "/api/users/2/role", body: `{"role":"admin"}`, status: http.StatusOK},
+	{name: "users_change_own_role", method: http.MethodPut, path: "/api/users/1/role", body: `{"role":"user"}`, status: http.StatusBadRequest},
+	{name: "users_change_role_unknown", method: http.MethodPut, path: "/api/users/2/role", body: `{"role":"owner"}`, status: http.StatusBadRequest},
+	{name: "users_disable", method: http.MethodPost, path: "/api/users/2/disable", status: http.StatusOK},
+	{name: "users_disable_self", method: http.MethodPost, path: "/api/users/1/disable", status: http.StatusBadRequest},
+	{name: "users_enable", method: http.MethodPost, path: "/api/users/2/enable", status: http.StatusOK},
+	{name: "users_delete", method: http.MethodDelete, path: "/api/users/2", status: http.StatusOK},
+	{name: "users_delete_unknown", method: http.MethodDelete, path: "/api/users/99", status: http.StatusBadRequest},
+	{name: "users_me_without_token", method: http.MethodGet, path: "/api/users/me", anonymous: true, status: http.StatusUnauthorized},
+	{name: "users_me_not_bearer", method: http.MethodGet, path: "/api/users/me", authorization: "Basic YWRtaW46c2VjcmV0", status: http.StatusUnauthorized},
+	{name: "read_invalid_token", method: http.MethodGet, path: "/api/genres", authorization: "Bearer not.a.token", status: http.StatusUnauthorized},
+	{name: "write_without_token", method: http.MethodPost, path: "/api/genres", body: `{"name":"Horror"}`, anonymous: true, status: http.StatusUnauthorized},
+	{name: "write_empty_bearer", method: http.MethodPost, path: "/api/genres", body: `{"name":"Horror"}`, authorization: "Bearer ", status: http.StatusUnauthorized},
+	{name: "write_invalid_token", method: http.MethodPost, path: "/api/genres", body: `{"name":"Horror"}`, authorization: "Bearer not.a.token", status: http.StatusUnauthorized},
+
+	{name: "nationals_create", method: http.MethodPost, path: "/api/nationals", body: `{"name":"Japan"}`, status: http.StatusOK},
+	{name: "nationals_create_breaks_contract", method: http.MethodPost, path: "/api/nationals", body: `{"name":81}`, status: http.StatusBadRequest},
+	{name: "nationals_list", method: http.MethodGet, path: "/api/nationals", status: http.StatusOK},
+	{name: "nationals_search", method: http.MethodGet, path: "/api/nationals/search?name=Korea", status: http.StatusOK},
+	{name: "nationals_get", method: http.MethodGet, path: "/api/nationals/1", status: http.StatusOK},
+	{name: "nationals_get_unknown", method: http.MethodGet, path: "/api/nationals/99", status: http.StatusOK},
+	{name: "nationals_update", method: http.MethodPut, path: "/api/nationals/2", body: `{"name":"USA"}`, status: http.StatusOK},
+	{name: "nationals_delete", method: http.MethodDelete, path: "/api/nationals/2", status: http.StatusOK},
+
+	{name: "actors_create", method: http.MethodPost, path: "/api/actors", body: `{"name":"Lee Sun-kyun","date_of_birth":"1975-03-02","nationality_id":1}`, status: http.StatusOK},
+	{name: "actors_list", method: http.MethodGet, path: "/api/actors", status: http.StatusOK},
+	{name: "actors_search", method: http.MethodGet, path: "/api/actors/search?name=Song%20Kang-ho", status: http.StatusOK},
+	{name: "actors_get", method: http.MethodGet, path: "/api/actors/1", status: http.StatusOK},
+	{name: "actors_movies", method: http.MethodGet, path: "/api/actors/1/movies?order=asc", status: http.StatusOK},
+	{name: "actors_update", method: http.MethodPut, path: "/api/actors/3", body: `{"name":"Choi Woo-shik","date_of_birth":"1990-03-26","nationality_id":2}`, status: http.StatusOK},
+	{name: "actors_delete_credited", method: http.MethodDelete, path: "/api/actors/3", status: http.StatusBadRequest},
+
+	{name: "directors_create", method: http.MethodPost, path: "/api/directors", body: `{"name":"Kim Jee-woon","date_of_birth":"1964-07-06","nationality_id":1}`, status: http.StatusOK},
+	{name: "directors_list", method: http.MethodGet, path: "/api/directors", status: http.StatusOK},
+	{name: "directors_search", method: http.MethodGet, path: "/api/directors/search?name=Bong%20Joon-ho", status: http.StatusOK},
+	{name: "directors_get", method: http.MethodGet, path: "/api/directors/1", status: http.StatusOK},
+	{name: "directors_movies", method: http.MethodGet, path: "/api/directors/1/movies", status: http.StatusOK},
+	{name: "directors_update", method: http.MethodPut, path: "/api/directors/1", body: `{"name":"Bong Joon-ho","date_of_birth":"1969-09-14","nationality_id":1}`, status: http.StatusOK},
+	{name: "directors_delete", method: http.MethodDelete, path: "/api/directors/1", status: http.StatusOK},
+
+	{name: "people_create", method: http.MethodPost, path: "/api/people", body: `{"name":"Cho Yeo-jeong","date_of_birth":"1981-02-10","nationality_id":1,"is_actor":true}`, status: http.StatusOK},
+	{name: "people_list", method: http.MethodGet, path: "/api/people", status: http.StatusOK},
+	{name: "people_get", method: http.MethodGet, path: "/api/people/2", status: http.StatusOK},
+	{name: "people_credits", method: http.MethodGet, path: "/api/people/1/credits", status: http.StatusOK},
+	{name: "people_update", method: http.MethodPut, path: "/api/people/3", body: `{"name":"Choi Woo-shik","date_of_birth":"1990-03-26","nationality_id":1}`, status: http.StatusOK},
+	{name: "people_delete", method: http.MethodDelete, path: "/api/people/4", status: http.StatusOK},
+	{name: "people_delete_credited", method: http.MethodDelete, path: "/api/people/3", status: http.StatusBadRequest},
+
+	{name: "genres_create", method: http.MethodPost, path: "/api/genres", body: `{"name":"Horror"}`, status: http.StatusOK},
+	{name: "genres_create_duplicate", method: http.MethodPost, path: "/api/genres", body: `{"name":"Drama"}`, status: http.StatusBadRequest},
+	{name: "genres_list", method: http.MethodGet, path: "/api/genres", status: http.StatusOK},
+	{name: "genres_search", method: http.MethodGet, path: "/api/genres/search?name=Drama", status: http.StatusOK},
+	{name: "genres_get", method: http.MethodGet, path: "/api/genres/1", status: http.StatusOK},
+	{name: "genres_movies", method: http.MethodGet, path: "/api/genres/1/movies", status: http.StatusOK},
+	{name: "genres_update", method: http.MethodPut, path: "/api/genres/3", body: `{"name":"Black Comedy"}`, status: http.StatusOK},
+	{name: "genres_delete", method: http.MethodDelete, path: "/api/genres/3", status: http.StatusOK},
+
+	{name: "movies_create", method: http.MethodPost, path: "/api/movies", body: `{"title":"Mother","release_date":"2009-05-28","duration":128,"language":"Korean","national_id":1,"genre_ids":[1,2]}`, status: http.StatusOK},
+	{name: "movies_list", method: http.MethodGet, path: "/api/movies", status: http.StatusOK},
+	{name: "movies_search", method: http.MethodGet, path: "/api/movies/search?title=Parasite", status: http.StatusOK},
+	{name: "movies_get", method: http.MethodGet, path: "/api/movies/1", status: http.StatusOK},
+	{name: "movies_get_anonymous", method: http.MethodGet, path: "/api/movies/1", anonymous: true, status: http.StatusOK},
+	{name: "movies_list_preferred", method: http.MethodGet, path: "/api/movies", role: "user", status: http.StatusOK},
+	{name: "movies_update", method: http.MethodPut, path: "/api/movies/1", body: `{"title":"Parasite","release_date":"2019-05-30","duration":132,"language":"Korean","national_id":1,"genre_ids":[1,3]}`, status: http.StatusOK},
+	{name: "movies_delete", method: http.MethodDelete, path: "/api/movies/2", status: http.StatusOK},
+	{name: "movies_upload_poster_rejects_type", method: http.MethodPost, path: "/api/movies/1/upload_poster", status: http.StatusBadRequest},
+
+	{name: "recommendation_list", method: http.MethodGet, path: "/api/movies/recommendation", status: http.StatusOK},
+	{name: "recommendation_create", method: http.MethodPost, path: "/api/movies/recommendation", body: `{"movie_id":2}`, status: http.StatusOK},
+	{name: "recommendation_delete", method: http.MethodDelete, path: "/api/movies/recommendation/1", status: http.StatusOK},
+
+	{name: "movie_actors_create", method: http.MethodPost, path: "/api/movies/2/actors", body: `{"actor_id":3,"role":"Detective","billing_order":2}`, status: http.StatusOK},
+	{name: "movie_actors_get", method: http.MethodGet, path: "/api/movies/1/actors", status: http.StatusOK},
+	{name: "movie_actors_replace", method: http.MethodPut, path: "/api/movies/1/actors", body: `{"actors":[{"actor_id":3,"role":"Kim Ki-woo","credit_type":"lead","billing_order":1}]}`, status: http.StatusOK},
+	{name: "movie_actors_update", method: http.MethodPut, path: "/api/movies/1/actors/3", body: `{"role":"Kevin","billing_order":3}`, status: http.StatusOK},
+	{name: "movie_actors_delete", method: http.MethodDelete, path: "/api/movies/1/actors/3", status: http.StatusOK},
+
+	{name: "movie_directors_create", method: http.MethodPost, path: "/api/movies/1/directors", body: `{"director_id":2}`, status: http.StatusOK},
+	{name: "movie_directors_create_not_director", method: http.MethodPost, path: "/api/movies/1/directors", body: `{"director_id":3}`, status: http.StatusBadRequest},
+	{name: "movie_directors_get", method: http.MethodGet, path: "/api/movies/1/directors", status: http.StatusOK},
+	{name: "movie_directors_delete", method: http.MethodDelete, path: "/api/movies/1/directors/1", status: http.StatusOK},
+
+	{name: "movie_crew_create", method: http.MethodPost, path: "/api/movies/2/crew", body: `{"person_id":2,"job":"writer"}`, status: http.StatusOK},
+	{name: "movie_crew_create_unknown_job", method: http.MethodPost, path: "/api/movies/2/crew", body: `{"person_id":2,"job":"caterer"}`, status: http.StatusBadRequest},
+	{name: "movie_crew_get", method: http.MethodGet, path: "/api/movies/1/crew?job=director", status: http.StatusOK},
+	{name: "movie_crew_delete", method: http.MethodDelete, path: "/api/movies/1/crew/2/writer", status: http.StatusOK},
+	{name: "crew_jobs", method: http.MethodGet, path: "/api/crew/jobs", status: http.StatusOK},
+	{name: "crew_person", method: http.MethodGet, path: "/api/crew/2", status: http.StatusOK},
+
+	{name: "movie_genres_create", method: http.MethodPost, path: "/api/movies/2/genres", body: `{"genre_ids":[2]}`, status: http.StatusOK},
+	{name: "movie_genres_get", method: http.MethodGet, path: "/api/movies/1/genres", status: http.StatusOK},
+	{name: "movie_genres_delete", method: http.MethodDelete, path: "/api/movies/1/genres/2", status: http.StatusOK},
+}
+
+func TestRoutes(t *testing.T) {
+	covered := map[string]bool{}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Responses that do not match the OpenAPI document are logged by
+			// the contract middleware, as errors.
+			var logs bytes.Buffer
+			r := newServer(t, covered, logging.New(&logs, "warn"))
+
+			req := newRequest(t, tc)
+			if tc.apiKey != "" {
+				req.Header.Set("X-API-Key", tc.apiKey)
+			} else if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			} else if !tc.anonymous {
+				token, err := helpers.GenerateTokenJWT(keys, 1, "admin", "admin")
+				if tc.role == "user" {
+					token, err = helpers.GenerateTokenJWT(keys, 2, "hyejin", "user")
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code, w.Body.String())
+			assert.Empty(t, logs.String())
+			assert.Len(t, w.Header().Get("X-Request-ID"), 32)
+			if w.Code >= http.StatusBadRequest {
+				var failure web.ResponseError
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &failure))
+				assert.Equal(t, w.Header().Get("X-Request-ID"), failure.RequestID)
+			}
+			if !tc.volatile {
+				assertGolden(t, tc.name, w)
+			}
+		})
+	}
+
+	t.Run("openapi_coverage", func(t *testing.T) {
+		for _, operation := range documentedOperations(t) {
+			assert.True(t, covered[operation], "%s is documented in /api/openapi.json but no case exercises it", operation)
+		}
+	})
+}
+
+func newRequest(t *testing.T, tc routeCase) *http.Request {
+	if tc.name == "movies_upload_poster_rejects_type" {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, err := form.CreateFormFile("poster_file", "poster.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write([]byte("not an image"))
+		form.Close()
+
+		req := httptest.NewRequest(tc.method, tc.path, &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		return req
+	}
+
+	req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+	if tc.body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req
+}
+
+// volatileKeys are response fields that change between runs.
+var volatileKeys = map[string]string{
+	"created_at": "<timestamp>",
+	"updated_at": "<timestamp>",
+	"token":      "<token>",
+	"key":        "<key>",
+	"latency_ms": "<latency>",
+	"request_id": "<request id>",
+}
+
+func normalize(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if placeholder, ok := volatileKeys[key]; ok {
+				if _, isObject := item.(map[string]any); !isObject {
+					v[key] = placeholder
+					continue
+				}
+			}
+			v[key] = normalize(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+	}
+	return value
+}
+
+func assertGolden(t *testing.T, name string, w *httptest.ResponseRecorder) {
+	t.Helper()
+
+	body := w.Body.String()
+	var decoded any
+	if json.Unmarshal(w.Body.Bytes(), &decoded) == nil {
+		var pretty strings.Builder
+		encoder := json.NewEncoder(&pretty)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(normalize(decoded))
+		body = strings.TrimSuffix(pretty.String(), "\n")
+	}
+	got := fmt.Sprintf("%d\n%s\n", w.Code, body)
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("missing golden file, run go test ./e2e -update: %v", err)
+	}
+	assert.Equal(t, string(want), got)
+}
+
+var pathParam = regexp.MustCompile(`\{[^}]+\}|[:*][^/]+`)
+
+// routeKey identifies a route independently of how its path parameters are
+// written, since OpenAPI uses {id} where gin uses :id.
+func routeKey(method, path string) string {
+	return strings.ToUpper(method) + " " + pathParam.ReplaceAllString(path, "{}")
+}
+
+// documentedOperations lists the operations of the served OpenAPI document
+// as route keys.
+func documentedOperations(t *testing.T) []string {
+	w := httptest.NewRecorder()
+	newServer(t, map[string]bool{}, logging.Discard()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+
+	var spec struct {
+		Paths map[string]map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	var operations []string
+	for path, methods := range spec.Paths {
+		for method := range methods {
+			operations = append(operations, routeKey(method, path))
+		}
+	}
+	sort.Strings(operations)
+	return operations
+}
+
+// newLimitedServer builds the router on a freshly seeded database with
+// config, for the tests of state kept across requests.
+func newLimitedServer(t *testing.T, config *config.Config) *gin.Engine {
+	store, repositories := newDatabase(t)
+	return app.InitialozedRoute(gin.New(), config, keys, store, repositories, metrics.New(), ratelimit.NewMemoryStore(), notify.NewLogNotifier(logging.Discard()), logging.Discard())
+}
+
+func login(r *gin.Engine, ip, password string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(`{"username":"admin","password":"`+password+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = ip + ":40000"
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit(t *testing.T) {
+	limited := *cfg
+	limited.RateLimit.Auth = ratelimit.Limit{Requests: 2, Per: time.Minute}
+	r := newLimitedServer(t, &limited)
+
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, http.StatusBadRequest, login(r, "10.0.0.1", "wrong").Code)
+	}
+
+	w := login(r, "10.0.0.1", "wrong")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	assert.Equal(t, "2", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", w.Header().Get("X-RateLimit-Reset"))
+
+	// Other clients are not held back.
+	assert.Equal(t, http.StatusBadRequest, login(r, "10.0.0.2", "wrong").Code)
+}
+
+func TestLoginLockout(t *testing.T) {
+	lockout := *cfg
+	lockout.Login = config.Login{FreeFailures: 0, MaxFailures: 1, IPMaxFailures: 100, LockoutDuration: time.Minute}
+	r := newLimitedServer(t, &lockout)
+
+	w := login(r, "10.0.0.1", "wrong")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid username or password")
+
+	// Even the right password is refused, from any client.
+	w = login(r, "10.0.0.2", fixturePassword)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+}
+
+func TestPasswordResetNeedsNotifier(t *testing.T) {
+	store, repositories := newDatabase(t)
+	r := app.InitialozedRoute(gin.New(), cfg, keys, store, repositories, metrics.New(), ratelimit.NewMemoryStore(), nil, logging.Discard())
+
+	for path, body := range map[string]string{
+		"/api/auth/password/forgot": `{"username":"admin"}`,
+		"/api/auth/password/reset":  `{"token":"not-a-token","password":"Another-passw0rd"}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotImplemented, w.Code, path)
+	}
+}
+
+// oidcStart starts a login at provider through the router, as the user of
+// token unless it is empty, and returns the path of the callback the provider
+// sends the browser back to, and the cookie the browser has for it.
+func oidcStart(t *testing.T, r *gin.Engine, provider *oidctest.Server, token string) (string, *http.Cookie) {
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/login", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("login answered %d: %s", w.Code, w.Body)
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("login set %d cookies", len(cookies))
+	}
+
+	var login struct {
+		Data web.OIDCLoginResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &login); err != nil {
+		t.Fatal(err)
+	}
+	redirect, err := provider.Authorize(login.Data.AuthorizationURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return "/api/auth/oidc/callback?" + redirect.RawQuery, cookies[0]
+}
+
+// oidcLogin logs in like oidcStart, and returns the answer of the callback
+// and what oidcStart returned.
+func oidcLogin(t *testing.T, r *gin.Engine, provider *oidctest.Server, token string) (*httptest.ResponseRecorder, string, *http.Cookie) {
+	callback, cookie := oidcStart(t, r, provider, token)
+	return oidcCallback(r, callback, cookie), callback, cookie
+}
+
+// oidcCallback calls the callback path with the state cookie, unless it is
+// nil.
+func oidcCallback(r *gin.Engine, callback string, cookie *http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, callback, nil)
+	if cookie != nil {
+		req.AddCookie(&http.Cookie{Name: cookie.Name, Value: cookie.Value})
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// oidcUser logs in at provider like oidcLogin, and returns who the token the
+// callback answered is for.
+func oidcUser(t *testing.T, r *gin.Engine, provider *oidctest.Server, token string) *web.UserInfoResponse {
+	w, _, _ := oidcLogin(t, r, provider, token)
+	var response struct {
+		Data web.AuthModelResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	valid, user, err := helpers.ValidateTokenJWT(keys, response.Data.Token)
+	if err != nil || !valid {
+		t.Fatalf("no valid token in %s: %v", w.Body, err)
+	}
+	return user
+}
+
+func TestOIDCLogin(t *testing.T) {
+	provider := oidctest.NewServer("efilm", "client-secret")
+	defer provider.Close()
+
+	withOIDC := *cfg
+	withOIDC.OIDC = config.OIDC{Issuer: provider.URL, ClientID: "efilm", ClientSecret: "client-secret", RedirectURL: "http://localhost:3000/callback", LoginTTL: time.Minute}
+	r := newLimitedServer(t, &withOIDC)
+
+	// The first login creates the user, the next ones find them.
+	provider.SetUser(oidctest.User{Subject: "1001", Email: "jieun@example.com", Name: "Lee Ji-eun"})
+	created := oidcUser(t, r, provider, "")
+	assert.Equal(t, "jieun", created.Username)
+	assert.Equal(t, "user", created.Role)
+	assert.Equal(t, created.UserID, oidcUser(t, r, provider, "").UserID)
+
+	// Taken usernames are numbered.
+	provider.SetUser(oidctest.User{Subject: "1002", PreferredUsername: "Admin"})
+	assert.Equal(t, "admin2", oidcUser(t, r, provider, "").Username)
+
+	// A logged in user links the identity to their account.
+	hyejin, err := helpers.GenerateTokenJWT(keys, 2, "hyejin", "user")
+	assert.NoError(t, err)
+	provider.SetUser(oidctest.User{Subject: "2002"})
+	assert.Equal(t, 2, oidcUser(t, r, provider, hyejin).UserID)
+	assert.Equal(t, 2, oidcUser(t, r, provider, "").UserID)
+
+	provider.SetUser(oidctest.User{Subject: "1001"})
+	w, _, _ := oidcLogin(t, r, provider, hyejin)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "this identity is linked to another user")
+
+	// States are single use.
+	w, callback, cookie := oidcLogin(t, r, provider, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = oidcCallback(r, callback, cookie)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "login state is invalid or expired")
+}
+
+func TestOIDCLoginStaysInTheBrowser(t *testing.T) {
+	provider := oidctest.NewServer("efilm", "client-secret")
+	defer provider.Close()
+	provider.SetUser(oidctest.User{Subject: "1001"})
+
+	withOIDC := *cfg
+	withOIDC.OIDC = config.OIDC{Issuer: provider.URL, ClientID: "efilm", ClientSecret: "client-secret", RedirectURL: "https://efilm.example.com/callback", LoginTTL: time.Minute}
+	r := newLimitedServer(t, &withOIDC)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/login", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if cookies := w.Result().Cookies(); assert.Len(t, cookies, 1) {
+		assert.Equal(t, "/api/auth/oidc", cookies[0].Path)
+		assert.Equal(t, 60, cookies[0].MaxAge)
+		assert.True(t, cookies[0].HttpOnly)
+		assert.True(t, cookies[0].Secure)
+		assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+	}
+
+	// A victim sent to the callback of a login the attacker started has no
+	// cookie, or the one of another login, and is not logged in as the
+	// attacker.
+	callback, cookie := oidcStart(t, r, provider, "")
+	_, other := oidcStart(t, r, provider, "")
+	for _, sent := range []*http.Cookie{nil, other} {
+		w := oidcCallback(r, callback, sent)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "This login was not started in this browser")
+	}
+
+	// The callback forgets the cookie.
+	w = oidcCallback(r, callback, cookie)
+	assert.Equal(t, http.StatusOK, w.Code)
+	if cookies := w.Result().Cookies(); assert.Len(t, cookies, 1) {
+		assert.Equal(t, "efilm_oidc_state", cookies[0].Name)
+		assert.Negative(t, cookies[0].MaxAge)
+	}
+}
+
+func TestTokensFollowTheUser(t *testing.T) {
+	r := newLimitedServer(t, cfg)
+	get := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/users", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	token := func(ID int, username, role string) string {
+		token, err := helpers.GenerateTokenJWT(keys, ID, username, role)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	// The role comes from the database, not from the token.
+	demoted := token(2, "hyejin", "admin")
+	assert.Equal(t, http.StatusForbidden, get(demoted).Code)
+
+	admin := token(1, "admin", "admin")
+	req := httptest.NewRequest(http.MethodPost, "/api/users/2/disable", nil)
+	req.Header.Set("Authorization", "Bearer "+admin)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	w := get(demoted)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "this account is disabled")
+
+	w = get(token(99, "ghost", "admin"))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "this account no longer exists")
+}
+
+func TestBodyLimit(t *testing.T) {
+	limited := *cfg
+	limited.Server.MaxBodyBytes = 64
+	limited.Server.MaxUploadBytes = 1024
+	r := newLimitedServer(t, &limited)
+	admin, err := helpers.GenerateTokenJWT(keys, 1, "admin", "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	send := func(body io.Reader, contentType string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/genres", body)
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("Authorization", "Bearer "+admin)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	name := `{"name":"` + strings.Repeat("a", 64) + `"}`
+
+	w := send(strings.NewReader(name), "application/json")
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), "Request body is larger than 64 bytes")
+
+	// Without a Content-Length the body is cut off while it is read.
+	w = send(io.LimitReader(strings.NewReader(name), int64(len(name))), "application/json")
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	assert.Equal(t, http.StatusOK, send(strings.NewReader(`{"name":"Horror"}`), "application/json").Code)
+
+	upload := func(size int) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="poster_file"; filename="poster.png"`)
+		header.Set("Content-Type", "image/png")
+		part, err := form.CreatePart(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write(bytes.Repeat([]byte{0}, size))
+		form.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/movies/1/upload_poster", io.LimitReader(&body, int64(body.Len())))
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		req.Header.Set("Authorization", "Bearer "+admin)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Uploads are held to their own, larger, cap.
+	w = upload(2048)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), "Poster file is larger than 1024 bytes")
+	assert.NotEqual(t, http.StatusRequestEntityTooLarge, upload(512).Code)
+}
+
+func TestUsersInfoIsDeprecated(t *testing.T) {
+	r := newLimitedServer(t, cfg)
+	token, err := helpers.GenerateTokenJWT(keys, 2, "hyejin", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	get := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	info, me := get(http.MethodPost, "/api/users/info"), get(http.MethodGet, "/api/users/me")
+	assert.Equal(t, http.StatusOK, info.Code)
+	assert.JSONEq(t, me.Body.String(), info.Body.String())
+	assert.Equal(t, "true", info.Header().Get("Deprecation"))
+	assert.Equal(t, `</api/users/me>; rel="successor-version"`, info.Header().Get("Link"))
+	assert.Empty(t, me.Header().Get("Deprecation"))
+}
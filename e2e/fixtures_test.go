@@ -0,0 +1,133 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fixturePassword is the password of the seeded "admin" user.
+const fixturePassword = "secret"
+
+// API keys of the seeded consumers.
+const (
+	fixtureReadKey    = "efk_fixture-read-key"
+	fixtureWriteKey   = "efk_fixture-write-key"
+	fixtureExpiredKey = "efk_fixture-expired-key"
+)
+
+func date(value string) time.Time {
+	parsed, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// seed fills store with a small catalogue. IDs are deterministic, so cases can
+// refer to them directly:
+//
+//	nationals: 1 Korea, 2 United States
+//	people:    1 Song Kang-ho (actor), 2 Bong Joon-ho (director), 3 Choi Woo-shik (actor),
+//	           4 Park Chan-wook (director)
+//	genres:    1 Drama, 2 Thriller, 3 Comedy
+//	movies:    1 Parasite (Drama, Thriller), 2 Memories of Murder (Drama)
+//	users:     1 admin, 2 hyejin (user, display name Kim Hye-jin)
+//	api keys:  1 catalogue-reader (read), 2 catalogue-writer (write),
+//	           3 retired (read, expired)
+func seed(t *testing.T, store repository.Querier, repositories repository.Repositories) {
+	t.Helper()
+	ctx := context.Background()
+
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	for _, name := range []string{"Korea", "United States"} {
+		must(repositories.National.Save(ctx, store, &domain.National{Name: name}))
+	}
+
+	must(repositories.Actor.Save(ctx, store, &domain.Person{Name: "Song Kang-ho", DateOfBirth: date("1967-01-17"), NationalityID: 1}))
+	must(repositories.Director.Save(ctx, store, &domain.Person{Name: "Bong Joon-ho", DateOfBirth: date("1969-09-14"), NationalityID: 1}))
+	must(repositories.Actor.Save(ctx, store, &domain.Person{Name: "Choi Woo-shik", DateOfBirth: date("1990-03-26"), NationalityID: 1}))
+	must(repositories.Director.Save(ctx, store, &domain.Person{Name: "Park Chan-wook", DateOfBirth: date("1963-08-23"), NationalityID: 1}))
+
+	for _, name := range []string{"Drama", "Thriller", "Comedy"} {
+		must(repositories.Genre.Save(ctx, store, &domain.Genre{Name: name}))
+	}
+
+	parasite, err := repositories.Movie.Save(ctx, store, &domain.Movie{
+		Title:       "Parasite",
+		ReleaseDate: date("2019-05-30"),
+		Duration:    132,
+		Plot:        "Greed and class discrimination threaten the newly formed symbiotic relationship between the wealthy Park family and the destitute Kim clan.",
+		Language:    "Korean",
+		NationalID:  1,
+	})
+	must(err)
+	memories, err := repositories.Movie.Save(ctx, store, &domain.Movie{
+		Title:       "Memories of Murder",
+		ReleaseDate: date("2003-04-25"),
+		Duration:    131,
+		Plot:        "In a small Korean province in 1986, two detectives struggle with the case of multiple young women being found raped and murdered by an unknown culprit.",
+		Language:    "Korean",
+		NationalID:  1,
+	})
+	must(err)
+
+	must(repositories.MovieGenre.Save(ctx, store, parasite, 1))
+	must(repositories.MovieGenre.Save(ctx, store, parasite, 2))
+	must(repositories.MovieGenre.Save(ctx, store, memories, 1))
+
+	must(repositories.MovieActor.SaveCredit(ctx, store, parasite, &domain.ActorMovie{
+		ID: 1, Role: "Kim Ki-taek", CharacterName: "Kim Ki-taek", CreditType: domain.CreditTypeLead, BillingOrder: 1,
+	}))
+	must(repositories.MovieActor.SaveCredit(ctx, store, parasite, &domain.ActorMovie{
+		ID: 3, Role: "Kim Ki-woo", CharacterName: "Kim Ki-woo", CreditType: domain.CreditTypeSupporting, BillingOrder: 2,
+	}))
+	must(repositories.MovieActor.SaveCredit(ctx, store, memories, &domain.ActorMovie{
+		ID: 1, Role: "Park Doo-man", CharacterName: "Park Doo-man", CreditType: domain.CreditTypeLead, BillingOrder: 1,
+	}))
+
+	must(repositories.MovieCrew.Save(ctx, store, parasite, 2, domain.JobDirector))
+	must(repositories.MovieCrew.Save(ctx, store, parasite, 2, "writer"))
+	must(repositories.MovieCrew.Save(ctx, store, memories, 2, domain.JobDirector))
+
+	must(repositories.Recommendation.Save(ctx, store, parasite))
+
+	password, err := bcrypt.GenerateFromPassword([]byte(fixturePassword), bcrypt.MinCost)
+	must(err)
+	must(repositories.Auth.Register(ctx, store, &domain.Auth{Username: "admin", Password: string(password), Role: domain.RoleAdmin}))
+	must(repositories.Auth.Register(ctx, store, &domain.Auth{Username: "hyejin", Password: string(password), Role: domain.RoleUser}))
+	// Postgres registers every user with the default role.
+	must(repositories.Auth.UpdateRole(ctx, store, 1, domain.RoleAdmin))
+	must(repositories.Auth.UpdateProfile(ctx, store, &domain.Auth{ID: 2, DisplayName: "Kim Hye-jin", PreferredLanguages: []string{"ko"}, PreferredGenres: []int{1}}))
+
+	for _, key := range []struct {
+		name, key, scope string
+		expiresAt        time.Time
+	}{
+		{"catalogue-reader", fixtureReadKey, domain.APIKeyRead, time.Time{}},
+		{"catalogue-writer", fixtureWriteKey, domain.APIKeyWrite, date("2099-01-01")},
+		{"retired", fixtureExpiredKey, domain.APIKeyRead, date("2020-01-01")},
+	} {
+		_, err := repositories.APIKey.Save(ctx, store, &domain.APIKey{
+			Name:      key.name,
+			Prefix:    key.key[:12],
+			KeyHash:   helpers.HashSecret(key.key),
+			Scope:     key.scope,
+			CreatedBy: 1,
+			CreatedAt: date("2019-06-01"),
+			ExpiresAt: key.expiresAt,
+		})
+		must(err)
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !postgres
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+	"github.com/dimassfeb-09/efilm-api.git/repository/memory"
+)
+
+// startDatabase has nothing to start for the in-memory store. Build with
+// -tags postgres to run against Postgres instead, see postgres_test.go.
+func startDatabase() (stop func(), err error) {
+	return func() {}, nil
+}
+
+// newDatabase returns a freshly seeded in-memory store and its repositories.
+func newDatabase(t *testing.T) (repository.DB, repository.Repositories) {
+	store := memory.NewStore()
+	repositories := memory.NewRepositories(store)
+	seed(t, store, repositories)
+	return store, repositories
+}
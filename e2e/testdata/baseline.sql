@@ -0,0 +1,96 @@
+-- The schema migrations/ starts from. It was made by hand before the API
+-- used golang-migrate, so no migration creates it; the Postgres harness
+-- loads it, then applies every migrations/*.up.sql on top.
+CREATE TABLE national
+(
+    id         SERIAL PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE genres
+(
+    id         SERIAL PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE actors
+(
+    id             SERIAL PRIMARY KEY,
+    name           VARCHAR(255) NOT NULL,
+    date_of_birth  DATE         NOT NULL,
+    nationality_id INTEGER      NOT NULL REFERENCES national (id),
+    created_at     TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE directors
+(
+    id             SERIAL PRIMARY KEY,
+    name           VARCHAR(255) NOT NULL,
+    date_of_birth  DATE         NOT NULL,
+    nationality_id INTEGER      NOT NULL REFERENCES national (id),
+    created_at     TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE movies
+(
+    id             SERIAL PRIMARY KEY,
+    title          VARCHAR(255) NOT NULL,
+    release_date   DATE         NOT NULL,
+    duration       INTEGER      NOT NULL,
+    plot           TEXT         NOT NULL DEFAULT '',
+    poster_url     VARCHAR(255) NOT NULL DEFAULT '',
+    trailer_url    VARCHAR(255) NOT NULL DEFAULT '',
+    language       VARCHAR(50)  NOT NULL,
+    nationality_id INTEGER      NOT NULL REFERENCES national (id),
+    created_at     TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE movie_genres
+(
+    movie_id INTEGER NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    genre_id INTEGER NOT NULL REFERENCES genres (id),
+    PRIMARY KEY (movie_id, genre_id)
+);
+
+CREATE TABLE movie_actors
+(
+    movie_id INTEGER      NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    actor_id INTEGER      NOT NULL REFERENCES actors (id),
+    role     VARCHAR(255) NOT NULL,
+    PRIMARY KEY (movie_id, actor_id)
+);
+
+CREATE TABLE movie_directors
+(
+    movie_id    INTEGER NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    director_id INTEGER NOT NULL REFERENCES directors (id),
+    PRIMARY KEY (movie_id, director_id)
+);
+
+CREATE TABLE recommendation
+(
+    id       SERIAL PRIMARY KEY,
+    movie_id INTEGER NOT NULL UNIQUE REFERENCES movies (id) ON DELETE CASCADE
+);
+
+CREATE TABLE users
+(
+    id       SERIAL PRIMARY KEY,
+    username VARCHAR(255) NOT NULL UNIQUE,
+    password VARCHAR(255) NOT NULL,
+    role     VARCHAR(20)  NOT NULL DEFAULT 'user'
+);
+
+-- The table golang-migrate keeps its version in.
+CREATE TABLE schema_migrations
+(
+    version BIGINT  NOT NULL PRIMARY KEY,
+    dirty   BOOLEAN NOT NULL
+);
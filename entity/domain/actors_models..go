@@ -1,12 +1,4 @@
 package domain
 
-import "time"
-
-type Actor struct {
-	ID            int       `json:"id"`
-	Name          string    `json:"name"`
-	DateOfBirth   time.Time `json:"date_of_birth"`
-	NationalityID int       `json:"nationality_id"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
-}
+// Actor is a person listed in the actors view of people.
+type Actor = Person
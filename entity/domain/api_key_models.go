@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// Scopes of API keys. Read keys may only call GET routes.
+const (
+	APIKeyRead  = "read"
+	APIKeyWrite = "write"
+)
+
+// APIKey lets a server call the API without a user. ExpiresAt and LastUsedAt
+// are zero for keys that never expire or were never used.
+type APIKey struct {
+	ID         int
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scope      string
+	CreatedBy  int
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+}
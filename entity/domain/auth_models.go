@@ -1,8 +1,22 @@
 package domain
 
+import "time"
+
+// Roles of users. Only admins may manage users, logins and API keys.
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
 type Auth struct {
-	ID       int    `json:"id"`
-	Username string `json:"username"`
-	Password string `json:"password"`
-	Role     string `json:"role"`
+	ID                 int      `json:"id"`
+	Username           string   `json:"username"`
+	Password           string   `json:"password"`
+	Role               string   `json:"role"`
+	DisplayName        string   `json:"display_name"`
+	AvatarURL          string   `json:"avatar_url"`
+	PreferredLanguages []string `json:"preferred_languages"`
+	PreferredGenres    []int    `json:"preferred_genres"`
+	// DisabledAt is zero for users who may log in.
+	DisabledAt time.Time `json:"disabled_at"`
 }
@@ -1,12 +1,4 @@
 package domain
 
-import "time"
-
-type Director struct {
-	ID            int       `json:"id"`
-	Name          string    `json:"name"`
-	DateOfBirth   time.Time `json:"date_of_birth"`
-	NationalityID int       `json:"nationality_id"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
-}
+// Director is a person listed in the directors view of people.
+type Director = Person
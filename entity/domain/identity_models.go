@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Identity links a user to the subject an OpenID Connect issuer knows them as.
+type Identity struct {
+	Issuer    string
+	Subject   string
+	UserID    int
+	CreatedAt time.Time
+}
+
+// OIDCLogin is a login started at an OpenID Connect provider, to be finished
+// by whoever comes back with the state hashed to StateHash until ExpiresAt.
+// LinkUserID is the user to link the identity to, or zero to log in with it.
+type OIDCLogin struct {
+	StateHash    string
+	CodeVerifier string
+	Nonce        string
+	LinkUserID   int
+	ExpiresAt    time.Time
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// LoginAttempt counts the recent failed logins of a username or client IP.
+// No login is tried for it before LockedUntil.
+type LoginAttempt struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   time.Time
+}
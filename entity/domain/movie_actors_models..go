@@ -2,11 +2,22 @@ package domain
 
 import "time"
 
+const (
+	CreditTypeLead       = "lead"
+	CreditTypeSupporting = "supporting"
+	CreditTypeCameo      = "cameo"
+	CreditTypeVoice      = "voice"
+)
+
 type ActorMovie struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	Role        string    `json:"role"`
-	DateOfBirth time.Time `json:"date_of_birth"`
+	ID            int       `json:"id"`
+	Name          string    `json:"name"`
+	Role          string    `json:"role"`
+	CharacterName string    `json:"character_name"`
+	CreditType    string    `json:"credit_type"`
+	BillingOrder  int       `json:"billing_order"`
+	Notes         string    `json:"notes"`
+	DateOfBirth   time.Time `json:"date_of_birth"`
 }
 
 type MovieActor struct {
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+const JobDirector = "director"
+
+type CrewJob struct {
+	Job        string `json:"job"`
+	Department string `json:"department"`
+}
+
+type CrewMember struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	DateOfBirth time.Time `json:"date_of_birth"`
+	Job         string    `json:"job"`
+	Department  string    `json:"department"`
+}
+
+type MovieCrew struct {
+	Movie Movie        `json:"movie"`
+	Crew  []CrewMember `json:"crew"`
+}
+
+type CrewCredit struct {
+	Movie      Movie  `json:"movie"`
+	Job        string `json:"job"`
+	Department string `json:"department"`
+}
+
+type PersonCrew struct {
+	Person  Person       `json:"person"`
+	Credits []CrewCredit `json:"credits"`
+}
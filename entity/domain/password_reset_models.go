@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// PasswordReset lets whoever holds the token hashed to TokenHash choose a new
+// password for the user until ExpiresAt.
+type PasswordReset struct {
+	TokenHash string
+	UserID    int
+	ExpiresAt time.Time
+}
@@ -10,3 +10,19 @@ type ActorModelResponse struct {
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
 }
+
+type ActorMovieModelResponse struct {
+	MovieID       int       `json:"movie_id"`
+	Title         string    `json:"title"`
+	ReleaseDate   time.Time `json:"release_date"`
+	Role          string    `json:"role"`
+	CharacterName string    `json:"character_name"`
+	CreditType    string    `json:"credit_type"`
+	BillingOrder  int       `json:"billing_order"`
+}
+
+type ActorMoviesModelResponse struct {
+	ActorID int                       `json:"actor_id"`
+	Name    string                    `json:"name"`
+	Movies  []ActorMovieModelResponse `json:"movies"`
+}
@@ -0,0 +1,10 @@
+package web
+
+import "time"
+
+type APIKeyRequest struct {
+	Name  string `json:"name" binding:"required" example:"recommendation-service"`
+	Scope string `json:"scope" binding:"required,oneof=read write"`
+	// ExpiresAt is left out for keys that never expire.
+	ExpiresAt *time.Time `json:"expires_at"`
+}
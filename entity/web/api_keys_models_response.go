@@ -0,0 +1,20 @@
+package web
+
+import "time"
+
+type APIKeyResponse struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scope      string     `json:"scope"`
+	CreatedBy  int        `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// APIKeyCreatedResponse carries the key itself, which is shown only once.
+type APIKeyCreatedResponse struct {
+	ID  int    `json:"id"`
+	Key string `json:"key"`
+}
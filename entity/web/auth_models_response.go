@@ -3,3 +3,7 @@ package web
 type AuthModelResponse struct {
 	Token string `json:"token"`
 }
+
+type OIDCLoginResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
@@ -10,3 +10,16 @@ type DirectorModelResponse struct {
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
 }
+
+type DirectorMovieModelResponse struct {
+	MovieID     int       `json:"movie_id"`
+	Title       string    `json:"title"`
+	ReleaseDate time.Time `json:"release_date"`
+	Role        string    `json:"role"`
+}
+
+type DirectorMoviesModelResponse struct {
+	DirectorID int                          `json:"director_id"`
+	Name       string                       `json:"name"`
+	Movies     []DirectorMovieModelResponse `json:"movies"`
+}
@@ -0,0 +1,12 @@
+package web
+
+type HealthCheckResponse struct {
+	Status    string  `json:"status" example:"ok"`
+	LatencyMS float64 `json:"latency_ms"`
+	Message   string  `json:"message,omitempty"`
+}
+
+type ReadinessResponse struct {
+	Status string                         `json:"status" example:"ok"`
+	Checks map[string]HealthCheckResponse `json:"checks"`
+}
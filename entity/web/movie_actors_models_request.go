@@ -1,15 +1,37 @@
 package web
 
 type MovieActorModelRequestPost struct {
-	ID      int    `json:"id"`
-	MovieID int    `json:"movie_id"`
-	ActorID int    `binding:"required" json:"actor_id"`
-	Role    string `binding:"required" json:"role"`
+	ID            int    `json:"id"`
+	MovieID       int    `json:"movie_id"`
+	ActorID       int    `binding:"required" json:"actor_id"`
+	Role          string `binding:"required" json:"role"`
+	CharacterName string `json:"character_name"`
+	CreditType    string `binding:"omitempty,oneof=lead supporting cameo voice" json:"credit_type"`
+	BillingOrder  int    `binding:"min=0" json:"billing_order"`
+	Notes         string `json:"notes"`
 }
 
 type MovieActorModelRequestPut struct {
-	ID      int    `json:"id"`
-	MovieID int    `json:"movie_id"`
-	ActorID int    `json:"actor_id"`
-	Role    string `binding:"required" json:"role"`
+	ID            int    `json:"id"`
+	MovieID       int    `json:"movie_id"`
+	ActorID       int    `json:"actor_id"`
+	Role          string `binding:"required" json:"role"`
+	CharacterName string `json:"character_name"`
+	CreditType    string `binding:"omitempty,oneof=lead supporting cameo voice" json:"credit_type"`
+	BillingOrder  int    `binding:"min=0" json:"billing_order"`
+	Notes         string `json:"notes"`
+}
+
+type MovieActorCastRequest struct {
+	ActorID       int    `binding:"required" json:"actor_id"`
+	Role          string `binding:"required" json:"role"`
+	CharacterName string `json:"character_name"`
+	CreditType    string `binding:"omitempty,oneof=lead supporting cameo voice" json:"credit_type"`
+	BillingOrder  int    `binding:"min=0" json:"billing_order"`
+	Notes         string `json:"notes"`
+}
+
+type MovieActorModelRequestBatch struct {
+	MovieID int                     `json:"movie_id"`
+	Actors  []MovieActorCastRequest `binding:"required,dive" json:"actors"`
 }
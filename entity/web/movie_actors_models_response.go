@@ -5,10 +5,14 @@ import (
 )
 
 type Actor struct {
-	ActorID     int       `json:"actor_id"`
-	Name        string    `json:"name"`
-	Role        string    `json:"role"`
-	DateOfBirth time.Time `json:"date_of_birth"`
+	ActorID       int       `json:"actor_id"`
+	Name          string    `json:"name"`
+	Role          string    `json:"role"`
+	CharacterName string    `json:"character_name"`
+	CreditType    string    `json:"credit_type"`
+	BillingOrder  int       `json:"billing_order"`
+	Notes         string    `json:"notes,omitempty"`
+	DateOfBirth   time.Time `json:"date_of_birth"`
 }
 
 type MovieActorModelResponse struct {
@@ -0,0 +1,7 @@
+package web
+
+type MovieCrewModelRequestPost struct {
+	MovieID  int    `json:"movie_id"`
+	PersonID int    `binding:"required" json:"person_id"`
+	Job      string `binding:"required" json:"job"`
+}
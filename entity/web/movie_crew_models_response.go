@@ -0,0 +1,33 @@
+package web
+
+import "time"
+
+type CrewJobResponse struct {
+	Job        string `json:"job"`
+	Department string `json:"department"`
+}
+
+type CrewMember struct {
+	PersonID    int       `json:"person_id"`
+	Name        string    `json:"name"`
+	DateOfBirth time.Time `json:"date_of_birth"`
+	Job         string    `json:"job"`
+	Department  string    `json:"department"`
+}
+
+type MovieCrewModelResponse struct {
+	Movie Movie        `json:"movie"`
+	Crew  []CrewMember `json:"crew"`
+}
+
+type CrewCredit struct {
+	Movie      Movie  `json:"movie"`
+	Job        string `json:"job"`
+	Department string `json:"department"`
+}
+
+type PersonCrewModelResponse struct {
+	PersonID int          `json:"person_id"`
+	Name     string       `json:"name"`
+	Credits  []CrewCredit `json:"credits"`
+}
@@ -15,6 +15,9 @@ type MovieModelResponse struct {
 	NationalID  int       `json:"national_id"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	// Preferred tells whether the movie is in one of the preferred genres of
+	// the user asking. Anonymous callers do not get it.
+	Preferred *bool `json:"preferred,omitempty"`
 }
 
 type MoviesGenreResponse struct {
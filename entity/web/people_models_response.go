@@ -0,0 +1,29 @@
+package web
+
+import "time"
+
+type PersonModelResponse struct {
+	ID            int       `json:"id"`
+	Name          string    `json:"name"`
+	DateOfBirth   time.Time `json:"date_of_birth"`
+	NationalityID int       `json:"nationality_id"`
+	IsActor       bool      `json:"is_actor"`
+	IsDirector    bool      `json:"is_director"`
+	DirectorID    int       `json:"director_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type CastCredit struct {
+	Movie         Movie  `json:"movie"`
+	Role          string `json:"role"`
+	CharacterName string `json:"character_name"`
+	CreditType    string `json:"credit_type"`
+	BillingOrder  int    `json:"billing_order"`
+}
+
+type PersonCreditsModelResponse struct {
+	Person PersonModelResponse `json:"person"`
+	Cast   []CastCredit        `json:"cast"`
+	Crew   []CrewCredit        `json:"crew"`
+}
@@ -0,0 +1,14 @@
+package web
+
+// UserProfileRequest changes the fields that are sent and keeps the others.
+// An empty string or list clears a field.
+type UserProfileRequest struct {
+	DisplayName        *string  `json:"display_name" example:"Lee Ji Eun"`
+	AvatarURL          *string  `json:"avatar_url" example:"https://example.com/jieun.png"`
+	PreferredLanguages []string `json:"preferred_languages"`
+	PreferredGenres    []int    `json:"preferred_genres"`
+}
+
+type UserRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin user"`
+}
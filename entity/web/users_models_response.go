@@ -1,6 +1,21 @@
 package web
 
+import "time"
+
 type UserInfoResponse struct {
 	UserID   int    `json:"user_id"`
 	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+type UserResponse struct {
+	UserID             int      `json:"user_id"`
+	Username           string   `json:"username"`
+	Role               string   `json:"role"`
+	DisplayName        string   `json:"display_name"`
+	AvatarURL          string   `json:"avatar_url"`
+	PreferredLanguages []string `json:"preferred_languages"`
+	PreferredGenres    []int    `json:"preferred_genres"`
+	// DisabledAt is left out for users who may log in.
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
 }
@@ -10,6 +10,9 @@ type ResponseError struct {
 	Code    int    `json:"code"`
 	Status  string `json:"status"`
 	Message string `json:"message"`
+	// RequestID is the X-Request-ID of the request, to find its log lines
+	// with.
+	RequestID string `json:"request_id,omitempty"`
 }
 
 type ResponseSuccessWithData struct {
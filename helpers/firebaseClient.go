@@ -2,31 +2,34 @@ package helpers
 
 import (
 	"context"
-	"log"
-	"os"
+	"errors"
+	"fmt"
 
 	"cloud.google.com/go/storage"
 	firebase "firebase.google.com/go/v4"
 	"google.golang.org/api/option"
 )
 
-func NewFirebaseStorageClient(ctx context.Context) *storage.BucketHandle {
-	opt := option.WithCredentialsFile("firebase-admin-sdk.json")
+func NewFirebaseStorageClient(ctx context.Context, credentialsFile string, bucketName string) (*storage.BucketHandle, error) {
+	if bucketName == "" {
+		return nil, errors.New("firebase bucket is not configured")
+	}
+
+	opt := option.WithCredentialsFile(credentialsFile)
 	app, err := firebase.NewApp(ctx, nil, opt)
 	if err != nil {
-		log.Fatalf("Error initializing Firebase app: %v\n", err)
+		return nil, fmt.Errorf("error initializing Firebase app: %w", err)
 	}
 
 	storageClient, err := app.Storage(ctx)
 	if err != nil {
-		log.Fatalf("Error initializing Firebase Storage client: %v\n", err)
+		return nil, fmt.Errorf("error initializing Firebase Storage client: %w", err)
 	}
 
-	bucketName := os.Getenv("BUCKET_NAME_FIREBASE")
 	bucket, err := storageClient.Bucket(bucketName)
 	if err != nil {
-		log.Fatalf("Error accessing default bucket: %v\n", err)
+		return nil, fmt.Errorf("error accessing bucket %s: %w", bucketName, err)
 	}
 
-	return bucket
+	return bucket, nil
 }
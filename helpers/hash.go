@@ -0,0 +1,14 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HashSecret returns the SHA-256 of a random secret, such as an API key or a
+// reset token, in hex. Only the hash is stored, so a leaked table gives away
+// no usable secrets. Random secrets are long enough not to need a slow hash.
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -2,24 +2,26 @@ package helpers
 
 import (
 	"errors"
+	"fmt"
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
-	"log"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 )
 
-func GenerateTokenJWT(ID int, username string, role string) (string, error) {
-	secretKeyJWTEnv := os.Getenv("SECRET_KEY_JWT")
-	if secretKeyJWTEnv == "" {
-		log.Fatal("SECRET_KEY_JWT not found")
-	}
+var errNoSecret = errors.New("jwt secret is not configured")
 
-	secretKey := []byte(secretKeyJWTEnv)
+func GenerateTokenJWT(keys *JWTKeys, ID int, username string, role string) (string, error) {
+	method, key := jwt.SigningMethod(jwt.SigningMethodHS256), any(keys.secret)
+	switch {
+	case keys.signing != nil:
+		method, key = keys.signing.method, keys.signing.private
+	case keys.secret == nil:
+		return "", errNoSecret
+	}
 
 	// Create the JWT token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	token := jwt.NewWithClaims(method, jwt.MapClaims{
 		"id":       ID,
 		"iss":      "eFilm APIs",
 		"iat":      time.Now().Unix(),
@@ -28,8 +30,11 @@ func GenerateTokenJWT(ID int, username string, role string) (string, error) {
 		"role":     role,
 		"username": username,
 	})
+	if keys.signing != nil {
+		token.Header["kid"] = keys.signing.id
+	}
 
-	signedToken, err := token.SignedString(secretKey)
+	signedToken, err := token.SignedString(key)
 	if err != nil {
 		return "", errors.New("error creating token")
 	}
@@ -37,21 +42,28 @@ func GenerateTokenJWT(ID int, username string, role string) (string, error) {
 	return signedToken, nil
 }
 
-func ValidateTokenJWT(jwtToken string) (bool, *web.UserInfoResponse, error) {
-
-	secretKeyJWTEnv := os.Getenv("SECRET_KEY_JWT")
-	if secretKeyJWTEnv == "" {
-		log.Fatal("SECRET_KEY_JWT not found")
-	}
-
-	secretKey := []byte(secretKeyJWTEnv)
-
+func ValidateTokenJWT(keys *JWTKeys, jwtToken string) (bool, *web.UserInfoResponse, error) {
 	// Parse the token
 	token, err := jwt.Parse(jwtToken, func(t *jwt.Token) (interface{}, error) {
-		if t.Method != jwt.SigningMethodHS256 {
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" {
+			// Tokens signed with the secret have no kid.
+			if t.Method != jwt.SigningMethodHS256 || keys.secret == nil {
+				return nil, errors.New("invalid signing method")
+			}
+			return keys.secret, nil
+		}
+
+		key, ok := keys.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		// Checked by name, so no token can have a public key taken for an
+		// HMAC secret.
+		if t.Method.Alg() != key.method.Alg() {
 			return nil, errors.New("invalid signing method")
 		}
-		return secretKey, nil
+		return key.public, nil
 	})
 
 	if err != nil {
@@ -73,6 +85,8 @@ func ValidateTokenJWT(jwtToken string) (bool, *web.UserInfoResponse, error) {
 
 			userInfo.UserID = int(claims["id"].(float64))
 			userInfo.Username = claims["username"].(string)
+			// Tokens of users without a role carry an empty one.
+			userInfo.Role, _ = claims["role"].(string)
 
 		}
 		return true, &userInfo, nil
@@ -0,0 +1,164 @@
+package helpers
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/dimassfeb-09/efilm-api.git/config"
+	"github.com/golang-jwt/jwt"
+)
+
+// minRSABits is the smallest RSA key accepted for signing tokens.
+const minRSABits = 2048
+
+// JWTKey is a key tokens are signed or verified with, named by the kid header
+// of the tokens. Private keys sign and verify. Public keys, kept after a
+// signing key is retired, only verify.
+type JWTKey struct {
+	ID  string
+	Key any
+}
+
+type jwtKey struct {
+	id      string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// JWTKeys signs tokens with one key and verifies them with any of its keys.
+type JWTKeys struct {
+	signing *jwtKey
+	keys    map[string]*jwtKey
+	// secret signs and verifies HS256 tokens, which carry no kid.
+	secret []byte
+}
+
+// NewJWTKeys returns the key set signing with the key named signingKey, an
+// RSA or Ed25519 private key, or with HS256 and secret when signingKey is
+// empty. While secret is set HS256 tokens are accepted, so tokens issued
+// before the switch to keys stay valid.
+func NewJWTKeys(secret string, signingKey string, keys []JWTKey) (*JWTKeys, error) {
+	set := &JWTKeys{keys: map[string]*jwtKey{}}
+	if secret != "" {
+		set.secret = []byte(secret)
+	}
+
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, errors.New("every JWT key needs an ID")
+		}
+		if _, ok := set.keys[key.ID]; ok {
+			return nil, fmt.Errorf("JWT key %s is listed twice", key.ID)
+		}
+
+		parsed, err := newJWTKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("JWT key %s: %w", key.ID, err)
+		}
+		set.keys[key.ID] = parsed
+	}
+
+	switch {
+	case signingKey != "":
+		set.signing = set.keys[signingKey]
+		if set.signing == nil {
+			return nil, fmt.Errorf("signing key %s is not among the JWT keys", signingKey)
+		}
+		if set.signing.private == nil {
+			return nil, fmt.Errorf("signing key %s is a public key", signingKey)
+		}
+	case set.secret == nil:
+		return nil, errNoSecret
+	}
+
+	return set, nil
+}
+
+func newJWTKey(key JWTKey) (*jwtKey, error) {
+	switch k := key.Key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("RSA keys need at least %d bits", minRSABits)
+		}
+		return &jwtKey{id: key.ID, method: jwt.SigningMethodRS256, private: k, public: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		return &jwtKey{id: key.ID, method: jwt.SigningMethodRS256, public: k}, nil
+	case ed25519.PrivateKey:
+		return &jwtKey{id: key.ID, method: jwt.SigningMethodEdDSA, private: k, public: k.Public()}, nil
+	case ed25519.PublicKey:
+		return &jwtKey{id: key.ID, method: jwt.SigningMethodEdDSA, public: k}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %T, use RSA or Ed25519", key.Key)
+}
+
+// LoadJWTKeys reads the key files named in cfg.
+func LoadJWTKeys(cfg config.JWT) (*JWTKeys, error) {
+	var keys []JWTKey
+	for _, file := range cfg.Keys {
+		raw, err := os.ReadFile(file.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT key %s: %w", file.ID, err)
+		}
+
+		key, err := ParseJWTKey(raw)
+		if err != nil {
+			return nil, fmt.Errorf("JWT key %s: %w", file.ID, err)
+		}
+		keys = append(keys, JWTKey{ID: file.ID, Key: key})
+	}
+
+	return NewJWTKeys(cfg.Secret, cfg.SigningKey, keys)
+}
+
+// ParseJWTKey reads a PEM encoded private key, PKCS #8 or PKCS #1, or a
+// public key.
+func ParseJWTKey(raw []byte) (any, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+}
+
+// JWKS returns the public keys of the set as a JSON Web Key Set, for other
+// services to verify tokens with.
+func (keys *JWTKeys) JWKS() map[string]any {
+	set := []map[string]any{}
+	for _, key := range keys.keys {
+		jwk := map[string]any{"kid": key.id, "use": "sig", "alg": key.method.Alg()}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk["kty"] = "RSA"
+			jwk["n"] = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk["e"] = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk["kty"] = "OKP"
+			jwk["crv"] = "Ed25519"
+			jwk["x"] = base64.RawURLEncoding.EncodeToString(public)
+		}
+		set = append(set, jwk)
+	}
+
+	// Map order is random; keep the document stable.
+	sort.Slice(set, func(i, j int) bool { return set[i]["kid"].(string) < set[j]["kid"].(string) })
+	return map[string]any{"keys": set}
+}
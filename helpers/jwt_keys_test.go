@@ -0,0 +1,91 @@
+package helpers
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWTKeysRoundTrip(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	for name, key := range map[string]any{"RS256": rsaKey, "EdDSA": edKey} {
+		t.Run(name, func(t *testing.T) {
+			keys, err := NewJWTKeys("", "current", []JWTKey{{ID: "current", Key: key}})
+			assert.NoError(t, err)
+
+			token, err := GenerateTokenJWT(keys, 7, "jieun", "admin")
+			assert.NoError(t, err)
+
+			parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+			assert.NoError(t, err)
+			assert.Equal(t, name, parsed.Method.Alg())
+			assert.Equal(t, "current", parsed.Header["kid"])
+
+			valid, user, err := ValidateTokenJWT(keys, token)
+			assert.NoError(t, err)
+			assert.True(t, valid)
+			assert.Equal(t, 7, user.UserID)
+			assert.Equal(t, "admin", user.Role)
+		})
+	}
+}
+
+func TestJWTKeysRotation(t *testing.T) {
+	oldPublic, oldKey, _ := ed25519.GenerateKey(rand.Reader)
+	_, newKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	before, err := NewJWTKeys("secret", "old", []JWTKey{{ID: "old", Key: oldKey}})
+	assert.NoError(t, err)
+	oldToken, err := GenerateTokenJWT(before, 1, "admin", "admin")
+	assert.NoError(t, err)
+	hsToken, err := GenerateTokenJWT(&JWTKeys{secret: []byte("secret")}, 1, "admin", "admin")
+	assert.NoError(t, err)
+
+	// The old key is retired: only its public key is kept.
+	after, err := NewJWTKeys("secret", "new", []JWTKey{{ID: "new", Key: newKey}, {ID: "old", Key: oldPublic}})
+	assert.NoError(t, err)
+
+	valid, _, err := ValidateTokenJWT(after, oldToken)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, _, err = ValidateTokenJWT(after, hsToken)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	_, err = NewJWTKeys("", "old", []JWTKey{{ID: "old", Key: oldPublic}})
+	assert.EqualError(t, err, "signing key old is a public key")
+
+	// Without the secret, HS256 tokens are no longer accepted.
+	keysOnly, err := NewJWTKeys("", "new", []JWTKey{{ID: "new", Key: newKey}})
+	assert.NoError(t, err)
+	_, _, err = ValidateTokenJWT(keysOnly, hsToken)
+	assert.Error(t, err)
+	_, _, err = ValidateTokenJWT(keysOnly, oldToken)
+	assert.ErrorContains(t, err, `unknown signing key "old"`)
+
+	assert.Len(t, after.JWKS()["keys"], 2)
+}
+
+func TestJWTKeysRejectsAlgorithmMismatch(t *testing.T) {
+	public, private, _ := ed25519.GenerateKey(rand.Reader)
+	keys, err := NewJWTKeys("", "ed", []JWTKey{{ID: "ed", Key: private}})
+	assert.NoError(t, err)
+
+	// An HS256 token keyed with the bytes of the public key, claiming its kid.
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"id": 1, "username": "admin", "exp": 1 << 40})
+	token.Header["kid"] = "ed"
+	forged, err := token.SignedString([]byte(public))
+	assert.NoError(t, err)
+
+	_, _, err = ValidateTokenJWT(keys, forged)
+	assert.ErrorContains(t, err, "invalid signing method")
+}
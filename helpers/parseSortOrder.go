@@ -0,0 +1,21 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseSortOrder reads an "asc"/"desc" query value. An empty value falls back
+// to defaultAscending.
+func ParseSortOrder(order string, defaultAscending bool) (ascending bool, err error) {
+	switch strings.ToLower(order) {
+	case "":
+		return defaultAscending, nil
+	case "asc":
+		return true, nil
+	case "desc":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid order %s, use asc or desc", order)
+	}
+}
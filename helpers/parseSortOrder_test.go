@@ -0,0 +1,29 @@
+package helpers
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseSortOrder(t *testing.T) {
+
+	ascending, err := ParseSortOrder("", true)
+	t.Run("expect default", func(t *testing.T) {
+		assert.Nil(t, err)
+		assert.True(t, ascending, "is should be true")
+	})
+
+	orders := map[string]bool{"asc": true, "ASC": true, "desc": false, "Desc": false}
+	for order, expected := range orders {
+		ascending, err := ParseSortOrder(order, !expected)
+		t.Run("expect "+order, func(t *testing.T) {
+			assert.Nil(t, err)
+			assert.Equal(t, expected, ascending)
+		})
+	}
+
+	_, err = ParseSortOrder("newest", false)
+	t.Run("expect error", func(t *testing.T) {
+		assert.NotNil(t, err, "is should be error")
+	})
+}
@@ -2,10 +2,15 @@ package helpers
 
 import (
 	"context"
-	"database/sql"
 )
 
-func RollbackOrCommit(ctx context.Context, tx *sql.Tx) {
+// Tx is the part of a transaction these helpers need; *sql.Tx satisfies it.
+type Tx interface {
+	Commit() error
+	Rollback() error
+}
+
+func RollbackOrCommit(ctx context.Context, tx Tx) {
 	defer func() {
 		err := recover()
 		if err != nil {
@@ -21,3 +26,14 @@ func RollbackOrCommit(ctx context.Context, tx *sql.Tx) {
 		}
 	}()
 }
+
+// CommitOrRollback finishes tx depending on the error returned by the caller,
+// so a failing multi-statement write leaves no partial changes behind.
+// Use it with a named error return: defer helpers.CommitOrRollback(tx, &err)
+func CommitOrRollback(tx Tx, err *error) {
+	if *err != nil {
+		tx.Rollback()
+		return
+	}
+	*err = tx.Commit()
+}
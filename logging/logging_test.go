@@ -0,0 +1,42 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerAddsRequestID(t *testing.T) {
+	var out bytes.Buffer
+	logger := New(&out, "info").With("component", "test")
+
+	logger.DebugContext(context.Background(), "dropped")
+	logger.InfoContext(context.Background(), "without")
+	logger.InfoContext(WithRequestID(context.Background(), "abc"), "with")
+
+	var lines []map[string]any
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var line map[string]any
+		assert.NoError(t, decoder.Decode(&line))
+		lines = append(lines, line)
+	}
+
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "without", lines[0]["msg"])
+	assert.NotContains(t, lines[0], "request_id")
+	assert.Equal(t, "with", lines[1]["msg"])
+	assert.Equal(t, "abc", lines[1]["request_id"])
+	assert.Equal(t, "test", lines[1]["component"])
+}
+
+func TestFromContext(t *testing.T) {
+	logger := New(&bytes.Buffer{}, "info")
+
+	assert.Same(t, logger, FromContext(WithLogger(context.Background(), logger)))
+	assert.Same(t, slog.Default(), FromContext(context.Background()))
+}
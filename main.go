@@ -1,31 +1,98 @@
 package main
 
 import (
-	"log"
+	"context"
+	"log/slog"
+	"net"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/dimassfeb-09/efilm-api.git/app"
-	"github.com/dimassfeb-09/efilm-api.git/middlewares"
+	"github.com/dimassfeb-09/efilm-api.git/config"
+	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/dimassfeb-09/efilm-api.git/metrics"
+	"github.com/dimassfeb-09/efilm-api.git/notify"
+	"github.com/dimassfeb-09/efilm-api.git/ratelimit"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+	"github.com/dimassfeb-09/efilm-api.git/tracing"
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
 )
 
 func main() {
-	r := gin.Default()
+	logger := logging.New(os.Stdout, "info")
+
+	cfg, err := config.Load(".env")
+	if err != nil {
+		logger.Error("invalid configuration", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	// Anything still using the log package, net/http included, goes through
+	// the same JSON handler.
+	logger = logging.New(os.Stdout, cfg.Log.Level)
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		logger.Error("cannot set up tracing", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	keys, err := helpers.LoadJWTKeys(cfg.JWT)
+	if err != nil {
+		logger.Error("cannot load the JWT keys", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	gin.SetMode(cfg.Mode)
+	r := gin.New()
 	r.HandleMethodNotAllowed = true
-	gin.SetMode(gin.ReleaseMode)
-	r.Use(middlewares.AllowCORS)
+	r.TrustedPlatform = cfg.Server.ClientIPHeader
+	if err := r.SetTrustedProxies(nil); err != nil {
+		logger.Error("cannot configure trusted proxies", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	db, err := app.DBConnection(cfg.Database)
+	if err != nil {
+		logger.Error("cannot connect to the database", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
 
-	db := app.DBConnection()
-	defer db.Close()
+	m := metrics.New()
+	m.RegisterDB(db)
 
-	r = app.InitialozedRoute(r, db)
+	notifier, err := notify.New(cfg.Notifier, logger)
+	if err != nil {
+		logger.Error("cannot set up the notifier", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	r = app.InitialozedRoute(r, cfg, keys, repository.NewDB(db), repository.NewRepositories(), m, ratelimit.NewMemoryStore(), notifier, logger)
+
+	// fly.io stops machines with SIGINT by default and SIGTERM when configured.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	server := app.NewServer(cfg, r)
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		logger.Error("cannot listen", slog.String("port", cfg.Port), slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	logger.Info("listening", slog.String("port", cfg.Port))
 
-	port := os.Getenv("APP_PORT")
-	err := r.Run(":" + port)
+	// Spans of the last requests are flushed after they are drained.
+	err = app.Serve(ctx, server, listener, cfg.Server.ShutdownTimeout, logger, func(ctx context.Context) error {
+		return db.Close()
+	}, shutdownTracing)
 	if err != nil {
-		log.Fatalf("Cannot run at port 8080: %s", err.Error())
+		logger.Error("unclean shutdown", slog.String("error", err.Error()))
+		os.Exit(1)
 	}
 
-	log.Printf("Success run at port 8080")
+	logger.Info("server stopped")
 }
@@ -0,0 +1,154 @@
+// Package metrics holds the Prometheus collectors of the API. The recording
+// methods are safe to call on a nil *Metrics, so services built without
+// metrics, as in tests, need no special casing.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "efilm"
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+
+	uploadBytes    prometheus.Histogram
+	uploadFailures *prometheus.CounterVec
+
+	registrations prometheus.Counter
+	logins        prometheus.Counter
+	failedLogins  prometheus.Counter
+}
+
+// New returns the collectors registered on a registry of their own, together
+// with the Go runtime and process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by route template, method and status.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time to handle HTTP requests by route template, method and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		uploadBytes: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "poster_upload_bytes",
+			Help:      "Size of uploaded movie posters.",
+			Buckets:   prometheus.ExponentialBuckets(16<<10, 4, 7),
+		}),
+		uploadFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "poster_upload_failures_total",
+			Help:      "Failed movie poster uploads by reason.",
+		}, []string{"reason"}),
+		registrations: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "registrations_total",
+			Help:      "Users registered.",
+		}),
+		logins: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "logins_total",
+			Help:      "Successful logins.",
+		}),
+		failedLogins: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "failed_logins_total",
+			Help:      "Logins rejected for an unknown user or a wrong password.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.uploadBytes,
+		m.uploadFailures,
+		m.registrations,
+		m.logins,
+		m.failedLogins,
+	)
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// RegisterDB exports the connection pool statistics of db: open, in use and
+// idle connections and the time spent waiting for one.
+func (m *Metrics) RegisterDB(db *sql.DB) {
+	if m == nil {
+		return
+	}
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, "efilm"))
+}
+
+func (m *Metrics) ObserveRequest(method, route, status string, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.requests.WithLabelValues(method, route, status).Inc()
+	m.requestDuration.WithLabelValues(method, route, status).Observe(duration.Seconds())
+}
+
+func (m *Metrics) PosterUploaded(size int64) {
+	if m == nil {
+		return
+	}
+	m.uploadBytes.Observe(float64(size))
+}
+
+// Upload failure reasons.
+const (
+	UploadInvalidFile = "invalid_file"
+	UploadNoMovie     = "movie_not_found"
+	UploadStorage     = "storage"
+	UploadDatabase    = "database"
+)
+
+func (m *Metrics) PosterUploadFailed(reason string) {
+	if m == nil {
+		return
+	}
+	m.uploadFailures.WithLabelValues(reason).Inc()
+}
+
+func (m *Metrics) Registered() {
+	if m == nil {
+		return
+	}
+	m.registrations.Inc()
+}
+
+func (m *Metrics) LoggedIn() {
+	if m == nil {
+		return
+	}
+	m.logins.Inc()
+}
+
+func (m *Metrics) LoginFailed() {
+	if m == nil {
+		return
+	}
+	m.failedLogins.Inc()
+}
@@ -0,0 +1,76 @@
+package metrics_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dimassfeb-09/efilm-api.git/metrics"
+	"github.com/dimassfeb-09/efilm-api.git/middlewares"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func TestMetricsLabelRequestsByRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := metrics.New()
+
+	r := gin.New()
+	r.Use(middlewares.Metrics(m))
+	r.GET("/api/movies/:movie_id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, path := range []string{"/api/movies/1", "/api/movies/2", "/nowhere"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	for _, method := range []string{"BREW", "PROPFIND", http.MethodDelete} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/nowhere", nil))
+	}
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `efilm_http_requests_total{method="GET",route="/api/movies/:movie_id",status="200"} 2`)
+	assert.Contains(t, body, `efilm_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, body, `efilm_http_requests_total{method="OTHER",route="unmatched",status="404"} 2`)
+	assert.Contains(t, body, `efilm_http_requests_total{method="DELETE",route="unmatched",status="404"} 1`)
+	assert.NotContains(t, body, `method="BREW"`)
+	assert.Contains(t, body, `efilm_http_request_duration_seconds_count{method="GET",route="/api/movies/:movie_id",status="200"} 2`)
+}
+
+func TestMetricsBusinessEvents(t *testing.T) {
+	m := metrics.New()
+	m.Registered()
+	m.LoggedIn()
+	m.LoginFailed()
+	m.LoginFailed()
+	m.PosterUploaded(200 << 10)
+	m.PosterUploadFailed(metrics.UploadStorage)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, "efilm_registrations_total 1")
+	assert.Contains(t, body, "efilm_logins_total 1")
+	assert.Contains(t, body, "efilm_failed_logins_total 2")
+	assert.Contains(t, body, "efilm_poster_upload_bytes_count 1")
+	assert.Contains(t, body, `efilm_poster_upload_failures_total{reason="storage"} 1`)
+}
+
+func TestMetricsNilIsNoop(t *testing.T) {
+	var m *metrics.Metrics
+	assert.NotPanics(t, func() {
+		m.Registered()
+		m.LoggedIn()
+		m.LoginFailed()
+		m.PosterUploaded(1)
+		m.PosterUploadFailed(metrics.UploadDatabase)
+		m.ObserveRequest("GET", "/", "200", 0)
+	})
+}
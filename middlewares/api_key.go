@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader carries the API keys of server-to-server consumers.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator looks up the API key a request was sent with.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*domain.APIKey, error)
+}
+
+// checkAPIKey answers requests with an unknown or expired API key, and
+// writes with a read key, and reports whether the request may go on.
+func checkAPIKey(c *gin.Context) bool {
+	apiKey, ok := currentAPIKey(c)
+	if !ok {
+		err := c.MustGet(apiKeyErrorKey).(error)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, web.ResponseError{
+			Code:      http.StatusUnauthorized,
+			Status:    "Status Unauthorized",
+			Message:   err.Error(),
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return false
+	}
+
+	if apiKey.Scope != domain.APIKeyWrite && c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		c.AbortWithStatusJSON(http.StatusForbidden, web.ResponseError{
+			Code:      http.StatusForbidden,
+			Status:    "Status Forbidden",
+			Message:   "This API key may only read",
+			RequestID: logging.RequestID(c.Request.Context()),
+		})
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,182 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+)
+
+// Principal is the user a request is authenticated as by its bearer token.
+type Principal struct {
+	UserID   int
+	Username string
+	Role     string
+}
+
+// Keys of what Authenticate attaches to the gin context.
+const (
+	principalKey   = "auth.principal"
+	tokenErrorKey  = "auth.token_error"
+	apiKeyKey      = "auth.api_key"
+	apiKeyErrorKey = "auth.api_key_error"
+)
+
+var (
+	errNoAuthorization = errors.New("Authorization header not found")
+	errNotBearer       = errors.New("Authorization header must be Bearer followed by a token")
+	errInvalidToken    = errors.New("Token is invalid")
+	errExpiredToken    = errors.New("Token is expired")
+)
+
+// UserAuthenticator looks up the user a bearer token was issued to.
+type UserAuthenticator interface {
+	// Authenticate returns the user ID stands for, or an error when they were
+	// deleted or disabled since.
+	Authenticate(ctx context.Context, ID int) (*domain.Auth, error)
+}
+
+// Authenticate checks the credentials a request carries, an API key and a
+// bearer token, and attaches the API key and the Principal of the token to
+// the gin context. The Principal is read from users on every request, so
+// disabling, deleting or demoting a user takes effect at once rather than at
+// their next login. Requests without valid credentials go on
+// unauthenticated; RequireUser, RequireRole and MiddlewareToken decide what
+// they may do.
+func Authenticate(keys *helpers.JWTKeys, apiKeys APIKeyAuthenticator, users UserAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key := c.GetHeader(APIKeyHeader); key != "" {
+			apiKey, err := apiKeys.Authenticate(c.Request.Context(), key)
+			if err != nil {
+				c.Set(apiKeyErrorKey, err)
+			} else {
+				c.Set(apiKeyKey, apiKey)
+			}
+		}
+
+		if header := c.GetHeader("Authorization"); header != "" {
+			principal, err := authenticateBearer(c.Request.Context(), keys, users, header)
+			if err != nil {
+				c.Set(tokenErrorKey, err)
+			} else {
+				c.Set(principalKey, principal)
+			}
+		}
+
+		c.Next()
+	}
+}
+
+func authenticateBearer(ctx context.Context, keys *helpers.JWTKeys, users UserAuthenticator, header string) (Principal, error) {
+	token, ok := BearerToken(header)
+	if !ok {
+		return Principal{}, errNotBearer
+	}
+
+	valid, user, err := helpers.ValidateTokenJWT(keys, token)
+	// The parse errors say nothing useful to clients, expiry aside.
+	var validation *jwt.ValidationError
+	if errors.As(err, &validation) && validation.Errors&jwt.ValidationErrorExpired != 0 {
+		return Principal{}, errExpiredToken
+	}
+	if err != nil || !valid {
+		return Principal{}, errInvalidToken
+	}
+
+	// The role in the token is the one the user had when logging in.
+	current, err := users.Authenticate(ctx, user.UserID)
+	if err != nil {
+		return Principal{}, err
+	}
+	return Principal{UserID: current.ID, Username: current.Username, Role: current.Role}, nil
+}
+
+// BearerToken returns the token of an Authorization header of the Bearer
+// scheme, whose name is matched case-insensitively as RFC 7235 asks.
+func BearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" || strings.ContainsAny(token, " \t") {
+		return "", false
+	}
+	return token, true
+}
+
+// CurrentUser returns the user the request is authenticated as, if any.
+func CurrentUser(c *gin.Context) (Principal, bool) {
+	principal, ok := c.Get(principalKey)
+	if !ok {
+		return Principal{}, false
+	}
+	return principal.(Principal), true
+}
+
+// currentAPIKey returns the valid API key the request was sent with, if any.
+func currentAPIKey(c *gin.Context) (*domain.APIKey, bool) {
+	apiKey, ok := c.Get(apiKeyKey)
+	if !ok {
+		return nil, false
+	}
+	return apiKey.(*domain.APIKey), true
+}
+
+// OptionalUser lets through requests without a bearer token and requests
+// with a valid one, so that handlers serving anyone can still tell who is
+// calling. Invalid tokens are refused rather than ignored, so clients learn
+// that theirs has expired.
+func OptionalUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if optionalUser(c) {
+			c.Next()
+		}
+	}
+}
+
+func optionalUser(c *gin.Context) bool {
+	if c.GetHeader("Authorization") == "" {
+		return true
+	}
+	_, ok := requireUser(c)
+	return ok
+}
+
+// RequireUser only lets through requests with a valid bearer token,
+// whatever their method.
+func RequireUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := requireUser(c); ok {
+			c.Next()
+		}
+	}
+}
+
+// requireUser answers requests without a valid bearer token with 401, and
+// returns the user of the others.
+func requireUser(c *gin.Context) (Principal, bool) {
+	if principal, ok := CurrentUser(c); ok {
+		return principal, true
+	}
+
+	err := errNoAuthorization
+	if tokenErr, ok := c.Get(tokenErrorKey); ok {
+		err = tokenErr.(error)
+	}
+	c.AbortWithStatusJSON(http.StatusUnauthorized, web.ResponseError{
+		Code:      http.StatusUnauthorized,
+		Status:    "Status Unauthorized",
+		Message:   err.Error(),
+		RequestID: logging.RequestID(c.Request.Context()),
+	})
+	return Principal{}, false
+}
@@ -0,0 +1,25 @@
+package middlewares
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBearerToken(t *testing.T) {
+	for header, want := range map[string]string{
+		"Bearer abc.def.ghi":      "abc.def.ghi",
+		"bearer abc.def.ghi":      "abc.def.ghi",
+		"  Bearer   abc.def.ghi ": "abc.def.ghi",
+		"Bearer":                  "",
+		"Bearer ":                 "",
+		"Bearerabc.def.ghi":       "",
+		"Basic YWRtaW46c2VjcmV0":  "",
+		"Bearer abc def":          "",
+		"abc.def.ghi":             "",
+	} {
+		token, ok := BearerToken(header)
+		assert.Equal(t, want, token, header)
+		assert.Equal(t, want != "", ok, header)
+	}
+}
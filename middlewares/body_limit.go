@@ -0,0 +1,60 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// LimitBody caps request bodies at maxBody bytes, and multipart ones at
+// maxUpload. A declared Content-Length over the cap is answered 413 at
+// once; a body that only turns out larger fails the handler reading it.
+func LimitBody(maxBody, maxUpload int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := maxBody
+		if isMultipart(c.Request) {
+			limit = maxUpload
+		}
+
+		if c.Request.ContentLength > limit {
+			abortTooLarge(c, limit)
+			return
+		}
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		}
+		c.Next()
+	}
+}
+
+// isMultipart tells whether r carries a multipart body, such as a poster
+// upload.
+func isMultipart(r *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return strings.HasPrefix(mediaType, "multipart/")
+}
+
+// TooLarge tells whether err comes from reading past the cap of LimitBody,
+// and returns that cap.
+func TooLarge(err error) (int64, bool) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return tooLarge.Limit, true
+	}
+	return 0, false
+}
+
+func abortTooLarge(c *gin.Context, limit int64) {
+	c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, web.ResponseError{
+		Code:      http.StatusRequestEntityTooLarge,
+		Status:    "Status Request Entity Too Large",
+		Message:   fmt.Sprintf("Request body is larger than %d bytes", limit),
+		RequestID: logging.RequestID(c.Request.Context()),
+	})
+}
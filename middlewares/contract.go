@@ -0,0 +1,97 @@
+package middlewares
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/dimassfeb-09/efilm-api.git/docs"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// ValidateContract rejects requests that do not match the OpenAPI document
+// before they reach the handler. It reads at most maxBody bytes of a body,
+// and leaves multipart bodies unread for the handler to stream. When
+// validateResponses is set, responses are checked too and every violation
+// is logged to logger; they are still sent as is.
+func ValidateContract(contract *docs.Contract, maxBody int64, validateResponses bool, logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			c.Next()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil && !isMultipart(c.Request) {
+			var err error
+			body, err = io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBody))
+			if limit, ok := TooLarge(err); ok {
+				abortTooLarge(c, limit)
+				return
+			}
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, web.ResponseError{
+					Code:      http.StatusBadRequest,
+					Status:    "Status Bad Request",
+					Message:   "Failed read request body",
+					RequestID: logging.RequestID(c.Request.Context()),
+				})
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		params := map[string]string{}
+		for _, param := range c.Params {
+			params[param.Key] = param.Value
+		}
+
+		if err := contract.ValidateRequest(c.Request, route, params, body); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, web.ResponseError{
+				Code:      http.StatusBadRequest,
+				Status:    "Status Bad Request",
+				Message:   err.Error(),
+				RequestID: logging.RequestID(c.Request.Context()),
+			})
+			return
+		}
+
+		if !validateResponses {
+			c.Next()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		err := contract.ValidateResponse(c.Request.Method, route, c.Writer.Status(), c.Writer.Header().Get("Content-Type"), recorder.body.Bytes())
+		if err != nil {
+			logger.ErrorContext(c.Request.Context(), "response does not match the OpenAPI document",
+				slog.String("method", c.Request.Method),
+				slog.String("route", route),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// responseRecorder keeps a copy of the body written through it.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
@@ -1,17 +1,208 @@
 package middlewares
 
-import "github.com/gin-gonic/gin"
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
-func AllowCORS(c *gin.Context) {
-	c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-	c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-	c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-	c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+	"github.com/dimassfeb-09/efilm-api.git/config"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/gin-gonic/gin"
+)
 
-	if c.Request.Method == "OPTIONS" {
-		c.AbortWithStatus(204)
-		return
+// CORS lets browsers on the origins cors allows call the API, answering
+// their preflight requests itself. Requests from other origins are served
+// without CORS headers, so browsers keep the responses from the page, and
+// their preflight requests are refused with 403.
+func CORS(cors config.CORS) gin.HandlerFunc {
+	routes := []routePolicy{{policy: newCORSPolicy(cors.CORSPolicy)}}
+	for prefix := range cors.Routes {
+		routes = append(routes, routePolicy{prefix: prefix, policy: newCORSPolicy(cors.RoutePolicy(prefix))})
 	}
+	sort.Slice(routes, func(i, j int) bool {
+		return len(routes[i].prefix) > len(routes[j].prefix)
+	})
 
-	c.Next()
+	return func(c *gin.Context) {
+		var policy *corsPolicy
+		for _, route := range routes {
+			if route.matches(c.Request.URL.Path) {
+				policy = route.policy
+				break
+			}
+		}
+
+		header := c.Writer.Header()
+		header.Add("Vary", "Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if preflight {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+		if !policy.allowsOrigin(origin) {
+			if preflight {
+				refusePreflight(c, fmt.Sprintf("Origin %s is not allowed", origin))
+				return
+			}
+			c.Next()
+			return
+		}
+		if preflight && !policy.allowsPreflight(c) {
+			return
+		}
+
+		if policy.anyOrigin && !policy.credentials {
+			header.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			header.Set("Access-Control-Allow-Origin", origin)
+		}
+		if policy.credentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if policy.exposedHeaders != "" {
+				header.Set("Access-Control-Expose-Headers", policy.exposedHeaders)
+			}
+			c.Next()
+			return
+		}
+
+		header.Set("Access-Control-Allow-Methods", policy.allowedMethods)
+		if policy.allowedHeaders != "" {
+			header.Set("Access-Control-Allow-Headers", policy.allowedHeaders)
+		}
+		if policy.maxAge > 0 {
+			header.Set("Access-Control-Max-Age", strconv.Itoa(int(policy.maxAge/time.Second)))
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+// allowsPreflight refuses preflight requests for a method or headers the
+// policy does not allow.
+func (policy *corsPolicy) allowsPreflight(c *gin.Context) bool {
+	method := c.GetHeader("Access-Control-Request-Method")
+	if !policy.methods[method] {
+		refusePreflight(c, fmt.Sprintf("Method %s is not allowed", method))
+		return false
+	}
+	for _, name := range strings.Split(c.GetHeader("Access-Control-Request-Headers"), ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !policy.headers[http.CanonicalHeaderKey(name)] {
+			refusePreflight(c, fmt.Sprintf("Header %s is not allowed", name))
+			return false
+		}
+	}
+	return true
+}
+
+func refusePreflight(c *gin.Context, message string) {
+	c.AbortWithStatusJSON(http.StatusForbidden, web.ResponseError{
+		Code:      http.StatusForbidden,
+		Status:    "Status Forbidden",
+		Message:   message,
+		RequestID: logging.RequestID(c.Request.Context()),
+	})
+}
+
+type routePolicy struct {
+	prefix string
+	policy *corsPolicy
+}
+
+// matches tells whether path is the prefix of the route or below it, so
+// /api/movies does not take in /api/moviesx.
+func (route routePolicy) matches(path string) bool {
+	prefix := strings.TrimSuffix(route.prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// corsPolicy is a config.CORSPolicy ready to check requests against.
+type corsPolicy struct {
+	anyOrigin      bool
+	origins        []originPattern
+	methods        map[string]bool
+	headers        map[string]bool
+	allowedMethods string
+	allowedHeaders string
+	exposedHeaders string
+	credentials    bool
+	maxAge         time.Duration
+}
+
+// originPattern matches an origin, or with subdomains every origin on a
+// subdomain of host.
+type originPattern struct {
+	scheme     string
+	host       string
+	port       string
+	subdomains bool
+}
+
+func newCORSPolicy(cors config.CORSPolicy) *corsPolicy {
+	policy := &corsPolicy{
+		methods:        map[string]bool{},
+		headers:        map[string]bool{},
+		allowedMethods: strings.Join(cors.AllowedMethods, ", "),
+		allowedHeaders: strings.Join(cors.AllowedHeaders, ", "),
+		exposedHeaders: strings.Join(cors.ExposedHeaders, ", "),
+		credentials:    cors.Credentials(),
+		maxAge:         cors.MaxAge,
+	}
+	for _, origin := range cors.AllowedOrigins {
+		if origin == "*" {
+			policy.anyOrigin = true
+			continue
+		}
+		scheme, host, _ := strings.Cut(origin, "://")
+		pattern := originPattern{subdomains: strings.HasPrefix(host, "*.")}
+		// config.Validate has refused the origins that do not parse.
+		if u, err := url.Parse(scheme + "://" + strings.TrimPrefix(host, "*.")); err == nil {
+			pattern.scheme, pattern.host, pattern.port = strings.ToLower(u.Scheme), strings.ToLower(u.Hostname()), u.Port()
+			policy.origins = append(policy.origins, pattern)
+		}
+	}
+	for _, method := range cors.AllowedMethods {
+		policy.methods[strings.ToUpper(method)] = true
+	}
+	for _, header := range cors.AllowedHeaders {
+		policy.headers[http.CanonicalHeaderKey(header)] = true
+	}
+	return policy
+}
+
+func (policy *corsPolicy) allowsOrigin(origin string) bool {
+	if policy.anyOrigin {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	scheme, host, port := strings.ToLower(u.Scheme), strings.ToLower(u.Hostname()), u.Port()
+	for _, pattern := range policy.origins {
+		if scheme != pattern.scheme || port != pattern.port {
+			continue
+		}
+		if host == pattern.host && !pattern.subdomains {
+			return true
+		}
+		if pattern.subdomains && strings.HasSuffix(host, "."+pattern.host) {
+			return true
+		}
+	}
+	return false
 }
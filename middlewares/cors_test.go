@@ -0,0 +1,121 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/config"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCORSRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.HandleMethodNotAllowed = true
+	credentials, noCredentials := true, false
+	r.Use(CORS(config.CORS{
+		CORSPolicy: config.CORSPolicy{
+			AllowedOrigins:   []string{"https://efilm.example.com", "https://*.preview.example.com"},
+			AllowedMethods:   []string{"GET", "POST", "PATCH"},
+			AllowedHeaders:   []string{"Authorization", "Content-Type"},
+			ExposedHeaders:   []string{"X-Request-ID"},
+			AllowCredentials: &credentials,
+			MaxAge:           10 * time.Minute,
+		},
+		Routes: map[string]config.CORSPolicy{
+			"/api/openapi.json": {AllowedOrigins: []string{"*"}, AllowCredentials: &noCredentials},
+			"/api/movies":       {AllowedOrigins: []string{"https://partner.example.com"}},
+		},
+	}))
+	r.PATCH("/api/users/me", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/api/openapi.json", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/api/movies/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func preflight(r *gin.Engine, path, origin, method, headers string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodOptions, path, nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", method)
+	if headers != "" {
+		req.Header.Set("Access-Control-Request-Headers", headers)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCORSPreflight(t *testing.T) {
+	r := newCORSRouter()
+
+	for _, origin := range []string{"https://efilm.example.com", "https://pr-12.preview.example.com"} {
+		w := preflight(r, "/api/users/me", origin, "PATCH", "authorization, content-type")
+		assert.Equal(t, http.StatusNoContent, w.Code, origin)
+		assert.Equal(t, origin, w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "GET, POST, PATCH", w.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Authorization, Content-Type", w.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+		assert.Equal(t, []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}, w.Header().Values("Vary"))
+	}
+
+	for name, w := range map[string]*httptest.ResponseRecorder{
+		"unknown origin":       preflight(r, "/api/users/me", "https://evil.example.com", "PATCH", ""),
+		"bare wildcard domain": preflight(r, "/api/users/me", "https://preview.example.com", "PATCH", ""),
+		"lookalike domain":     preflight(r, "/api/users/me", "https://evilpreview.example.com", "PATCH", ""),
+		"other scheme":         preflight(r, "/api/users/me", "http://efilm.example.com", "PATCH", ""),
+		"method":               preflight(r, "/api/users/me", "https://efilm.example.com", "DELETE", ""),
+		"header":               preflight(r, "/api/users/me", "https://efilm.example.com", "PATCH", "X-Debug"),
+	} {
+		assert.Equal(t, http.StatusForbidden, w.Code, name)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"), name)
+	}
+}
+
+func TestCORSRouteOverride(t *testing.T) {
+	r := newCORSRouter()
+
+	// The document is public: any origin, without credentials.
+	w := preflight(r, "/api/openapi.json", "https://other.example.org", "GET", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+
+	// A route leaving credentials out inherits them.
+	w = preflight(r, "/api/movies/1", "https://partner.example.com", "GET", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://partner.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+
+	// Routes cover whole path segments only.
+	for _, path := range []string{"/api/openapi.jsonx", "/api/moviesx"} {
+		w = preflight(r, path, "https://partner.example.com", "GET", "")
+		assert.Equal(t, http.StatusForbidden, w.Code, path)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"), path)
+	}
+}
+
+func TestCORSActualRequest(t *testing.T) {
+	r := newCORSRouter()
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/users/me", nil)
+	req.Header.Set("Origin", "https://efilm.example.com")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://efilm.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "X-Request-ID", w.Header().Get("Access-Control-Expose-Headers"))
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+
+	// Other origins are served, but browsers keep the response from them.
+	req.Header.Set("Origin", "https://evil.example.com")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+}
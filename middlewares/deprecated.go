@@ -0,0 +1,13 @@
+package middlewares
+
+import "github.com/gin-gonic/gin"
+
+// Deprecated marks the responses of a route kept for old clients with a
+// Deprecation header, and a Link to the route that replaces it at successor.
+func Deprecated(successor string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "true")
+		c.Header("Link", "<"+successor+`>; rel="successor-version"`)
+		c.Next()
+	}
+}
@@ -2,47 +2,29 @@ package middlewares
 
 import (
 	"net/http"
-	"strings"
 
-	"github.com/dimassfeb-09/efilm-api.git/entity/web"
-	"github.com/dimassfeb-09/efilm-api.git/helpers"
 	"github.com/gin-gonic/gin"
 )
 
-func MiddlewareToken(c *gin.Context) {
-	if c.Request.Method == "POST" || c.Request.Method == "PUT" || c.Request.Method == "DELETE" {
-		authorization := c.Request.Header.Get("Authorization")
-		if authorization == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, web.ResponseError{
-				Code:    http.StatusUnauthorized,
-				Status:  "Status Unauthorized",
-				Message: "Authorization header not found",
-			})
+// MiddlewareToken lets through requests with a valid API key, within its
+// scope, writes with a valid bearer token and reads with a valid bearer
+// token or none, see OptionalUser. It relies on Authenticate.
+func MiddlewareToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader(APIKeyHeader) != "" {
+			if checkAPIKey(c) {
+				c.Next()
+			}
 			return
 		}
-		bearers := strings.Split(authorization, "Bearer")
-		if bearers[1] == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, web.ResponseError{
-				Code:    http.StatusUnauthorized,
-				Status:  "Status Unauthorized",
-				Message: "Token not found",
-			})
-			return
-		} else {
-			token := strings.TrimSpace(bearers[1])
-			isValid, _, err := helpers.ValidateTokenJWT(token)
-			if err != nil {
-				c.AbortWithStatusJSON(http.StatusUnauthorized, web.ResponseError{
-					Code:    http.StatusUnauthorized,
-					Status:  "Status Unauthorized",
-					Message: err.Error(),
-				})
-				return
-			}
 
-			if isValid {
-				c.Next()
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			if _, ok := requireUser(c); !ok {
+				return
 			}
+		} else if !optionalUser(c) {
+			return
 		}
+		c.Next()
 	}
 }
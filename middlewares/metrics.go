@@ -0,0 +1,42 @@
+package middlewares
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics records every request under its route template, e.g.
+// /api/movies/:movie_id, so IDs do not each become a series of their own.
+// Unmatched requests with a method outside the standard ones are recorded as
+// OTHER, as clients can send any method they make up.
+func Metrics(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		method, route := c.Request.Method, c.FullPath()
+		if route == "" {
+			route = "unmatched"
+			if !standardMethods[method] {
+				method = "OTHER"
+			}
+		}
+		m.ObserveRequest(method, route, strconv.Itoa(c.Writer.Status()), time.Since(start))
+	}
+}
+
+var standardMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodConnect: true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
@@ -0,0 +1,71 @@
+package middlewares
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/dimassfeb-09/efilm-api.git/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit takes a token for every request from the bucket of its client
+// among the buckets called name, and answers 429 once that bucket is empty.
+// Requests go through when the store fails, so an outage of a shared store
+// does not take the API down with it.
+func RateLimit(store ratelimit.Store, name string, limit ratelimit.Limit, key func(c *gin.Context) string, logger *slog.Logger) gin.HandlerFunc {
+	if !limit.Enabled() {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	return func(c *gin.Context) {
+		result, err := store.Take(c.Request.Context(), name+":"+key(c), limit)
+		if err != nil {
+			logger.WarnContext(c.Request.Context(), "rate limit store failed", slog.String("limit", name), slog.String("error", err.Error()))
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("X-RateLimit-Reset", seconds(result.Reset))
+
+		if !result.Allowed {
+			c.Header("Retry-After", seconds(result.RetryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, web.ResponseError{
+				Code:      http.StatusTooManyRequests,
+				Status:    "Status Too Many Requests",
+				Message:   fmt.Sprintf("Too many requests, retry in %s seconds", seconds(result.RetryAfter)),
+				RequestID: logging.RequestID(c.Request.Context()),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ClientKey returns the key of the client of a request: a valid API key, the
+// user of a valid bearer token, or else the client IP. It relies on
+// Authenticate.
+func ClientKey(c *gin.Context) string {
+	if apiKey, ok := currentAPIKey(c); ok {
+		return "key:" + strconv.Itoa(apiKey.ID)
+	}
+	if user, ok := CurrentUser(c); ok {
+		return "user:" + strconv.Itoa(user.UserID)
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// seconds rounds d up to whole seconds, as Retry-After wants.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
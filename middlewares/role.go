@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole only lets through requests with a valid bearer token of a user
+// with role, whatever their method. It relies on Authenticate.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := requireUser(c)
+		if !ok {
+			return
+		}
+
+		if user.Role != role {
+			c.AbortWithStatusJSON(http.StatusForbidden, web.ResponseError{
+				Code:      http.StatusForbidden,
+				Status:    "Status Forbidden",
+				Message:   "Only " + role + " users may do this",
+				RequestID: logging.RequestID(c.Request.Context()),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
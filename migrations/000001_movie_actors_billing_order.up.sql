@@ -0,0 +1 @@
+ALTER TABLE movie_actors ADD COLUMN IF NOT EXISTS billing_order INTEGER NOT NULL DEFAULT 0;
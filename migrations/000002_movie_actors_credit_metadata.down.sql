@@ -0,0 +1,4 @@
+ALTER TABLE movie_actors
+    DROP COLUMN IF EXISTS character_name,
+    DROP COLUMN IF EXISTS credit_type,
+    DROP COLUMN IF EXISTS notes;
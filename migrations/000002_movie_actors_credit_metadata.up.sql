@@ -0,0 +1,7 @@
+ALTER TABLE movie_actors
+    ADD COLUMN IF NOT EXISTS character_name VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS credit_type VARCHAR(20) NOT NULL DEFAULT 'supporting'
+        CHECK (credit_type IN ('lead', 'supporting', 'cameo', 'voice')),
+    ADD COLUMN IF NOT EXISTS notes TEXT NOT NULL DEFAULT '';
+
+UPDATE movie_actors SET character_name = role WHERE character_name = '';
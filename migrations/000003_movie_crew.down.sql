@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS movie_directors
+(
+    movie_id    INTEGER NOT NULL REFERENCES movies (id),
+    director_id INTEGER NOT NULL REFERENCES directors (id)
+);
+
+INSERT INTO movie_directors (movie_id, director_id)
+SELECT movie_id, person_id
+FROM movie_crew
+WHERE job = 'director';
+
+DROP TABLE IF EXISTS movie_crew;
+DROP TABLE IF EXISTS crew_jobs;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS crew_jobs
+(
+    job        VARCHAR(50) PRIMARY KEY,
+    department VARCHAR(50) NOT NULL
+);
+
+INSERT INTO crew_jobs (job, department)
+VALUES ('director', 'directing'),
+       ('writer', 'writing'),
+       ('producer', 'production'),
+       ('composer', 'sound'),
+       ('cinematographer', 'camera'),
+       ('editor', 'editing')
+ON CONFLICT (job) DO NOTHING;
+
+-- Crew members are stored in the directors table until people are unified.
+CREATE TABLE IF NOT EXISTS movie_crew
+(
+    movie_id  INTEGER     NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    person_id INTEGER     NOT NULL REFERENCES directors (id) ON DELETE CASCADE,
+    job       VARCHAR(50) NOT NULL REFERENCES crew_jobs (job),
+    PRIMARY KEY (movie_id, person_id, job)
+);
+
+CREATE INDEX IF NOT EXISTS movie_crew_person_id_idx ON movie_crew (person_id);
+
+INSERT INTO movie_crew (movie_id, person_id, job)
+SELECT movie_id, director_id, 'director'
+FROM movie_directors
+ON CONFLICT DO NOTHING;
+
+DROP TABLE movie_directors;
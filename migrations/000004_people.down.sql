@@ -0,0 +1,53 @@
+-- Directors get their director ID back as their ID. Other crew members have
+-- to be directors again, so they are given a director ID first.
+CREATE TABLE IF NOT EXISTS actors
+(
+    id             SERIAL PRIMARY KEY,
+    name           VARCHAR(255) NOT NULL,
+    date_of_birth  DATE         NOT NULL,
+    nationality_id INTEGER      NOT NULL REFERENCES national (id),
+    created_at     TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS directors
+(
+    id             SERIAL PRIMARY KEY,
+    name           VARCHAR(255) NOT NULL,
+    date_of_birth  DATE         NOT NULL,
+    nationality_id INTEGER      NOT NULL REFERENCES national (id),
+    created_at     TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO actors (id, name, date_of_birth, nationality_id, created_at, updated_at)
+SELECT id, name, date_of_birth, nationality_id, created_at, updated_at
+FROM people
+WHERE is_actor OR id IN (SELECT actor_id FROM movie_actors);
+
+UPDATE people
+SET director_id = nextval('people_director_id_seq')
+WHERE director_id IS NULL
+  AND id IN (SELECT person_id FROM movie_crew);
+
+INSERT INTO directors (id, name, date_of_birth, nationality_id, created_at, updated_at)
+SELECT director_id, name, date_of_birth, nationality_id, created_at, updated_at
+FROM people
+WHERE is_director OR id IN (SELECT person_id FROM movie_crew);
+
+SELECT setval(pg_get_serial_sequence('actors', 'id'), COALESCE((SELECT MAX(id) FROM actors), 0) + 1, FALSE);
+SELECT setval(pg_get_serial_sequence('directors', 'id'), COALESCE((SELECT MAX(id) FROM directors), 0) + 1, FALSE);
+
+ALTER TABLE movie_actors DROP CONSTRAINT IF EXISTS movie_actors_actor_id_fkey;
+ALTER TABLE movie_actors ADD CONSTRAINT movie_actors_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES actors (id);
+
+ALTER TABLE movie_crew DROP CONSTRAINT movie_crew_person_id_fkey;
+ALTER TABLE movie_crew DROP CONSTRAINT movie_crew_pkey;
+UPDATE movie_crew mc
+SET person_id = p.director_id
+FROM people p
+WHERE p.id = mc.person_id;
+ALTER TABLE movie_crew ADD PRIMARY KEY (movie_id, person_id, job);
+ALTER TABLE movie_crew ADD CONSTRAINT movie_crew_person_id_fkey FOREIGN KEY (person_id) REFERENCES directors (id) ON DELETE CASCADE;
+
+DROP TABLE people;
@@ -0,0 +1,63 @@
+-- Actors and directors are merged into a single people table. Actor IDs are
+-- kept as they are; a director becomes the same person as an actor with the
+-- same name and date of birth, otherwise gets a new ID. Directors keep their
+-- old ID as director_id, which is what /api/directors goes by.
+CREATE TABLE IF NOT EXISTS people
+(
+    id             SERIAL PRIMARY KEY,
+    name           VARCHAR(255) NOT NULL,
+    date_of_birth  DATE         NOT NULL,
+    nationality_id INTEGER      NOT NULL REFERENCES national (id),
+    is_actor       BOOLEAN      NOT NULL DEFAULT FALSE,
+    is_director    BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at     TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    director_id    INTEGER UNIQUE,
+    CHECK (NOT is_director OR director_id IS NOT NULL)
+);
+
+CREATE SEQUENCE IF NOT EXISTS people_director_id_seq OWNED BY people.director_id;
+
+INSERT INTO people (id, name, date_of_birth, nationality_id, is_actor, created_at, updated_at)
+SELECT id, name, date_of_birth, nationality_id, TRUE, created_at, updated_at
+FROM actors;
+
+SELECT setval(pg_get_serial_sequence('people', 'id'), COALESCE((SELECT MAX(id) FROM people), 0) + 1, FALSE);
+
+-- Duplicated actors or directors are matched lowest ID first, so each
+-- director is merged into one actor at most and each actor with one director.
+WITH matches AS (SELECT DISTINCT ON (d.id) d.id AS director_id, p.id AS person_id
+                 FROM directors d
+                          JOIN people p ON p.name = d.name AND p.date_of_birth = d.date_of_birth
+                 ORDER BY d.id, p.id),
+     merged AS (SELECT DISTINCT ON (person_id) director_id, person_id
+                FROM matches
+                ORDER BY person_id, director_id)
+UPDATE people p
+SET is_director = TRUE,
+    director_id = m.director_id
+FROM merged m
+WHERE p.id = m.person_id;
+
+INSERT INTO people (name, date_of_birth, nationality_id, is_director, created_at, updated_at, director_id)
+SELECT name, date_of_birth, nationality_id, TRUE, created_at, updated_at, id
+FROM directors d
+WHERE NOT EXISTS (SELECT 1 FROM people p WHERE p.director_id = d.id)
+ORDER BY id;
+
+SELECT setval('people_director_id_seq', COALESCE((SELECT MAX(id) FROM directors), 0) + 1, FALSE);
+
+ALTER TABLE movie_actors DROP CONSTRAINT IF EXISTS movie_actors_actor_id_fkey;
+ALTER TABLE movie_actors ADD CONSTRAINT movie_actors_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES people (id);
+
+ALTER TABLE movie_crew DROP CONSTRAINT movie_crew_person_id_fkey;
+ALTER TABLE movie_crew DROP CONSTRAINT movie_crew_pkey;
+UPDATE movie_crew mc
+SET person_id = p.id
+FROM people p
+WHERE p.director_id = mc.person_id;
+ALTER TABLE movie_crew ADD PRIMARY KEY (movie_id, person_id, job);
+ALTER TABLE movie_crew ADD CONSTRAINT movie_crew_person_id_fkey FOREIGN KEY (person_id) REFERENCES people (id) ON DELETE CASCADE;
+
+DROP TABLE actors CASCADE;
+DROP TABLE directors CASCADE;
@@ -0,0 +1,10 @@
+-- Failed logins per username and per client IP, keyed as user:<username> or
+-- ip:<address>. Usernames are tracked whether or not they exist, so lockouts
+-- do not reveal which ones do.
+CREATE TABLE IF NOT EXISTS login_attempts
+(
+    key             TEXT PRIMARY KEY,
+    failures        INTEGER     NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMPTZ NOT NULL,
+    locked_until    TIMESTAMPTZ
+);
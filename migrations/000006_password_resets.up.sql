@@ -0,0 +1,10 @@
+-- Pending password resets. Only the SHA-256 of a token is stored, so the
+-- table alone cannot be used to reset anybody's password.
+CREATE TABLE IF NOT EXISTS password_resets
+(
+    token_hash TEXT PRIMARY KEY,
+    user_id    INTEGER     NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS password_resets_user_id ON password_resets (user_id);
@@ -0,0 +1,14 @@
+-- API keys of server-to-server consumers. Only the SHA-256 of a key is
+-- stored; prefix, its first characters, tells keys apart in listings.
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    id           SERIAL PRIMARY KEY,
+    name         TEXT        NOT NULL,
+    prefix       TEXT        NOT NULL,
+    key_hash     TEXT        NOT NULL UNIQUE,
+    scope        TEXT        NOT NULL CHECK (scope IN ('read', 'write')),
+    created_by   INTEGER     NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at   TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ
+);
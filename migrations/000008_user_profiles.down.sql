@@ -0,0 +1,6 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS display_name,
+    DROP COLUMN IF EXISTS avatar_url,
+    DROP COLUMN IF EXISTS preferred_languages,
+    DROP COLUMN IF EXISTS preferred_genres,
+    DROP COLUMN IF EXISTS disabled_at;
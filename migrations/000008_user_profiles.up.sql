@@ -0,0 +1,8 @@
+-- Profiles users edit themselves, and the time an admin disabled a user.
+-- Preferred genres are genre IDs; genres deleted later are ignored on read.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS display_name        TEXT      NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS avatar_url          TEXT      NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS preferred_languages TEXT[]    NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS preferred_genres    INTEGER[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS disabled_at         TIMESTAMPTZ;
@@ -0,0 +1,23 @@
+-- Users logged in with an OpenID Connect provider, by the subject the issuer
+-- knows them as, and the logins started there but not yet finished. Only the
+-- SHA-256 of a login's state is stored; its code verifier has to be kept as
+-- is to be sent to the provider.
+CREATE TABLE IF NOT EXISTS user_identities
+(
+    issuer     TEXT        NOT NULL,
+    subject    TEXT        NOT NULL,
+    user_id    INTEGER     NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (issuer, subject)
+);
+
+CREATE INDEX IF NOT EXISTS user_identities_user_id ON user_identities (user_id);
+
+CREATE TABLE IF NOT EXISTS oidc_logins
+(
+    state_hash    TEXT PRIMARY KEY,
+    code_verifier TEXT        NOT NULL,
+    nonce         TEXT        NOT NULL,
+    link_user_id  INTEGER,
+    expires_at    TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,36 @@
+// Package migrations embeds the golang-migrate files so the server can tell
+// whether the database schema is the one it was built against.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var FS embed.FS
+
+// Latest returns the version of the newest migration.
+func Latest() (int, error) {
+	files, err := fs.Glob(FS, "*.up.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	latest := 0
+	for _, file := range files {
+		prefix, _, _ := strings.Cut(file, "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return 0, fmt.Errorf("migration %s has no version prefix", file)
+		}
+		if version > latest {
+			latest = version
+		}
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,93 @@
+// Package notify delivers messages to users. The API only knows users by
+// username, so the notifiers here are for local use: one writes messages to
+// the log, the other appends them to a file. A deployment that reaches users
+// by email or otherwise implements Notifier.
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/config"
+)
+
+type Message struct {
+	// To is the username of the recipient.
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, message Message) error
+}
+
+// New returns the notifier chosen by cfg, which is nil for kind none.
+func New(cfg config.Notifier, logger *slog.Logger) (Notifier, error) {
+	switch cfg.Kind {
+	case "none":
+		return nil, nil
+	case "log":
+		return NewLogNotifier(logger), nil
+	case "file":
+		return NewFileNotifier(cfg.File), nil
+	}
+	return nil, fmt.Errorf("unknown notifier %q", cfg.Kind)
+}
+
+type logNotifier struct {
+	logger *slog.Logger
+}
+
+// NewLogNotifier returns a notifier logging every message at info level.
+func NewLogNotifier(logger *slog.Logger) Notifier {
+	return &logNotifier{logger: logger}
+}
+
+func (n *logNotifier) Notify(ctx context.Context, message Message) error {
+	n.logger.InfoContext(ctx, "notification",
+		slog.String("to", message.To),
+		slog.String("subject", message.Subject),
+		slog.String("body", message.Body),
+	)
+	return nil
+}
+
+type fileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileNotifier returns a notifier appending every message to the file at
+// path as a JSON line, creating the file when needed.
+func NewFileNotifier(path string) Notifier {
+	return &fileNotifier{path: path}
+}
+
+func (n *fileNotifier) Notify(ctx context.Context, message Message) error {
+	line, err := json.Marshal(struct {
+		Time time.Time `json:"time"`
+		Message
+	}{time.Now().UTC(), message})
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", n.path, err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s: %w", n.path, err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,31 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileNotifierAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messages.jsonl")
+	notifier := NewFileNotifier(path)
+
+	assert.NoError(t, notifier.Notify(context.Background(), Message{To: "jieun", Subject: "first", Body: "one"}))
+	assert.NoError(t, notifier.Notify(context.Background(), Message{To: "jieun", Subject: "second", Body: "two"}))
+
+	raw, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	if assert.Len(t, lines, 2) {
+		var message Message
+		assert.NoError(t, json.Unmarshal([]byte(lines[1]), &message))
+		assert.Equal(t, Message{To: "jieun", Subject: "second", Body: "two"}, message)
+		assert.Contains(t, lines[1], `"time":`)
+	}
+}
@@ -0,0 +1,65 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/dimassfeb-09/efilm-api.git/config"
+	"github.com/dimassfeb-09/efilm-api.git/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+)
+
+func login(t *testing.T, server *oidctest.Server, provider *Provider, verifier, nonce string) string {
+	authorizationURL, err := provider.AuthCodeURL(context.Background(), "state", Challenge(verifier), nonce)
+	assert.NoError(t, err)
+	redirect, err := server.Authorize(authorizationURL)
+	assert.NoError(t, err)
+	assert.Equal(t, "state", redirect.Query().Get("state"))
+	return redirect.Query().Get("code")
+}
+
+func TestExchange(t *testing.T) {
+	server := oidctest.NewServer("efilm", "client-secret")
+	defer server.Close()
+	server.SetUser(oidctest.User{Subject: "248289761001", Email: "jieun@example.com", Name: "Lee Ji-eun"})
+
+	provider := New(config.OIDC{Issuer: server.URL + "/", ClientID: "efilm", ClientSecret: "client-secret", RedirectURL: "http://localhost/callback"}, http.DefaultClient)
+	assert.Equal(t, server.URL, provider.Issuer())
+
+	verifier, err := NewVerifier()
+	assert.NoError(t, err)
+	code := login(t, server, provider, verifier, "nonce")
+
+	claims, err := provider.Exchange(context.Background(), code, verifier, "nonce")
+	assert.NoError(t, err)
+	assert.Equal(t, &Claims{Subject: "248289761001", Email: "jieun@example.com", Name: "Lee Ji-eun"}, claims)
+
+	// Codes are single use.
+	_, err = provider.Exchange(context.Background(), code, verifier, "nonce")
+	assert.ErrorContains(t, err, "invalid_grant")
+}
+
+func TestExchangeRejects(t *testing.T) {
+	server := oidctest.NewServer("efilm", "client-secret")
+	defer server.Close()
+	server.SetUser(oidctest.User{Subject: "248289761001"})
+
+	provider := New(config.OIDC{Issuer: server.URL, ClientID: "efilm", ClientSecret: "client-secret", RedirectURL: "http://localhost/callback"}, http.DefaultClient)
+	verifier, _ := NewVerifier()
+
+	other, _ := NewVerifier()
+	_, err := provider.Exchange(context.Background(), login(t, server, provider, verifier, "nonce"), other, "nonce")
+	assert.ErrorContains(t, err, "invalid_grant")
+
+	_, err = provider.Exchange(context.Background(), login(t, server, provider, verifier, "nonce"), verifier, "another nonce")
+	assert.EqualError(t, err, "oidc: the ID token is for another login")
+
+	wrongSecret := New(config.OIDC{Issuer: server.URL, ClientID: "efilm", ClientSecret: "wrong", RedirectURL: "http://localhost/callback"}, http.DefaultClient)
+	_, err = wrongSecret.Exchange(context.Background(), login(t, server, provider, verifier, "nonce"), verifier, "nonce")
+	assert.ErrorContains(t, err, "invalid_client")
+
+	wrongIssuer := New(config.OIDC{Issuer: server.URL + "/realms/efilm", ClientID: "efilm"}, http.DefaultClient)
+	_, err = wrongIssuer.AuthCodeURL(context.Background(), "state", Challenge(verifier), "nonce")
+	assert.Error(t, err)
+}
@@ -0,0 +1,158 @@
+// Package ratelimit throttles clients with token buckets. A bucket holds up to
+// Limit.Requests tokens and refills at Limit.Requests per Limit.Per; every
+// request takes one token and is refused when none is left.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limit allows Requests per Per, all of them in a burst if need be. The zero
+// Limit allows everything.
+type Limit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// ParseLimit reads limits written as "<requests>/<duration>", e.g. "10/1m".
+// "0" and "" turn limiting off.
+func ParseLimit(value string) (Limit, error) {
+	if value == "" || value == "0" {
+		return Limit{}, nil
+	}
+
+	requests, per, ok := strings.Cut(value, "/")
+	limit := Limit{}
+	var err error
+	if ok {
+		limit.Requests, err = strconv.Atoi(requests)
+		if err == nil {
+			limit.Per, err = time.ParseDuration(per)
+		}
+	}
+	if !ok || err != nil || limit.Requests <= 0 || limit.Per <= 0 {
+		return Limit{}, fmt.Errorf("limit must look like 10/1m, got %q", value)
+	}
+	return limit, nil
+}
+
+func (limit Limit) Enabled() bool {
+	return limit.Requests > 0
+}
+
+func (limit Limit) String() string {
+	if !limit.Enabled() {
+		return "0"
+	}
+	return fmt.Sprintf("%d/%s", limit.Requests, limit.Per)
+}
+
+// UnmarshalText lets limits be set as strings in the YAML configuration.
+func (limit *Limit) UnmarshalText(text []byte) error {
+	parsed, err := ParseLimit(string(text))
+	if err != nil {
+		return err
+	}
+	*limit = parsed
+	return nil
+}
+
+// Result tells whether a request may go ahead and what is left of the bucket.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is how long until the next token when the request is refused.
+	RetryAfter time.Duration
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+}
+
+// Store keeps the buckets. MemoryStore suits a single instance; instances
+// behind a load balancer need a Store shared between them, such as one backed
+// by Redis, to enforce a single limit.
+type Store interface {
+	// Take takes a token from the bucket of key, creating a full one first if
+	// there is none.
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+var ErrDisabled = errors.New("rate limit is disabled")
+
+// sweepEvery bounds how often MemoryStore looks for buckets to forget.
+const sweepEvery = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	per     time.Duration
+}
+
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*bucket{}, now: time.Now}
+}
+
+func (store *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	if !limit.Enabled() {
+		return Result{}, ErrDisabled
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	now := store.now()
+	store.sweep(now)
+
+	capacity := float64(limit.Requests)
+	perToken := limit.Per / time.Duration(limit.Requests)
+
+	b, ok := store.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		store.buckets[key] = b
+	}
+	b.per = limit.Per
+	b.tokens = math.Min(capacity, b.tokens+float64(now.Sub(b.updated))/float64(perToken))
+	b.updated = now
+
+	result := Result{Limit: limit.Requests}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - b.tokens) * float64(perToken))
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = time.Duration((capacity - b.tokens) * float64(perToken))
+
+	return result, nil
+}
+
+// sweep forgets the buckets that have had time to refill, as a new bucket is
+// just as full.
+func (store *MemoryStore) sweep(now time.Time) {
+	if now.Sub(store.lastSweep) < sweepEvery {
+		return
+	}
+	store.lastSweep = now
+
+	for key, b := range store.buckets {
+		if now.Sub(b.updated) >= b.per {
+			delete(store.buckets, key)
+		}
+	}
+}
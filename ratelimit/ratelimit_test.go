@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLimit(t *testing.T) {
+	limit, err := ParseLimit("10/1m")
+	assert.NoError(t, err)
+	assert.Equal(t, Limit{Requests: 10, Per: time.Minute}, limit)
+
+	limit, err = ParseLimit("0")
+	assert.NoError(t, err)
+	assert.False(t, limit.Enabled())
+
+	for _, value := range []string{"10", "10/forever", "-1/1m", "10/0s"} {
+		_, err := ParseLimit(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestMemoryStoreRefills(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(0, 0)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	limit := Limit{Requests: 2, Per: time.Minute}
+
+	for remaining := 1; remaining >= 0; remaining-- {
+		result, err := store.Take(ctx, "ip:1", limit)
+		assert.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, remaining, result.Remaining)
+	}
+
+	result, _ := store.Take(ctx, "ip:1", limit)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 30*time.Second, result.RetryAfter)
+	assert.Equal(t, time.Minute, result.Reset)
+
+	// Other clients have buckets of their own.
+	result, _ = store.Take(ctx, "ip:2", limit)
+	assert.True(t, result.Allowed)
+
+	now = now.Add(30 * time.Second)
+	result, _ = store.Take(ctx, "ip:1", limit)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	// Idle buckets are forgotten once full again.
+	now = now.Add(2 * time.Minute)
+	store.Take(ctx, "ip:3", limit)
+	assert.Len(t, store.buckets, 1)
+}
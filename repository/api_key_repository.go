@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+)
+
+type APIKeyRepository interface {
+	Save(ctx context.Context, tx Querier, key *domain.APIKey) (int, error)
+	FindAll(ctx context.Context, db Querier) ([]*domain.APIKey, error)
+	FindByID(ctx context.Context, db Querier, ID int) (*domain.APIKey, error)
+	// FindByHash returns nil when no key has the hash.
+	FindByHash(ctx context.Context, db Querier, keyHash string) (*domain.APIKey, error)
+	UpdateLastUsed(ctx context.Context, tx Querier, ID int, at time.Time) error
+	Delete(ctx context.Context, tx Querier, ID int) error
+}
+
+type APIKeyRepositoryImpl struct {
+}
+
+func NewAPIKeyRepository() APIKeyRepository {
+	return &APIKeyRepositoryImpl{}
+}
+
+const apiKeyColumns = "id, name, prefix, key_hash, scope, created_by, created_at, expires_at, last_used_at"
+
+func (repository *APIKeyRepositoryImpl) Save(ctx context.Context, tx Querier, key *domain.APIKey) (int, error) {
+	query := `INSERT INTO api_keys (name, prefix, key_hash, scope, created_by, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	var id int
+	err := tx.QueryRowContext(ctx, query, key.Name, key.Prefix, key.KeyHash, key.Scope, key.CreatedBy, key.CreatedAt, nullTime(key.ExpiresAt)).
+		Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (repository *APIKeyRepositoryImpl) FindAll(ctx context.Context, db Querier) ([]*domain.APIKey, error) {
+	rows, err := db.QueryContext(ctx, "SELECT "+apiKeyColumns+" FROM api_keys ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*domain.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+func (repository *APIKeyRepositoryImpl) FindByID(ctx context.Context, db Querier, ID int) (*domain.APIKey, error) {
+	key, err := scanAPIKey(db.QueryRowContext(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE id = $1", ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("API key with ID %d not found", ID)
+		}
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (repository *APIKeyRepositoryImpl) FindByHash(ctx context.Context, db Querier, keyHash string) (*domain.APIKey, error) {
+	key, err := scanAPIKey(db.QueryRowContext(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE key_hash = $1", keyHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (repository *APIKeyRepositoryImpl) UpdateLastUsed(ctx context.Context, tx Querier, ID int, at time.Time) error {
+	_, err := tx.ExecContext(ctx, "UPDATE api_keys SET last_used_at = $1 WHERE id = $2", at, ID)
+	return err
+}
+
+func (repository *APIKeyRepositoryImpl) Delete(ctx context.Context, tx Querier, ID int) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM api_keys WHERE id = $1", ID)
+	return err
+}
+
+func scanAPIKey(row interface{ Scan(dest ...any) error }) (*domain.APIKey, error) {
+	var key domain.APIKey
+	var expiresAt, lastUsedAt sql.NullTime
+	err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.KeyHash, &key.Scope, &key.CreatedBy, &key.CreatedAt, &expiresAt, &lastUsedAt)
+	if err != nil {
+		return nil, err
+	}
+	key.ExpiresAt = expiresAt.Time
+	key.LastUsedAt = lastUsedAt.Time
+
+	return &key, nil
+}
+
+// nullTime stores the zero time as NULL.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
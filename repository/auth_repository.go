@@ -5,15 +5,34 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrUsernameNotFound is returned by Login for usernames nobody registered.
+	ErrUsernameNotFound = errors.New("sorry, username not found")
+	// ErrUserNotFound is wrapped by the errors about user IDs nobody has.
+	ErrUserNotFound = errors.New("not found")
 )
 
 type AuthRepository interface {
-	Register(ctx context.Context, tx *sql.Tx, auth *domain.Auth) error
-	Login(ctx context.Context, tx *sql.Tx, username string) (*domain.Auth, error)
-	FindByUsername(ctx context.Context, db *sql.DB, username string) (*domain.Auth, error)
-	FindByID(ctx context.Context, db *sql.DB, ID int) (*domain.Auth, error)
+	Register(ctx context.Context, tx Querier, auth *domain.Auth) error
+	Login(ctx context.Context, tx Querier, username string) (*domain.Auth, error)
+	FindByUsername(ctx context.Context, db Querier, username string) (*domain.Auth, error)
+	FindByID(ctx context.Context, db Querier, ID int) (*domain.Auth, error)
+	// FindAll returns the users whose username or display name contains
+	// search, or every user when it is empty.
+	FindAll(ctx context.Context, db Querier, search string) ([]*domain.Auth, error)
+	UpdatePassword(ctx context.Context, tx Querier, ID int, password string) error
+	UpdateProfile(ctx context.Context, tx Querier, auth *domain.Auth) error
+	UpdateRole(ctx context.Context, tx Querier, ID int, role string) error
+	// UpdateDisabledAt disables a user, or enables them with the zero time.
+	UpdateDisabledAt(ctx context.Context, tx Querier, ID int, disabledAt time.Time) error
+	Delete(ctx context.Context, tx Querier, ID int) error
 }
 
 type AuthRepositoryImpl struct {
@@ -23,7 +42,7 @@ func NewAuthRepository() AuthRepository {
 	return &AuthRepositoryImpl{}
 }
 
-func (a *AuthRepositoryImpl) Register(ctx context.Context, tx *sql.Tx, auth *domain.Auth) error {
+func (a *AuthRepositoryImpl) Register(ctx context.Context, tx Querier, auth *domain.Auth) error {
 	query := "INSERT INTO users (username, password) VALUES ($1, $2)"
 	_, err := tx.ExecContext(ctx, query, auth.Username, auth.Password)
 	if err != nil {
@@ -32,26 +51,20 @@ func (a *AuthRepositoryImpl) Register(ctx context.Context, tx *sql.Tx, auth *dom
 
 	return nil
 }
-
-func (a *AuthRepositoryImpl) Login(ctx context.Context, tx *sql.Tx, username string) (*domain.Auth, error) {
-	query := "SELECT id, username, password, role FROM users WHERE username = $1"
-
-	var auth domain.Auth
-	err := tx.QueryRowContext(ctx, query, username).
-		Scan(&auth.ID, &auth.Username, &auth.Password, &auth.Role)
+func (a *AuthRepositoryImpl) Login(ctx context.Context, tx Querier, username string) (*domain.Auth, error) {
+	auth, err := scanUser(tx.QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE username = $1", username))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("sorry, username not found")
+			return nil, ErrUsernameNotFound
 		}
 		return nil, err
 	}
 
-	return &auth, nil
+	return auth, nil
 }
 
-func (a *AuthRepositoryImpl) FindByUsername(ctx context.Context, db *sql.DB, username string) (*domain.Auth, error) {
-	var auth domain.Auth
-	err := db.QueryRow("SELECT id, username, password FROM users WHERE username = $1", username).Scan(&auth.ID, &auth.Username, &auth.Password)
+func (a *AuthRepositoryImpl) FindByUsername(ctx context.Context, db Querier, username string) (*domain.Auth, error) {
+	auth, err := scanUser(db.QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE username = $1", username))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("auth with username %s not found", username)
@@ -59,18 +72,126 @@ func (a *AuthRepositoryImpl) FindByUsername(ctx context.Context, db *sql.DB, use
 		return nil, err
 	}
 
-	return &auth, nil
+	return auth, nil
 }
 
-func (a *AuthRepositoryImpl) FindByID(ctx context.Context, db *sql.DB, ID int) (*domain.Auth, error) {
-	var auth domain.Auth
-	err := db.QueryRow("SELECT id FROM users WHERE id = $1", ID).Scan(&auth.ID)
+func (a *AuthRepositoryImpl) FindByID(ctx context.Context, db Querier, ID int) (*domain.Auth, error) {
+	auth, err := scanUser(db.QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE id = $1", ID))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("auth with username %s not found", ID)
+			return nil, fmt.Errorf("auth with ID %d %w", ID, ErrUserNotFound)
 		}
 		return nil, err
 	}
 
+	return auth, nil
+}
+
+func (a *AuthRepositoryImpl) FindAll(ctx context.Context, db Querier, search string) ([]*domain.Auth, error) {
+	query := "SELECT " + userColumns + " FROM users"
+	var args []any
+	if search != "" {
+		query += ` WHERE username ILIKE $1 OR display_name ILIKE $1`
+		args = append(args, "%"+likeEscaper.Replace(search)+"%")
+	}
+
+	rows, err := db.QueryContext(ctx, query+" ORDER BY id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*domain.Auth
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+func (a *AuthRepositoryImpl) UpdatePassword(ctx context.Context, tx Querier, ID int, password string) error {
+	result, err := tx.ExecContext(ctx, "UPDATE users SET password = $1 WHERE id = $2", password, ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("auth with ID %d %w", ID, ErrUserNotFound)
+	}
+
+	return nil
+}
+
+func (a *AuthRepositoryImpl) UpdateProfile(ctx context.Context, tx Querier, auth *domain.Auth) error {
+	query := "UPDATE users SET display_name = $1, avatar_url = $2, preferred_languages = $3, preferred_genres = $4 WHERE id = $5"
+	result, err := tx.ExecContext(ctx, query, auth.DisplayName, auth.AvatarURL, pq.Array(auth.PreferredLanguages), pq.Array(auth.PreferredGenres), auth.ID)
+	if err != nil {
+		return err
+	}
+	return checkUserAffected(result, auth.ID)
+}
+
+func (a *AuthRepositoryImpl) UpdateRole(ctx context.Context, tx Querier, ID int, role string) error {
+	result, err := tx.ExecContext(ctx, "UPDATE users SET role = $1 WHERE id = $2", role, ID)
+	if err != nil {
+		return err
+	}
+	return checkUserAffected(result, ID)
+}
+
+func (a *AuthRepositoryImpl) UpdateDisabledAt(ctx context.Context, tx Querier, ID int, disabledAt time.Time) error {
+	result, err := tx.ExecContext(ctx, "UPDATE users SET disabled_at = $1 WHERE id = $2", nullTime(disabledAt), ID)
+	if err != nil {
+		return err
+	}
+	return checkUserAffected(result, ID)
+}
+
+func (a *AuthRepositoryImpl) Delete(ctx context.Context, tx Querier, ID int) error {
+	result, err := tx.ExecContext(ctx, "DELETE FROM users WHERE id = $1", ID)
+	if err != nil {
+		return err
+	}
+	return checkUserAffected(result, ID)
+}
+
+const userColumns = "id, username, password, role, display_name, avatar_url, preferred_languages, preferred_genres, disabled_at"
+
+func scanUser(row interface{ Scan(dest ...any) error }) (*domain.Auth, error) {
+	var auth domain.Auth
+	var genres pq.Int64Array
+	var disabledAt sql.NullTime
+	err := row.Scan(&auth.ID, &auth.Username, &auth.Password, &auth.Role, &auth.DisplayName, &auth.AvatarURL,
+		pq.Array(&auth.PreferredLanguages), &genres, &disabledAt)
+	if err != nil {
+		return nil, err
+	}
+	for _, genre := range genres {
+		auth.PreferredGenres = append(auth.PreferredGenres, int(genre))
+	}
+	auth.DisabledAt = disabledAt.Time
+
 	return &auth, nil
 }
+
+func checkUserAffected(result sql.Result, ID int) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("auth with ID %d %w", ID, ErrUserNotFound)
+	}
+	return nil
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
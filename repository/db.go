@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Querier runs statements. Repositories take a Querier instead of *sql.DB or
+// *sql.Tx so the same method works inside and outside a transaction, and so
+// the in-memory repositories in repository/memory can stand in for Postgres.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) Row
+}
+
+// Rows is the part of *sql.Rows repositories use.
+type Rows interface {
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+	Close() error
+}
+
+// Row is the part of *sql.Row repositories use.
+type Row interface {
+	Scan(dest ...any) error
+	Err() error
+}
+
+// Tx is a Querier whose statements are committed or rolled back together.
+type Tx interface {
+	Querier
+	Commit() error
+	Rollback() error
+}
+
+// DB is a Querier that can start transactions. Services hold a DB.
+type DB interface {
+	Querier
+	Begin() (Tx, error)
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error)
+	PingContext(ctx context.Context) error
+}
+
+type sqlDB struct {
+	*sql.DB
+}
+
+// NewDB adapts a *sql.DB connection pool to DB. Every statement is traced,
+// see tracing.go.
+func NewDB(db *sql.DB) DB {
+	return &sqlDB{DB: db}
+}
+
+func (db *sqlDB) Begin() (Tx, error) {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &sqlTx{Tx: tx}, nil
+}
+
+func (db *sqlDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
+	tx, err := db.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlTx{Tx: tx}, nil
+}
+
+func (db *sqlDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return tracedExec(ctx, db.DB.ExecContext, query, args)
+}
+
+func (db *sqlDB) QueryContext(ctx context.Context, query string, args ...any) (Rows, error) {
+	return tracedQuery(ctx, db.DB.QueryContext, query, args)
+}
+
+func (db *sqlDB) QueryRowContext(ctx context.Context, query string, args ...any) Row {
+	return tracedQueryRow(ctx, db.DB.QueryRowContext, query, args)
+}
+
+type sqlTx struct {
+	*sql.Tx
+}
+
+func (tx *sqlTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return tracedExec(ctx, tx.Tx.ExecContext, query, args)
+}
+
+func (tx *sqlTx) QueryContext(ctx context.Context, query string, args ...any) (Rows, error) {
+	return tracedQuery(ctx, tx.Tx.QueryContext, query, args)
+}
+
+func (tx *sqlTx) QueryRowContext(ctx context.Context, query string, args ...any) Row {
+	return tracedQueryRow(ctx, tx.Tx.QueryRowContext, query, args)
+}
@@ -9,12 +9,12 @@ import (
 )
 
 type GenreRepository interface {
-	Save(ctx context.Context, tx *sql.Tx, genre *domain.Genre) error
-	Update(ctx context.Context, tx *sql.Tx, genre *domain.Genre) error
-	Delete(ctx context.Context, tx *sql.Tx, ID int) error
-	FindAll(ctx context.Context, db *sql.DB) ([]*domain.Genre, error)
-	FindByName(ctx context.Context, db *sql.DB, name string) (*domain.Genre, error)
-	FindByID(ctx context.Context, db *sql.DB, ID int) (*domain.Genre, error)
+	Save(ctx context.Context, tx Querier, genre *domain.Genre) error
+	Update(ctx context.Context, tx Querier, genre *domain.Genre) error
+	Delete(ctx context.Context, tx Querier, ID int) error
+	FindAll(ctx context.Context, db Querier) ([]*domain.Genre, error)
+	FindByName(ctx context.Context, db Querier, name string) (*domain.Genre, error)
+	FindByID(ctx context.Context, db Querier, ID int) (*domain.Genre, error)
 }
 
 type GenreRepositoryaImpl struct {
@@ -24,7 +24,7 @@ func NewGenreRepository() GenreRepository {
 	return &GenreRepositoryaImpl{}
 }
 
-func (repository *GenreRepositoryaImpl) Save(ctx context.Context, tx *sql.Tx, genre *domain.Genre) error {
+func (repository *GenreRepositoryaImpl) Save(ctx context.Context, tx Querier, genre *domain.Genre) error {
 	_, err := tx.ExecContext(ctx, "INSERT INTO genres (name) VALUES ($1)", genre.Name)
 	if err != nil {
 		return err
@@ -33,7 +33,7 @@ func (repository *GenreRepositoryaImpl) Save(ctx context.Context, tx *sql.Tx, ge
 	return nil
 }
 
-func (repository *GenreRepositoryaImpl) Update(ctx context.Context, tx *sql.Tx, genre *domain.Genre) error {
+func (repository *GenreRepositoryaImpl) Update(ctx context.Context, tx Querier, genre *domain.Genre) error {
 	_, err := tx.ExecContext(ctx, "UPDATE genres SET name = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2", genre.Name, genre.ID)
 	if err != nil {
 		return err
@@ -42,7 +42,7 @@ func (repository *GenreRepositoryaImpl) Update(ctx context.Context, tx *sql.Tx,
 	return nil
 }
 
-func (repository *GenreRepositoryaImpl) Delete(ctx context.Context, tx *sql.Tx, ID int) error {
+func (repository *GenreRepositoryaImpl) Delete(ctx context.Context, tx Querier, ID int) error {
 	_, err := tx.ExecContext(ctx, "DELETE FROM genres WHERE id = $1", ID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -53,7 +53,7 @@ func (repository *GenreRepositoryaImpl) Delete(ctx context.Context, tx *sql.Tx,
 	return nil
 }
 
-func (repository *GenreRepositoryaImpl) FindAll(ctx context.Context, db *sql.DB) ([]*domain.Genre, error) {
+func (repository *GenreRepositoryaImpl) FindAll(ctx context.Context, db Querier) ([]*domain.Genre, error) {
 	rows, err := db.QueryContext(ctx, "SELECT id, name FROM genres")
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -72,7 +72,7 @@ func (repository *GenreRepositoryaImpl) FindAll(ctx context.Context, db *sql.DB)
 	return genres, nil
 }
 
-func (repository *GenreRepositoryaImpl) FindByName(ctx context.Context, db *sql.DB, name string) (*domain.Genre, error) {
+func (repository *GenreRepositoryaImpl) FindByName(ctx context.Context, db Querier, name string) (*domain.Genre, error) {
 	var genre domain.Genre
 	err := db.QueryRowContext(ctx, "SELECT id, name FROM genres WHERE name = $1", name).
 		Scan(&genre.ID, &genre.Name)
@@ -86,7 +86,7 @@ func (repository *GenreRepositoryaImpl) FindByName(ctx context.Context, db *sql.
 	return &genre, nil
 }
 
-func (repository *GenreRepositoryaImpl) FindByID(ctx context.Context, db *sql.DB, ID int) (*domain.Genre, error) {
+func (repository *GenreRepositoryaImpl) FindByID(ctx context.Context, db Querier, ID int) (*domain.Genre, error) {
 	var genre domain.Genre
 	err := db.QueryRowContext(ctx, "SELECT id, name FROM genres WHERE id = $1", ID).
 		Scan(&genre.ID, &genre.Name)
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+)
+
+type IdentityRepository interface {
+	Save(ctx context.Context, tx Querier, identity *domain.Identity) error
+	// FindBySubject returns nil when nobody is linked to the subject.
+	FindBySubject(ctx context.Context, db Querier, issuer, subject string) (*domain.Identity, error)
+	DeleteByUserID(ctx context.Context, tx Querier, userID int) error
+}
+
+type IdentityRepositoryImpl struct {
+}
+
+func NewIdentityRepository() IdentityRepository {
+	return &IdentityRepositoryImpl{}
+}
+
+func (repository *IdentityRepositoryImpl) Save(ctx context.Context, tx Querier, identity *domain.Identity) error {
+	query := "INSERT INTO user_identities (issuer, subject, user_id, created_at) VALUES ($1, $2, $3, $4)"
+	_, err := tx.ExecContext(ctx, query, identity.Issuer, identity.Subject, identity.UserID, identity.CreatedAt)
+	return err
+}
+
+func (repository *IdentityRepositoryImpl) FindBySubject(ctx context.Context, db Querier, issuer, subject string) (*domain.Identity, error) {
+	query := "SELECT issuer, subject, user_id, created_at FROM user_identities WHERE issuer = $1 AND subject = $2"
+
+	var identity domain.Identity
+	err := db.QueryRowContext(ctx, query, issuer, subject).Scan(&identity.Issuer, &identity.Subject, &identity.UserID, &identity.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &identity, nil
+}
+
+func (repository *IdentityRepositoryImpl) DeleteByUserID(ctx context.Context, tx Querier, userID int) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM user_identities WHERE user_id = $1", userID)
+	return err
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+)
+
+type LoginAttemptRepository interface {
+	// FindByKey returns nil when key has no failed logins on record.
+	FindByKey(ctx context.Context, db Querier, key string) (*domain.LoginAttempt, error)
+	// Fail counts a failed login of key at now, starting over when the last
+	// one was before since, and returns the attempts on record. Concurrent
+	// failures all count.
+	Fail(ctx context.Context, tx Querier, key string, now, since time.Time) (*domain.LoginAttempt, error)
+	// Lock refuses logins for key before until, unless they already are for
+	// longer.
+	Lock(ctx context.Context, tx Querier, key string, until time.Time) error
+	Delete(ctx context.Context, tx Querier, key string) error
+}
+
+type LoginAttemptRepositoryImpl struct {
+}
+
+func NewLoginAttemptRepository() LoginAttemptRepository {
+	return &LoginAttemptRepositoryImpl{}
+}
+
+func (repository *LoginAttemptRepositoryImpl) FindByKey(ctx context.Context, db Querier, key string) (*domain.LoginAttempt, error) {
+	query := "SELECT key, failures, last_failure_at, locked_until FROM login_attempts WHERE key = $1"
+
+	var attempt domain.LoginAttempt
+	var lockedUntil sql.NullTime
+	err := db.QueryRowContext(ctx, query, key).Scan(&attempt.Key, &attempt.Failures, &attempt.LastFailureAt, &lockedUntil)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	attempt.LockedUntil = lockedUntil.Time
+
+	return &attempt, nil
+}
+
+func (repository *LoginAttemptRepositoryImpl) Fail(ctx context.Context, tx Querier, key string, now, since time.Time) (*domain.LoginAttempt, error) {
+	query := `
+		INSERT INTO login_attempts (key, failures, last_failure_at) VALUES ($1, 1, $2)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN login_attempts.last_failure_at <= $3 THEN 1 ELSE login_attempts.failures + 1 END,
+			locked_until = CASE WHEN login_attempts.last_failure_at <= $3 THEN NULL ELSE login_attempts.locked_until END,
+			last_failure_at = $2
+		RETURNING key, failures, last_failure_at, locked_until
+	`
+
+	var attempt domain.LoginAttempt
+	var lockedUntil sql.NullTime
+	err := tx.QueryRowContext(ctx, query, key, now, since).Scan(&attempt.Key, &attempt.Failures, &attempt.LastFailureAt, &lockedUntil)
+	if err != nil {
+		return nil, err
+	}
+	attempt.LockedUntil = lockedUntil.Time
+
+	return &attempt, nil
+}
+
+func (repository *LoginAttemptRepositoryImpl) Lock(ctx context.Context, tx Querier, key string, until time.Time) error {
+	query := "UPDATE login_attempts SET locked_until = $2 WHERE key = $1 AND (locked_until IS NULL OR locked_until < $2)"
+	_, err := tx.ExecContext(ctx, query, key, until)
+	return err
+}
+
+func (repository *LoginAttemptRepositoryImpl) Delete(ctx context.Context, tx Querier, key string) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM login_attempts WHERE key = $1", key)
+	return err
+}
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+type apiKeyRepository struct {
+	store *Store
+}
+
+func NewAPIKeyRepository(store *Store) repository.APIKeyRepository {
+	return &apiKeyRepository{store: store}
+}
+
+func (r *apiKeyRepository) Save(ctx context.Context, tx repository.Querier, key *domain.APIKey) (int, error) {
+	var ID int
+	err := r.store.do(func(t *tables) error {
+		for _, existing := range t.apiKeys {
+			if existing.KeyHash == key.KeyHash {
+				return fmt.Errorf("API key %s already exists", key.Prefix)
+			}
+		}
+
+		ID = t.nextID("api_keys")
+		saved := *key
+		saved.ID = ID
+		t.apiKeys[ID] = saved
+		return nil
+	})
+	return ID, err
+}
+
+func (r *apiKeyRepository) FindAll(ctx context.Context, db repository.Querier) ([]*domain.APIKey, error) {
+	var keys []*domain.APIKey
+	r.store.do(func(t *tables) error {
+		for _, key := range t.apiKeys {
+			key := key
+			keys = append(keys, &key)
+		}
+		return nil
+	})
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	return keys, nil
+}
+
+func (r *apiKeyRepository) FindByID(ctx context.Context, db repository.Querier, ID int) (*domain.APIKey, error) {
+	var found *domain.APIKey
+	r.store.do(func(t *tables) error {
+		if key, ok := t.apiKeys[ID]; ok {
+			found = &key
+		}
+		return nil
+	})
+	if found == nil {
+		return nil, fmt.Errorf("API key with ID %d not found", ID)
+	}
+	return found, nil
+}
+
+func (r *apiKeyRepository) FindByHash(ctx context.Context, db repository.Querier, keyHash string) (*domain.APIKey, error) {
+	var found *domain.APIKey
+	r.store.do(func(t *tables) error {
+		for _, key := range t.apiKeys {
+			if key.KeyHash == keyHash {
+				key := key
+				found = &key
+			}
+		}
+		return nil
+	})
+	return found, nil
+}
+
+func (r *apiKeyRepository) UpdateLastUsed(ctx context.Context, tx repository.Querier, ID int, at time.Time) error {
+	return r.store.do(func(t *tables) error {
+		if key, ok := t.apiKeys[ID]; ok {
+			key.LastUsedAt = at
+			t.apiKeys[ID] = key
+		}
+		return nil
+	})
+}
+
+func (r *apiKeyRepository) Delete(ctx context.Context, tx repository.Querier, ID int) error {
+	return r.store.do(func(t *tables) error {
+		delete(t.apiKeys, ID)
+		return nil
+	})
+}
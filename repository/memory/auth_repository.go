@@ -0,0 +1,156 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+type authRepository struct {
+	store *Store
+}
+
+func NewAuthRepository(store *Store) repository.AuthRepository {
+	return &authRepository{store: store}
+}
+
+func (r *authRepository) Register(ctx context.Context, tx repository.Querier, auth *domain.Auth) error {
+	return r.store.do(func(t *tables) error {
+		for _, user := range t.users {
+			if user.Username == auth.Username {
+				return fmt.Errorf("username %s already exists", auth.Username)
+			}
+		}
+
+		ID := t.nextID("users")
+		t.users[ID] = domain.Auth{ID: ID, Username: auth.Username, Password: auth.Password, Role: auth.Role}
+		return nil
+	})
+}
+
+func (r *authRepository) Login(ctx context.Context, tx repository.Querier, username string) (*domain.Auth, error) {
+	auth := r.findByUsername(username)
+	if auth == nil {
+		return nil, repository.ErrUsernameNotFound
+	}
+	return auth, nil
+}
+
+func (r *authRepository) FindByUsername(ctx context.Context, db repository.Querier, username string) (*domain.Auth, error) {
+	auth := r.findByUsername(username)
+	if auth == nil {
+		return nil, fmt.Errorf("auth with username %s not found", username)
+	}
+	return auth, nil
+}
+
+func (r *authRepository) FindByID(ctx context.Context, db repository.Querier, ID int) (*domain.Auth, error) {
+	var found *domain.Auth
+	r.store.do(func(t *tables) error {
+		if user, ok := t.users[ID]; ok {
+			found = copyUser(user)
+		}
+		return nil
+	})
+	if found == nil {
+		return nil, fmt.Errorf("auth with ID %d %w", ID, repository.ErrUserNotFound)
+	}
+	return found, nil
+}
+
+func (r *authRepository) UpdatePassword(ctx context.Context, tx repository.Querier, ID int, password string) error {
+	return r.store.do(func(t *tables) error {
+		user, ok := t.users[ID]
+		if !ok {
+			return fmt.Errorf("auth with ID %d %w", ID, repository.ErrUserNotFound)
+		}
+		user.Password = password
+		t.users[ID] = user
+		return nil
+	})
+}
+
+func (r *authRepository) FindAll(ctx context.Context, db repository.Querier, search string) ([]*domain.Auth, error) {
+	search = strings.ToLower(search)
+
+	var users []*domain.Auth
+	r.store.do(func(t *tables) error {
+		for _, user := range t.users {
+			if strings.Contains(strings.ToLower(user.Username), search) || strings.Contains(strings.ToLower(user.DisplayName), search) {
+				users = append(users, copyUser(user))
+			}
+		}
+		return nil
+	})
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users, nil
+}
+
+func (r *authRepository) UpdateProfile(ctx context.Context, tx repository.Querier, auth *domain.Auth) error {
+	return r.update(auth.ID, func(user *domain.Auth) {
+		user.DisplayName = auth.DisplayName
+		user.AvatarURL = auth.AvatarURL
+		user.PreferredLanguages = append([]string(nil), auth.PreferredLanguages...)
+		user.PreferredGenres = append([]int(nil), auth.PreferredGenres...)
+	})
+}
+
+func (r *authRepository) UpdateRole(ctx context.Context, tx repository.Querier, ID int, role string) error {
+	return r.update(ID, func(user *domain.Auth) {
+		user.Role = role
+	})
+}
+
+func (r *authRepository) UpdateDisabledAt(ctx context.Context, tx repository.Querier, ID int, disabledAt time.Time) error {
+	return r.update(ID, func(user *domain.Auth) {
+		user.DisabledAt = disabledAt
+	})
+}
+
+func (r *authRepository) Delete(ctx context.Context, tx repository.Querier, ID int) error {
+	return r.store.do(func(t *tables) error {
+		if _, ok := t.users[ID]; !ok {
+			return fmt.Errorf("auth with ID %d %w", ID, repository.ErrUserNotFound)
+		}
+		delete(t.users, ID)
+		return nil
+	})
+}
+
+func (r *authRepository) update(ID int, change func(user *domain.Auth)) error {
+	return r.store.do(func(t *tables) error {
+		user, ok := t.users[ID]
+		if !ok {
+			return fmt.Errorf("auth with ID %d %w", ID, repository.ErrUserNotFound)
+		}
+		change(&user)
+		t.users[ID] = user
+		return nil
+	})
+}
+
+// copyUser copies user along with its slices, which the tables share with
+// their clones.
+func copyUser(user domain.Auth) *domain.Auth {
+	user.PreferredLanguages = append([]string(nil), user.PreferredLanguages...)
+	user.PreferredGenres = append([]int(nil), user.PreferredGenres...)
+	return &user
+}
+
+func (r *authRepository) findByUsername(username string) *domain.Auth {
+	var found *domain.Auth
+	r.store.do(func(t *tables) error {
+		for _, user := range t.users {
+			if user.Username == username {
+				found = copyUser(user)
+			}
+		}
+		return nil
+	})
+	return found
+}
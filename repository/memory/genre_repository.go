@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+type genreRepository struct {
+	store *Store
+}
+
+func NewGenreRepository(store *Store) repository.GenreRepository {
+	return &genreRepository{store: store}
+}
+
+func (r *genreRepository) Save(ctx context.Context, tx repository.Querier, genre *domain.Genre) error {
+	return r.store.do(func(t *tables) error {
+		ID := t.nextID("genres")
+		t.genres[ID] = domain.Genre{ID: ID, Name: genre.Name}
+		return nil
+	})
+}
+
+func (r *genreRepository) Update(ctx context.Context, tx repository.Querier, genre *domain.Genre) error {
+	return r.store.do(func(t *tables) error {
+		if _, ok := t.genres[genre.ID]; ok {
+			t.genres[genre.ID] = domain.Genre{ID: genre.ID, Name: genre.Name}
+		}
+		return nil
+	})
+}
+
+func (r *genreRepository) Delete(ctx context.Context, tx repository.Querier, ID int) error {
+	return r.store.do(func(t *tables) error {
+		delete(t.genres, ID)
+		return nil
+	})
+}
+
+func (r *genreRepository) FindAll(ctx context.Context, db repository.Querier) ([]*domain.Genre, error) {
+	var genres []*domain.Genre
+	r.store.do(func(t *tables) error {
+		for _, genre := range t.genres {
+			genre := genre
+			genres = append(genres, &genre)
+		}
+		return nil
+	})
+	sort.Slice(genres, func(i, j int) bool { return genres[i].ID < genres[j].ID })
+	return genres, nil
+}
+
+func (r *genreRepository) FindByName(ctx context.Context, db repository.Querier, name string) (*domain.Genre, error) {
+	var found *domain.Genre
+	r.store.do(func(t *tables) error {
+		for _, genre := range t.genres {
+			if genre.Name == name {
+				genre := genre
+				found = &genre
+			}
+		}
+		return nil
+	})
+	if found == nil {
+		return nil, fmt.Errorf("genre with name %s not found", name)
+	}
+	return found, nil
+}
+
+func (r *genreRepository) FindByID(ctx context.Context, db repository.Querier, ID int) (*domain.Genre, error) {
+	var found *domain.Genre
+	r.store.do(func(t *tables) error {
+		if genre, ok := t.genres[ID]; ok {
+			found = &genre
+		}
+		return nil
+	})
+	if found == nil {
+		return nil, fmt.Errorf("genre with id %d not found", ID)
+	}
+	return found, nil
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+type identity struct {
+	Issuer  string
+	Subject string
+}
+
+type identityRepository struct {
+	store *Store
+}
+
+func NewIdentityRepository(store *Store) repository.IdentityRepository {
+	return &identityRepository{store: store}
+}
+
+func (r *identityRepository) Save(ctx context.Context, tx repository.Querier, saved *domain.Identity) error {
+	return r.store.do(func(t *tables) error {
+		key := identity{Issuer: saved.Issuer, Subject: saved.Subject}
+		if _, ok := t.identities[key]; ok {
+			return fmt.Errorf("identity %s of %s already exists", saved.Subject, saved.Issuer)
+		}
+		t.identities[key] = *saved
+		return nil
+	})
+}
+
+func (r *identityRepository) FindBySubject(ctx context.Context, db repository.Querier, issuer, subject string) (*domain.Identity, error) {
+	var found *domain.Identity
+	r.store.do(func(t *tables) error {
+		if saved, ok := t.identities[identity{Issuer: issuer, Subject: subject}]; ok {
+			found = &saved
+		}
+		return nil
+	})
+	return found, nil
+}
+
+func (r *identityRepository) DeleteByUserID(ctx context.Context, tx repository.Querier, userID int) error {
+	return r.store.do(func(t *tables) error {
+		for key, saved := range t.identities {
+			if saved.UserID == userID {
+				delete(t.identities, key)
+			}
+		}
+		return nil
+	})
+}
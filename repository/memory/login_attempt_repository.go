@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+type loginAttemptRepository struct {
+	store *Store
+}
+
+func NewLoginAttemptRepository(store *Store) repository.LoginAttemptRepository {
+	return &loginAttemptRepository{store: store}
+}
+
+func (r *loginAttemptRepository) FindByKey(ctx context.Context, db repository.Querier, key string) (*domain.LoginAttempt, error) {
+	var found *domain.LoginAttempt
+	r.store.do(func(t *tables) error {
+		if attempt, ok := t.loginAttempts[key]; ok {
+			found = &attempt
+		}
+		return nil
+	})
+	return found, nil
+}
+
+func (r *loginAttemptRepository) Fail(ctx context.Context, tx repository.Querier, key string, now, since time.Time) (*domain.LoginAttempt, error) {
+	var failed domain.LoginAttempt
+	r.store.do(func(t *tables) error {
+		attempt, ok := t.loginAttempts[key]
+		if !ok || !attempt.LastFailureAt.After(since) {
+			attempt = domain.LoginAttempt{Key: key}
+		}
+		attempt.Failures++
+		attempt.LastFailureAt = now
+		t.loginAttempts[key] = attempt
+		failed = attempt
+		return nil
+	})
+	return &failed, nil
+}
+
+func (r *loginAttemptRepository) Lock(ctx context.Context, tx repository.Querier, key string, until time.Time) error {
+	return r.store.do(func(t *tables) error {
+		if attempt, ok := t.loginAttempts[key]; ok && attempt.LockedUntil.Before(until) {
+			attempt.LockedUntil = until
+			t.loginAttempts[key] = attempt
+		}
+		return nil
+	})
+}
+
+func (r *loginAttemptRepository) Delete(ctx context.Context, tx repository.Querier, key string) error {
+	return r.store.do(func(t *tables) error {
+		delete(t.loginAttempts, key)
+		return nil
+	})
+}
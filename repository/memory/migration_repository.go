@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+type migrationRepository struct {
+	store *Store
+}
+
+func NewMigrationRepository(store *Store) repository.MigrationRepository {
+	return &migrationRepository{store: store}
+}
+
+func (r *migrationRepository) Version(ctx context.Context, db repository.Querier) (version int, dirty bool, err error) {
+	err = r.store.do(func(t *tables) error {
+		if t.schemaVersion == 0 {
+			return errors.New("no migration has been applied")
+		}
+		version, dirty = t.schemaVersion, t.schemaDirty
+		return nil
+	})
+	return version, dirty, err
+}
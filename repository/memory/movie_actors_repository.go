@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+type movieActorRepository struct {
+	store *Store
+}
+
+func NewMovieActorRepository(store *Store) repository.MovieActorRepository {
+	return &movieActorRepository{store: store}
+}
+
+func (r *movieActorRepository) Delete(ctx context.Context, tx repository.Querier, actorID int) error {
+	return r.deleteWhere(func(row movieActor) bool { return row.Credit.ID == actorID })
+}
+
+func (r *movieActorRepository) SaveCredit(ctx context.Context, tx repository.Querier, movieID int, credit *domain.ActorMovie) error {
+	return r.store.do(func(t *tables) error {
+		for _, row := range t.movieActors {
+			if row.MovieID == movieID && row.Credit.ID == credit.ID {
+				return errors.New(`duplicate key value violates unique constraint "movie_actors_pkey"`)
+			}
+		}
+
+		t.movieActors = append(t.movieActors, movieActor{MovieID: movieID, Credit: storedCredit(credit)})
+		return nil
+	})
+}
+
+func (r *movieActorRepository) UpdateCredit(ctx context.Context, tx repository.Querier, movieID int, credit *domain.ActorMovie) error {
+	return r.store.do(func(t *tables) error {
+		for i, row := range t.movieActors {
+			if row.MovieID == movieID && row.Credit.ID == credit.ID {
+				t.movieActors[i].Credit = storedCredit(credit)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *movieActorRepository) DeleteFromMovie(ctx context.Context, tx repository.Querier, movieID, actorID int) error {
+	return r.deleteWhere(func(row movieActor) bool { return row.MovieID == movieID && row.Credit.ID == actorID })
+}
+
+func (r *movieActorRepository) deleteWhere(match func(row movieActor) bool) error {
+	return r.store.do(func(t *tables) error {
+		rows := t.movieActors[:0]
+		for _, row := range t.movieActors {
+			if !match(row) {
+				rows = append(rows, row)
+			}
+		}
+		t.movieActors = rows
+		return nil
+	})
+}
+
+// storedCredit keeps only the columns of movie_actors; name and date of birth
+// come from people when the cast is read back.
+func storedCredit(credit *domain.ActorMovie) domain.ActorMovie {
+	return domain.ActorMovie{
+		ID:            credit.ID,
+		Role:          credit.Role,
+		CharacterName: credit.CharacterName,
+		CreditType:    credit.CreditType,
+		BillingOrder:  credit.BillingOrder,
+		Notes:         credit.Notes,
+	}
+}
+
+func (r *movieActorRepository) FindByID(ctx context.Context, db repository.Querier, movieID int) (*domain.MovieActor, error) {
+	var actorMovie *domain.MovieActor
+	r.store.do(func(t *tables) error {
+		movie, ok := t.movies[movieID]
+		if !ok {
+			return nil
+		}
+
+		actorMovie = &domain.MovieActor{
+			Movie: domain.Movie{ID: movie.ID, Title: movie.Title, ReleaseDate: movie.ReleaseDate},
+		}
+		for _, row := range t.movieActors {
+			if row.MovieID != movieID {
+				continue
+			}
+			actor := row.Credit
+			if person, ok := t.people[actor.ID]; ok {
+				actor.Name = person.Name
+				actor.DateOfBirth = person.DateOfBirth
+			}
+			actorMovie.Actors = append(actorMovie.Actors, actor)
+		}
+		return nil
+	})
+	if actorMovie == nil {
+		return nil, errors.New("movie not found")
+	}
+
+	// Billed credits come first in billing order, unbilled ones (0) follow by name
+	sort.SliceStable(actorMovie.Actors, func(i, j int) bool {
+		a, b := actorMovie.Actors[i], actorMovie.Actors[j]
+		if (a.BillingOrder == 0) != (b.BillingOrder == 0) {
+			return b.BillingOrder == 0
+		}
+		if a.BillingOrder != b.BillingOrder {
+			return a.BillingOrder < b.BillingOrder
+		}
+		return a.Name < b.Name
+	})
+	return actorMovie, nil
+}
+
+func (r *movieActorRepository) FindActorAtMovieExists(ctx context.Context, db repository.Querier, actorID int) error {
+	return r.store.do(func(t *tables) error {
+		for _, row := range t.movieActors {
+			if row.Credit.ID == actorID {
+				return nil
+			}
+		}
+		return errors.New("actors ID at movie not found")
+	})
+}
+
+func (r *movieActorRepository) FindMoviesByActor(ctx context.Context, db repository.Querier, actorID int, ascending bool) ([]domain.CastCredit, error) {
+	var credits []domain.CastCredit
+	r.store.do(func(t *tables) error {
+		credits = castCredits(t, actorID)
+		return nil
+	})
+
+	sort.SliceStable(credits, func(i, j int) bool {
+		a, b := credits[i].Movie, credits[j].Movie
+		if a.ReleaseDate.Equal(b.ReleaseDate) {
+			return a.ID < b.ID
+		}
+		return a.ReleaseDate.Before(b.ReleaseDate) == ascending
+	})
+	return credits, nil
+}
@@ -0,0 +1,169 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+type movieCrewRepository struct {
+	store *Store
+}
+
+func NewMovieCrewRepository(store *Store) repository.MovieCrewRepository {
+	return &movieCrewRepository{store: store}
+}
+
+func (r *movieCrewRepository) Save(ctx context.Context, tx repository.Querier, movieID, personID int, job string) error {
+	return r.store.do(func(t *tables) error {
+		for _, row := range t.movieCrew {
+			if row.MovieID == movieID && row.PersonID == personID && row.Job == job {
+				return errors.New(`duplicate key value violates unique constraint "movie_crew_pkey"`)
+			}
+		}
+
+		t.movieCrew = append(t.movieCrew, movieCrew{MovieID: movieID, PersonID: personID, Job: job})
+		return nil
+	})
+}
+
+func (r *movieCrewRepository) Delete(ctx context.Context, tx repository.Querier, movieID, personID int, job string) error {
+	return r.deleteWhere(func(row movieCrew) bool {
+		return row.MovieID == movieID && row.PersonID == personID && row.Job == job
+	})
+}
+
+func (r *movieCrewRepository) DeleteByMovie(ctx context.Context, tx repository.Querier, movieID int) error {
+	return r.deleteWhere(func(row movieCrew) bool { return row.MovieID == movieID })
+}
+
+func (r *movieCrewRepository) deleteWhere(match func(row movieCrew) bool) error {
+	return r.store.do(func(t *tables) error {
+		rows := t.movieCrew[:0]
+		for _, row := range t.movieCrew {
+			if !match(row) {
+				rows = append(rows, row)
+			}
+		}
+		t.movieCrew = rows
+		return nil
+	})
+}
+
+func (r *movieCrewRepository) FindByID(ctx context.Context, db repository.Querier, movieID int, job string) (*domain.MovieCrew, error) {
+	var crew *domain.MovieCrew
+	r.store.do(func(t *tables) error {
+		movie, ok := t.movies[movieID]
+		if !ok {
+			return nil
+		}
+
+		crew = &domain.MovieCrew{
+			Movie: domain.Movie{ID: movie.ID, Title: movie.Title, ReleaseDate: movie.ReleaseDate},
+		}
+		for _, row := range t.movieCrew {
+			person, ok := t.people[row.PersonID]
+			if row.MovieID != movieID || !ok || (job != "" && row.Job != job) {
+				continue
+			}
+			crew.Crew = append(crew.Crew, domain.CrewMember{
+				ID:          person.ID,
+				Name:        person.Name,
+				DateOfBirth: person.DateOfBirth,
+				Job:         row.Job,
+				Department:  department(t, row.Job),
+			})
+		}
+		return nil
+	})
+	if crew == nil {
+		return nil, errors.New("movie not found")
+	}
+
+	sort.SliceStable(crew.Crew, func(i, j int) bool {
+		if crew.Crew[i].Department == crew.Crew[j].Department {
+			return crew.Crew[i].Name < crew.Crew[j].Name
+		}
+		return crew.Crew[i].Department < crew.Crew[j].Department
+	})
+	return crew, nil
+}
+
+func (r *movieCrewRepository) FindByPerson(ctx context.Context, db repository.Querier, personID int) (*domain.PersonCrew, error) {
+	var personCrew *domain.PersonCrew
+	r.store.do(func(t *tables) error {
+		person, ok := t.people[personID]
+		if !ok {
+			return nil
+		}
+
+		personCrew = &domain.PersonCrew{
+			Person:  domain.Person{ID: person.ID, Name: person.Name},
+			Credits: crewCredits(t, personID, ""),
+		}
+		return nil
+	})
+	if personCrew == nil {
+		return nil, fmt.Errorf("person with ID %d not found", personID)
+	}
+
+	sort.SliceStable(personCrew.Credits, func(i, j int) bool {
+		a, b := personCrew.Credits[i], personCrew.Credits[j]
+		if a.Movie.ReleaseDate.Equal(b.Movie.ReleaseDate) {
+			return a.Department < b.Department
+		}
+		return a.Movie.ReleaseDate.After(b.Movie.ReleaseDate)
+	})
+	return personCrew, nil
+}
+
+func (r *movieCrewRepository) FindCrewAtMovie(ctx context.Context, db repository.Querier, movieID, personID int, job string) (bool, error) {
+	err := r.store.do(func(t *tables) error {
+		for _, row := range t.movieCrew {
+			if row.MovieID == movieID && row.PersonID == personID && row.Job == job {
+				return nil
+			}
+		}
+		return repository.ErrCrewNotFound
+	})
+	return err == nil, err
+}
+
+func (r *movieCrewRepository) FindJob(ctx context.Context, db repository.Querier, job string) (*domain.CrewJob, error) {
+	var found *domain.CrewJob
+	r.store.do(func(t *tables) error {
+		for _, crewJob := range t.crewJobs {
+			if crewJob.Job == job {
+				crewJob := crewJob
+				found = &crewJob
+			}
+		}
+		return nil
+	})
+	if found == nil {
+		return nil, fmt.Errorf("job %s is not a known crew job", job)
+	}
+	return found, nil
+}
+
+func (r *movieCrewRepository) FindAllJobs(ctx context.Context, db repository.Querier) ([]*domain.CrewJob, error) {
+	var crewJobs []*domain.CrewJob
+	r.store.do(func(t *tables) error {
+		for _, crewJob := range t.crewJobs {
+			crewJob := crewJob
+			crewJobs = append(crewJobs, &crewJob)
+		}
+		return nil
+	})
+	sort.Slice(crewJobs, func(i, j int) bool {
+		if crewJobs[i].Department == crewJobs[j].Department {
+			return crewJobs[i].Job < crewJobs[j].Job
+		}
+		return crewJobs[i].Department < crewJobs[j].Department
+	})
+	return crewJobs, nil
+}
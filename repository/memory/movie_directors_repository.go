@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+// movieDirectorRepository is the director slice of movie_crew, as in the
+// Postgres implementation. Directors are identified by their director ID.
+type movieDirectorRepository struct {
+	store *Store
+	crew  repository.MovieCrewRepository
+}
+
+func NewMovieDirectorRepository(store *Store) repository.MovieDirectorRepository {
+	return &movieDirectorRepository{store: store, crew: NewMovieCrewRepository(store)}
+}
+
+// person returns the people key of the director directorID, or zero.
+func (r *movieDirectorRepository) person(directorID int) int {
+	var personID int
+	r.store.do(func(t *tables) error {
+		personID, _ = directorPerson(t, directorID)
+		return nil
+	})
+	return personID
+}
+
+func (r *movieDirectorRepository) Save(ctx context.Context, tx repository.Querier, movieID, directorID int) error {
+	personID := r.person(directorID)
+	if personID == 0 {
+		return nil
+	}
+	return r.crew.Save(ctx, tx, movieID, personID, domain.JobDirector)
+}
+
+func (r *movieDirectorRepository) Delete(ctx context.Context, tx repository.Querier, movieID int, directorID int) error {
+	return r.crew.Delete(ctx, tx, movieID, r.person(directorID), domain.JobDirector)
+}
+
+func (r *movieDirectorRepository) FindByID(ctx context.Context, db repository.Querier, movieID int) (*domain.MovieDirector, error) {
+	crew, err := r.crew.FindByID(ctx, db, movieID, domain.JobDirector)
+	if err != nil {
+		return nil, err
+	}
+
+	directorMovie := &domain.MovieDirector{Movie: crew.Movie}
+	for _, member := range crew.Crew {
+		var directorID int
+		r.store.do(func(t *tables) error {
+			directorID = t.people[member.ID].DirectorID
+			return nil
+		})
+		directorMovie.Directors = append(directorMovie.Directors, domain.Director{
+			ID:          directorID,
+			Name:        member.Name,
+			DateOfBirth: member.DateOfBirth,
+		})
+	}
+	return directorMovie, nil
+}
+
+func (r *movieDirectorRepository) FindDirectorAtMovie(ctx context.Context, db repository.Querier, movieID, directorID int) (bool, error) {
+	exists, _ := r.crew.FindCrewAtMovie(ctx, db, movieID, r.person(directorID), domain.JobDirector)
+	if !exists {
+		return false, errors.New("directors ID at movie not found")
+	}
+	return true, nil
+}
+
+func (r *movieDirectorRepository) FindMoviesByDirector(ctx context.Context, db repository.Querier, directorID int, ascending bool) ([]domain.Movie, error) {
+	var movies []domain.Movie
+	r.store.do(func(t *tables) error {
+		personID, _ := directorPerson(t, directorID)
+		for _, credit := range crewCredits(t, personID, domain.JobDirector) {
+			movies = append(movies, credit.Movie)
+		}
+		return nil
+	})
+
+	sort.SliceStable(movies, func(i, j int) bool {
+		if movies[i].ReleaseDate.Equal(movies[j].ReleaseDate) {
+			return movies[i].ID < movies[j].ID
+		}
+		return movies[i].ReleaseDate.Before(movies[j].ReleaseDate) == ascending
+	})
+	return movies, nil
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+type movieGenreRepository struct {
+	store *Store
+}
+
+func NewMovieGenreRepository(store *Store) repository.MovieGenreRepository {
+	return &movieGenreRepository{store: store}
+}
+
+func (r *movieGenreRepository) Save(ctx context.Context, tx repository.Querier, movieID, genreID int) error {
+	return r.store.do(func(t *tables) error {
+		if _, ok := t.movies[movieID]; !ok {
+			return fmt.Errorf("movie with id %d does not exist", movieID)
+		}
+		if _, ok := t.genres[genreID]; !ok {
+			return fmt.Errorf("genre with id %d does not exist", genreID)
+		}
+
+		t.movieGenres = append(t.movieGenres, movieGenre{MovieID: movieID, GenreID: genreID})
+		return nil
+	})
+}
+
+func (r *movieGenreRepository) Delete(ctx context.Context, tx repository.Querier, movieID int, genreID int) error {
+	return r.store.do(func(t *tables) error {
+		rows := t.movieGenres[:0]
+		for _, row := range t.movieGenres {
+			if row.MovieID != movieID || row.GenreID != genreID {
+				rows = append(rows, row)
+			}
+		}
+		t.movieGenres = rows
+		return nil
+	})
+}
+
+func (r *movieGenreRepository) FindByID(ctx context.Context, db repository.Querier, movieID int) (*domain.MovieGenre, error) {
+	var genreMovie *domain.MovieGenre
+	r.store.do(func(t *tables) error {
+		movie, ok := t.movies[movieID]
+		if !ok {
+			return nil
+		}
+
+		genreMovie = &domain.MovieGenre{
+			Movie: domain.Movie{ID: movie.ID, Title: movie.Title, ReleaseDate: movie.ReleaseDate},
+		}
+		for _, row := range t.movieGenres {
+			if genre, ok := t.genres[row.GenreID]; ok && row.MovieID == movieID {
+				genreMovie.Genres = append(genreMovie.Genres, genre)
+			}
+		}
+		return nil
+	})
+	if genreMovie == nil {
+		return nil, errors.New("movie not found")
+	}
+
+	sort.Slice(genreMovie.Genres, func(i, j int) bool { return genreMovie.Genres[i].ID < genreMovie.Genres[j].ID })
+	return genreMovie, nil
+}
+
+func (r *movieGenreRepository) FindGenreExists(ctx context.Context, db repository.Querier, genreID int) error {
+	return r.store.do(func(t *tables) error {
+		for _, row := range t.movieGenres {
+			if row.GenreID == genreID {
+				return nil
+			}
+		}
+		return errors.New("genres ID at movie not found")
+	})
+}
@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+type movieRepository struct {
+	store *Store
+}
+
+func NewMovieRepository(store *Store) repository.MovieRepository {
+	return &movieRepository{store: store}
+}
+
+func (r *movieRepository) Save(ctx context.Context, tx repository.Querier, movie *domain.Movie) (int, error) {
+	var ID int
+	err := r.store.do(func(t *tables) error {
+		ID = t.nextID("movies")
+		saved := *movie
+		saved.ID = ID
+		saved.CreatedAt = now()
+		saved.UpdatedAt = now()
+		t.movies[ID] = saved
+		return nil
+	})
+	return ID, err
+}
+
+func (r *movieRepository) Update(ctx context.Context, tx repository.Querier, movie *domain.Movie) error {
+	return r.store.do(func(t *tables) error {
+		existing, ok := t.movies[movie.ID]
+		if ok {
+			updated := *movie
+			updated.CreatedAt = existing.CreatedAt
+			updated.UpdatedAt = now()
+			t.movies[movie.ID] = updated
+		}
+		return nil
+	})
+}
+
+func (r *movieRepository) Delete(ctx context.Context, tx repository.Querier, ID int) error {
+	return r.store.do(func(t *tables) error {
+		delete(t.movies, ID)
+
+		// movie_crew.movie_id cascades on delete
+		crew := t.movieCrew[:0]
+		for _, row := range t.movieCrew {
+			if row.MovieID != ID {
+				crew = append(crew, row)
+			}
+		}
+		t.movieCrew = crew
+		return nil
+	})
+}
+
+func (r *movieRepository) find(match func(movie domain.Movie) bool) []*domain.Movie {
+	var movies []*domain.Movie
+	r.store.do(func(t *tables) error {
+		for _, movie := range t.movies {
+			if match(movie) {
+				movie := movie
+				movies = append(movies, &movie)
+			}
+		}
+		return nil
+	})
+	sort.Slice(movies, func(i, j int) bool { return movies[i].ID < movies[j].ID })
+	return movies
+}
+
+func (r *movieRepository) FindByID(ctx context.Context, db repository.Querier, ID int) (*domain.Movie, error) {
+	movies := r.find(func(movie domain.Movie) bool { return movie.ID == ID })
+	if len(movies) == 0 {
+		return nil, errors.New("sorry, movie id not found")
+	}
+	return movies[0], nil
+}
+
+func (r *movieRepository) FindByTitle(ctx context.Context, db repository.Querier, title string) (*domain.Movie, error) {
+	movies := r.find(func(movie domain.Movie) bool { return movie.Title == title })
+	if len(movies) == 0 {
+		return nil, fmt.Errorf("movie with name %s not found", title)
+	}
+	return movies[0], nil
+}
+
+func (r *movieRepository) FindAll(ctx context.Context, db repository.Querier) ([]*domain.Movie, error) {
+	return r.find(func(movie domain.Movie) bool { return true }), nil
+}
+
+func (r *movieRepository) FindAllMoviesByGenreID(ctx context.Context, db repository.Querier, genreID int) ([]*domain.Movie, error) {
+	tagged := map[int]bool{}
+	r.store.do(func(t *tables) error {
+		for _, row := range t.movieGenres {
+			if _, ok := t.genres[row.GenreID]; ok && row.GenreID == genreID {
+				tagged[row.MovieID] = true
+			}
+		}
+		return nil
+	})
+	return r.find(func(movie domain.Movie) bool { return tagged[movie.ID] }), nil
+}
@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+type nationalRepository struct {
+	store *Store
+}
+
+func NewNationalRepository(store *Store) repository.NationalRepository {
+	return &nationalRepository{store: store}
+}
+
+func (r *nationalRepository) Save(ctx context.Context, tx repository.Querier, national *domain.National) error {
+	return r.store.do(func(t *tables) error {
+		ID := t.nextID("national")
+		t.nationals[ID] = domain.National{ID: ID, Name: national.Name, CreatedAt: now(), UpdatedAt: now()}
+		return nil
+	})
+}
+
+func (r *nationalRepository) Update(ctx context.Context, tx repository.Querier, national *domain.National) error {
+	return r.store.do(func(t *tables) error {
+		current, ok := t.nationals[national.ID]
+		if ok {
+			current.Name = national.Name
+			current.UpdatedAt = now()
+			t.nationals[national.ID] = current
+		}
+		return nil
+	})
+}
+
+func (r *nationalRepository) Delete(ctx context.Context, tx repository.Querier, ID int) error {
+	return r.store.do(func(t *tables) error {
+		delete(t.nationals, ID)
+		return nil
+	})
+}
+
+func (r *nationalRepository) FindAll(ctx context.Context, db repository.Querier) ([]*domain.National, error) {
+	var nationals []*domain.National
+	r.store.do(func(t *tables) error {
+		for _, national := range t.nationals {
+			national := national
+			nationals = append(nationals, &national)
+		}
+		return nil
+	})
+	sort.Slice(nationals, func(i, j int) bool { return nationals[i].ID < nationals[j].ID })
+	return nationals, nil
+}
+
+func (r *nationalRepository) FindByName(ctx context.Context, db repository.Querier, name string) (*domain.National, error) {
+	var found *domain.National
+	r.store.do(func(t *tables) error {
+		for _, national := range t.nationals {
+			if national.Name == name {
+				national := national
+				found = &national
+			}
+		}
+		return nil
+	})
+	if found == nil {
+		return nil, fmt.Errorf("national with name %s not found", name)
+	}
+	return found, nil
+}
+
+func (r *nationalRepository) FindByID(ctx context.Context, db repository.Querier, ID int) (*domain.National, error) {
+	var found *domain.National
+	r.store.do(func(t *tables) error {
+		if national, ok := t.nationals[ID]; ok {
+			found = &national
+		}
+		return nil
+	})
+	if found == nil {
+		return nil, fmt.Errorf("national with id %d not found", ID)
+	}
+	return found, nil
+}
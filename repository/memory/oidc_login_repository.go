@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+type oidcLoginRepository struct {
+	store *Store
+}
+
+func NewOIDCLoginRepository(store *Store) repository.OIDCLoginRepository {
+	return &oidcLoginRepository{store: store}
+}
+
+func (r *oidcLoginRepository) Save(ctx context.Context, tx repository.Querier, login *domain.OIDCLogin) error {
+	return r.store.do(func(t *tables) error {
+		t.oidcLogins[login.StateHash] = *login
+		return nil
+	})
+}
+
+func (r *oidcLoginRepository) Take(ctx context.Context, tx repository.Querier, stateHash string) (*domain.OIDCLogin, error) {
+	var found *domain.OIDCLogin
+	r.store.do(func(t *tables) error {
+		if login, ok := t.oidcLogins[stateHash]; ok {
+			found = &login
+			delete(t.oidcLogins, stateHash)
+		}
+		return nil
+	})
+	return found, nil
+}
+
+func (r *oidcLoginRepository) DeleteExpired(ctx context.Context, tx repository.Querier, now time.Time) error {
+	return r.store.do(func(t *tables) error {
+		for hash, login := range t.oidcLogins {
+			if login.ExpiresAt.Before(now) {
+				delete(t.oidcLogins, hash)
+			}
+		}
+		return nil
+	})
+}
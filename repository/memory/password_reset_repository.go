@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+type passwordResetRepository struct {
+	store *Store
+}
+
+func NewPasswordResetRepository(store *Store) repository.PasswordResetRepository {
+	return &passwordResetRepository{store: store}
+}
+
+func (r *passwordResetRepository) Save(ctx context.Context, tx repository.Querier, reset *domain.PasswordReset) error {
+	return r.store.do(func(t *tables) error {
+		t.passwordResets[reset.TokenHash] = *reset
+		return nil
+	})
+}
+
+func (r *passwordResetRepository) FindByTokenHash(ctx context.Context, db repository.Querier, tokenHash string) (*domain.PasswordReset, error) {
+	var found *domain.PasswordReset
+	r.store.do(func(t *tables) error {
+		if reset, ok := t.passwordResets[tokenHash]; ok {
+			found = &reset
+		}
+		return nil
+	})
+	return found, nil
+}
+
+func (r *passwordResetRepository) DeleteByUserID(ctx context.Context, tx repository.Querier, userID int) error {
+	return r.store.do(func(t *tables) error {
+		for hash, reset := range t.passwordResets {
+			if reset.UserID == userID {
+				delete(t.passwordResets, hash)
+			}
+		}
+		return nil
+	})
+}
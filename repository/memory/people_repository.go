@@ -0,0 +1,319 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+type personRepository struct {
+	store *Store
+	view  string
+}
+
+func NewPersonRepository(store *Store) repository.PersonRepository {
+	return &personRepository{store: store}
+}
+
+func NewActorRepository(store *Store) repository.ActorRepository {
+	return &personRepository{store: store, view: domain.PersonViewActor}
+}
+
+func NewDirectorRepository(store *Store) repository.DirectorRepository {
+	return &personRepository{store: store, view: domain.PersonViewDirector}
+}
+
+func (r *personRepository) visible(person domain.Person) bool {
+	switch r.view {
+	case domain.PersonViewActor:
+		return person.IsActor
+	case domain.PersonViewDirector:
+		return person.IsDirector
+	default:
+		return true
+	}
+}
+
+func (r *personRepository) notFound() string {
+	if r.view != "" {
+		return r.view
+	}
+	return "person"
+}
+
+func markAs(t *tables, person *domain.Person, view string) {
+	switch view {
+	case domain.PersonViewActor:
+		person.IsActor = true
+	case domain.PersonViewDirector:
+		person.IsDirector = true
+		if person.DirectorID == 0 {
+			person.DirectorID = t.nextID("directors")
+		}
+	}
+}
+
+// personID returns the people key of whoever the repository's view knows as
+// ID. The directors view goes by director ID, as in the Postgres
+// implementation.
+func (r *personRepository) personID(t *tables, ID int) (int, bool) {
+	if r.view != domain.PersonViewDirector {
+		_, ok := t.people[ID]
+		return ID, ok
+	}
+	return directorPerson(t, ID)
+}
+
+// directorPerson returns the people key of the director directorID.
+func directorPerson(t *tables, directorID int) (int, bool) {
+	for ID, person := range t.people {
+		if person.DirectorID != 0 && person.DirectorID == directorID {
+			return ID, true
+		}
+	}
+	return 0, false
+}
+
+func (r *personRepository) Save(ctx context.Context, tx repository.Querier, person *domain.Person) error {
+	return r.store.do(func(t *tables) error {
+		if r.view != "" {
+			for ID, existing := range t.people {
+				if existing.Name == person.Name && existing.DateOfBirth.Equal(person.DateOfBirth) {
+					markAs(t, &existing, r.view)
+					existing.UpdatedAt = now()
+					t.people[ID] = existing
+					return nil
+				}
+			}
+		}
+
+		ID := t.nextID("people")
+		saved := domain.Person{
+			ID:            ID,
+			Name:          person.Name,
+			DateOfBirth:   person.DateOfBirth,
+			NationalityID: person.NationalityID,
+			CreatedAt:     now(),
+			UpdatedAt:     now(),
+		}
+		if person.IsActor {
+			markAs(t, &saved, domain.PersonViewActor)
+		}
+		if person.IsDirector {
+			markAs(t, &saved, domain.PersonViewDirector)
+		}
+		markAs(t, &saved, r.view)
+		t.people[ID] = saved
+		return nil
+	})
+}
+
+func (r *personRepository) Update(ctx context.Context, tx repository.Querier, person *domain.Person) error {
+	return r.store.do(func(t *tables) error {
+		ID, ok := r.personID(t, person.ID)
+		if !ok {
+			return nil
+		}
+
+		existing := t.people[ID]
+		existing.Name = person.Name
+		existing.DateOfBirth = person.DateOfBirth
+		existing.NationalityID = person.NationalityID
+		existing.UpdatedAt = person.UpdatedAt
+		t.people[ID] = existing
+		return nil
+	})
+}
+
+func (r *personRepository) Delete(ctx context.Context, tx repository.Querier, ID int) error {
+	return r.store.do(func(t *tables) error {
+		ID, ok := r.personID(t, ID)
+		if !ok {
+			return nil
+		}
+		existing := t.people[ID]
+
+		if r.view != domain.PersonViewDirector && credited(t, ID) {
+			return repository.ErrPersonHasCredits
+		}
+
+		switch r.view {
+		case domain.PersonViewActor:
+			existing.IsActor = false
+		case domain.PersonViewDirector:
+			existing.IsDirector = false
+		default:
+			existing.IsActor, existing.IsDirector = false, false
+		}
+
+		if existing.IsActor || existing.IsDirector {
+			t.people[ID] = existing
+			return nil
+		}
+
+		// movie_actors.actor_id does not cascade on delete
+		if credited(t, ID) {
+			return errors.New(`update or delete on table "people" violates foreign key constraint "movie_actors_actor_id_fkey" on table "movie_actors"`)
+		}
+		delete(t.people, ID)
+
+		// movie_crew.person_id cascades on delete
+		crew := t.movieCrew[:0]
+		for _, row := range t.movieCrew {
+			if row.PersonID != ID {
+				crew = append(crew, row)
+			}
+		}
+		t.movieCrew = crew
+		return nil
+	})
+}
+
+// credited reports whether the person has acting credits.
+func credited(t *tables, ID int) bool {
+	for _, row := range t.movieActors {
+		if row.Credit.ID == ID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *personRepository) MarkAs(ctx context.Context, tx repository.Querier, ID int, view string) error {
+	if view != domain.PersonViewActor && view != domain.PersonViewDirector {
+		return fmt.Errorf("unknown people view %s", view)
+	}
+
+	return r.store.do(func(t *tables) error {
+		if existing, ok := t.people[ID]; ok {
+			markAs(t, &existing, view)
+			t.people[ID] = existing
+		}
+		return nil
+	})
+}
+
+func (r *personRepository) find(match func(person domain.Person) bool) []*domain.Person {
+	var people []*domain.Person
+	r.store.do(func(t *tables) error {
+		for _, person := range t.people {
+			if r.view == domain.PersonViewDirector {
+				person.ID = person.DirectorID
+			}
+			if r.visible(person) && match(person) {
+				person := person
+				people = append(people, &person)
+			}
+		}
+		return nil
+	})
+	sort.Slice(people, func(i, j int) bool { return people[i].ID < people[j].ID })
+	return people
+}
+
+func (r *personRepository) FindByID(ctx context.Context, db repository.Querier, ID int) (*domain.Person, error) {
+	people := r.find(func(person domain.Person) bool { return person.ID == ID })
+	if len(people) == 0 {
+		return nil, fmt.Errorf("sorry, %s id not found", r.notFound())
+	}
+	return people[0], nil
+}
+
+func (r *personRepository) FindByName(ctx context.Context, db repository.Querier, name string) (*domain.Person, error) {
+	people := r.find(func(person domain.Person) bool { return person.Name == name })
+	if len(people) == 0 {
+		return nil, fmt.Errorf("%s with name %s not found", r.notFound(), name)
+	}
+	return people[0], nil
+}
+
+func (r *personRepository) FindByNational(ctx context.Context, db repository.Querier, nationalityID int) ([]*domain.Person, error) {
+	return r.find(func(person domain.Person) bool { return person.NationalityID == nationalityID }), nil
+}
+
+func (r *personRepository) FindAll(ctx context.Context, db repository.Querier) ([]*domain.Person, error) {
+	return r.find(func(person domain.Person) bool { return true }), nil
+}
+
+func (r *personRepository) FindCredits(ctx context.Context, db repository.Querier, ID int) (*domain.PersonCredits, error) {
+	person, err := r.FindByID(ctx, db, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	credits := domain.PersonCredits{Person: *person}
+	r.store.do(func(t *tables) error {
+		credits.Cast = castCredits(t, ID)
+		credits.Crew = crewCredits(t, ID, "")
+		return nil
+	})
+
+	sort.SliceStable(credits.Cast, func(i, j int) bool {
+		return newerFirst(credits.Cast[i].Movie, credits.Cast[j].Movie)
+	})
+	sort.SliceStable(credits.Crew, func(i, j int) bool {
+		if credits.Crew[i].Movie.ID == credits.Crew[j].Movie.ID {
+			return credits.Crew[i].Department < credits.Crew[j].Department
+		}
+		return newerFirst(credits.Crew[i].Movie, credits.Crew[j].Movie)
+	})
+
+	return &credits, nil
+}
+
+// castCredits lists the acting credits of a person, in no particular order.
+func castCredits(t *tables, personID int) []domain.CastCredit {
+	var credits []domain.CastCredit
+	for _, row := range t.movieActors {
+		movie, ok := t.movies[row.MovieID]
+		if row.Credit.ID != personID || !ok {
+			continue
+		}
+		credits = append(credits, domain.CastCredit{
+			Movie:         domain.Movie{ID: movie.ID, Title: movie.Title, ReleaseDate: movie.ReleaseDate},
+			Role:          row.Credit.Role,
+			CharacterName: row.Credit.CharacterName,
+			CreditType:    row.Credit.CreditType,
+			BillingOrder:  row.Credit.BillingOrder,
+		})
+	}
+	return credits
+}
+
+// crewCredits lists the crew credits of a person, limited to one job when job
+// is not empty, in no particular order.
+func crewCredits(t *tables, personID int, job string) []domain.CrewCredit {
+	var credits []domain.CrewCredit
+	for _, row := range t.movieCrew {
+		movie, ok := t.movies[row.MovieID]
+		if row.PersonID != personID || !ok || (job != "" && row.Job != job) {
+			continue
+		}
+		credits = append(credits, domain.CrewCredit{
+			Movie:      domain.Movie{ID: movie.ID, Title: movie.Title, ReleaseDate: movie.ReleaseDate},
+			Job:        row.Job,
+			Department: department(t, row.Job),
+		})
+	}
+	return credits
+}
+
+func department(t *tables, job string) string {
+	for _, crewJob := range t.crewJobs {
+		if crewJob.Job == job {
+			return crewJob.Department
+		}
+	}
+	return ""
+}
+
+func newerFirst(a, b domain.Movie) bool {
+	if a.ReleaseDate.Equal(b.ReleaseDate) {
+		return a.ID < b.ID
+	}
+	return a.ReleaseDate.After(b.ReleaseDate)
+}
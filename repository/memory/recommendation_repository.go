@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+type recommendationRepository struct {
+	store *Store
+}
+
+func NewRecommendationMovieRepository(store *Store) repository.RecommendationMovieRepository {
+	return &recommendationRepository{store: store}
+}
+
+func (r *recommendationRepository) FindAll(ctx context.Context, tx repository.Querier) ([]*domain.RecommendationMovie, error) {
+	var recommendations []*domain.RecommendationMovie
+	r.store.do(func(t *tables) error {
+		for _, row := range t.recommendations {
+			movie, ok := t.movies[row.MovieID]
+			if !ok {
+				continue
+			}
+			recommendations = append(recommendations, &domain.RecommendationMovie{
+				ID:          movie.ID,
+				Title:       movie.Title,
+				ReleaseDate: movie.ReleaseDate,
+				Duration:    movie.Duration,
+				Plot:        movie.Plot,
+				PosterUrl:   movie.PosterUrl,
+				TrailerUrl:  movie.TrailerUrl,
+				Language:    movie.Language,
+				NationalID:  movie.NationalID,
+				CreatedAt:   movie.CreatedAt,
+				UpdatedAt:   movie.UpdatedAt,
+			})
+		}
+		return nil
+	})
+	return recommendations, nil
+}
+
+func (r *recommendationRepository) FindByID(ctx context.Context, db repository.Querier, movieID int) (*domain.RecommendationMovie, error) {
+	var found *domain.RecommendationMovie
+	r.store.do(func(t *tables) error {
+		for _, row := range t.recommendations {
+			if row.MovieID == movieID {
+				found = &domain.RecommendationMovie{ID: row.ID}
+			}
+		}
+		return nil
+	})
+	if found == nil {
+		return nil, errors.New("recommendation movie not found")
+	}
+	return found, nil
+}
+
+func (r *recommendationRepository) Save(ctx context.Context, tx repository.Querier, movieID int) error {
+	return r.store.do(func(t *tables) error {
+		t.recommendations = append(t.recommendations, recommendation{ID: t.nextID("recommendation"), MovieID: movieID})
+		return nil
+	})
+}
+
+func (r *recommendationRepository) Delete(ctx context.Context, tx repository.Querier, movieID int) error {
+	return r.store.do(func(t *tables) error {
+		rows := t.recommendations[:0]
+		for _, row := range t.recommendations {
+			if row.MovieID != movieID {
+				rows = append(rows, row)
+			}
+		}
+		t.recommendations = rows
+		return nil
+	})
+}
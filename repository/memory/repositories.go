@@ -0,0 +1,27 @@
+package memory
+
+import "github.com/dimassfeb-09/efilm-api.git/repository"
+
+// NewRepositories returns every repository backed by store.
+func NewRepositories(store *Store) repository.Repositories {
+	return repository.Repositories{
+		Auth:           NewAuthRepository(store),
+		LoginAttempt:   NewLoginAttemptRepository(store),
+		PasswordReset:  NewPasswordResetRepository(store),
+		APIKey:         NewAPIKeyRepository(store),
+		Identity:       NewIdentityRepository(store),
+		OIDCLogin:      NewOIDCLoginRepository(store),
+		Person:         NewPersonRepository(store),
+		Actor:          NewActorRepository(store),
+		Director:       NewDirectorRepository(store),
+		National:       NewNationalRepository(store),
+		Genre:          NewGenreRepository(store),
+		Movie:          NewMovieRepository(store),
+		MovieActor:     NewMovieActorRepository(store),
+		MovieDirector:  NewMovieDirectorRepository(store),
+		MovieCrew:      NewMovieCrewRepository(store),
+		MovieGenre:     NewMovieGenreRepository(store),
+		Recommendation: NewRecommendationMovieRepository(store),
+		Migration:      NewMigrationRepository(store),
+	}
+}
@@ -0,0 +1,249 @@
+// Package memory implements the repository interfaces on top of plain Go maps
+// so services can be tested without a Postgres instance.
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/migrations"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+var errNoSQL = errors.New("memory: the in-memory store does not run SQL")
+
+type movieGenre struct {
+	MovieID int
+	GenreID int
+}
+
+type movieActor struct {
+	MovieID int
+	Credit  domain.ActorMovie
+}
+
+type movieCrew struct {
+	MovieID  int
+	PersonID int
+	Job      string
+}
+
+type recommendation struct {
+	ID      int
+	MovieID int
+}
+
+type tables struct {
+	nationals       map[int]domain.National
+	people          map[int]domain.Person
+	movies          map[int]domain.Movie
+	genres          map[int]domain.Genre
+	users           map[int]domain.Auth
+	loginAttempts   map[string]domain.LoginAttempt
+	passwordResets  map[string]domain.PasswordReset
+	apiKeys         map[int]domain.APIKey
+	identities      map[identity]domain.Identity
+	oidcLogins      map[string]domain.OIDCLogin
+	movieGenres     []movieGenre
+	movieActors     []movieActor
+	movieCrew       []movieCrew
+	crewJobs        []domain.CrewJob
+	recommendations []recommendation
+	sequences       map[string]int
+	schemaVersion   int
+	schemaDirty     bool
+}
+
+func (t *tables) clone() *tables {
+	c := &tables{
+		nationals:       make(map[int]domain.National, len(t.nationals)),
+		people:          make(map[int]domain.Person, len(t.people)),
+		movies:          make(map[int]domain.Movie, len(t.movies)),
+		genres:          make(map[int]domain.Genre, len(t.genres)),
+		users:           make(map[int]domain.Auth, len(t.users)),
+		loginAttempts:   make(map[string]domain.LoginAttempt, len(t.loginAttempts)),
+		passwordResets:  make(map[string]domain.PasswordReset, len(t.passwordResets)),
+		apiKeys:         make(map[int]domain.APIKey, len(t.apiKeys)),
+		identities:      make(map[identity]domain.Identity, len(t.identities)),
+		oidcLogins:      make(map[string]domain.OIDCLogin, len(t.oidcLogins)),
+		movieGenres:     append([]movieGenre(nil), t.movieGenres...),
+		movieActors:     append([]movieActor(nil), t.movieActors...),
+		movieCrew:       append([]movieCrew(nil), t.movieCrew...),
+		crewJobs:        append([]domain.CrewJob(nil), t.crewJobs...),
+		recommendations: append([]recommendation(nil), t.recommendations...),
+		sequences:       make(map[string]int, len(t.sequences)),
+		schemaVersion:   t.schemaVersion,
+		schemaDirty:     t.schemaDirty,
+	}
+	for k, v := range t.nationals {
+		c.nationals[k] = v
+	}
+	for k, v := range t.people {
+		c.people[k] = v
+	}
+	for k, v := range t.movies {
+		c.movies[k] = v
+	}
+	for k, v := range t.genres {
+		c.genres[k] = v
+	}
+	for k, v := range t.users {
+		c.users[k] = v
+	}
+	for k, v := range t.loginAttempts {
+		c.loginAttempts[k] = v
+	}
+	for k, v := range t.passwordResets {
+		c.passwordResets[k] = v
+	}
+	for k, v := range t.apiKeys {
+		c.apiKeys[k] = v
+	}
+	for k, v := range t.identities {
+		c.identities[k] = v
+	}
+	for k, v := range t.oidcLogins {
+		c.oidcLogins[k] = v
+	}
+	for k, v := range t.sequences {
+		c.sequences[k] = v
+	}
+	return c
+}
+
+// Store holds the tables shared by the in-memory repositories. It implements
+// repository.DB, so it can be handed to services in place of a connection.
+//
+// Writes are applied immediately, since services read through the pool while
+// their transaction is still open. Rollback restores the tables as they were
+// when the transaction began.
+type Store struct {
+	mu   sync.Mutex
+	data *tables
+}
+
+// NewStore returns an empty store seeded with the crew jobs that the
+// movie_crew migration inserts, at the schema version of the newest migration.
+func NewStore() *Store {
+	latest, err := migrations.Latest()
+	if err != nil {
+		panic(err)
+	}
+
+	return &Store{data: &tables{
+		nationals:      map[int]domain.National{},
+		people:         map[int]domain.Person{},
+		movies:         map[int]domain.Movie{},
+		genres:         map[int]domain.Genre{},
+		users:          map[int]domain.Auth{},
+		loginAttempts:  map[string]domain.LoginAttempt{},
+		passwordResets: map[string]domain.PasswordReset{},
+		apiKeys:        map[int]domain.APIKey{},
+		identities:     map[identity]domain.Identity{},
+		oidcLogins:     map[string]domain.OIDCLogin{},
+		crewJobs: []domain.CrewJob{
+			{Job: "cinematographer", Department: "camera"},
+			{Job: "director", Department: "directing"},
+			{Job: "editor", Department: "editing"},
+			{Job: "producer", Department: "production"},
+			{Job: "composer", Department: "sound"},
+			{Job: "writer", Department: "writing"},
+		},
+		sequences:     map[string]int{},
+		schemaVersion: latest,
+	}}
+}
+
+// SetSchemaVersion changes the migration version the store reports.
+func (s *Store) SetSchemaVersion(version int, dirty bool) {
+	s.do(func(t *tables) error {
+		t.schemaVersion = version
+		t.schemaDirty = dirty
+		return nil
+	})
+}
+
+// do runs fn with the tables locked.
+func (s *Store) do(fn func(t *tables) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.data)
+}
+
+func (t *tables) nextID(table string) int {
+	t.sequences[table]++
+	return t.sequences[table]
+}
+
+func (s *Store) Begin() (repository.Tx, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &tx{store: s, snapshot: s.data.clone()}, nil
+}
+
+func (s *Store) BeginTx(ctx context.Context, opts *sql.TxOptions) (repository.Tx, error) {
+	return s.Begin()
+}
+
+// PingContext always succeeds; there is no connection to lose.
+func (s *Store) PingContext(ctx context.Context) error {
+	return nil
+}
+
+func (s *Store) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return nil, errNoSQL
+}
+
+func (s *Store) QueryContext(ctx context.Context, query string, args ...any) (repository.Rows, error) {
+	return nil, errNoSQL
+}
+
+func (s *Store) QueryRowContext(ctx context.Context, query string, args ...any) repository.Row {
+	panic(errNoSQL)
+}
+
+type tx struct {
+	store    *Store
+	snapshot *tables
+	done     bool
+}
+
+func (t *tx) Commit() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+	return nil
+}
+
+func (t *tx) Rollback() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+	t.store.data = t.snapshot
+	return nil
+}
+
+func (t *tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return nil, errNoSQL
+}
+
+func (t *tx) QueryContext(ctx context.Context, query string, args ...any) (repository.Rows, error) {
+	return nil, errNoSQL
+}
+
+func (t *tx) QueryRowContext(ctx context.Context, query string, args ...any) repository.Row {
+	panic(errNoSQL)
+}
+
+func now() time.Time {
+	return time.Now().UTC()
+}
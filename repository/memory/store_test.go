@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreRollback(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	genres := NewGenreRepository(store)
+
+	err := genres.Save(ctx, store, &domain.Genre{Name: "Drama"})
+	assert.NoError(t, err)
+
+	tx, err := store.Begin()
+	assert.NoError(t, err)
+
+	err = genres.Save(ctx, tx, &domain.Genre{Name: "Comedy"})
+	assert.NoError(t, err)
+	_, err = genres.FindByName(ctx, store, "Comedy")
+	assert.NoError(t, err, "writes are visible before commit")
+
+	assert.NoError(t, tx.Rollback())
+	_, err = genres.FindByName(ctx, store, "Comedy")
+	assert.EqualError(t, err, "genre with name Comedy not found")
+
+	all, err := genres.FindAll(ctx, store)
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// MigrationRepository reads the schema version golang-migrate records.
+type MigrationRepository interface {
+	Version(ctx context.Context, db Querier) (version int, dirty bool, err error)
+}
+
+type MigrationRepositoryImpl struct {
+}
+
+func NewMigrationRepository() MigrationRepository {
+	return &MigrationRepositoryImpl{}
+}
+
+func (repository *MigrationRepositoryImpl) Version(ctx context.Context, db Querier) (int, bool, error) {
+	var version int
+	var dirty bool
+	err := db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, errors.New("no migration has been applied")
+		}
+		return 0, false, err
+	}
+
+	return version, dirty, nil
+}
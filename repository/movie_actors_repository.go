@@ -9,11 +9,13 @@ import (
 )
 
 type MovieActorRepository interface {
-	Save(ctx context.Context, tx *sql.Tx, movieID, actorID int, role string) error
-	Update(ctx context.Context, tx *sql.Tx, movieID, actorID int, role string) error
-	Delete(ctx context.Context, tx *sql.Tx, actorID int) error
-	FindByID(ctx context.Context, db *sql.DB, movieID int) (*domain.MovieActor, error)
-	FindActorAtMovieExists(ctx context.Context, db *sql.DB, actorID int) error
+	Delete(ctx context.Context, tx Querier, actorID int) error
+	SaveCredit(ctx context.Context, tx Querier, movieID int, credit *domain.ActorMovie) error
+	UpdateCredit(ctx context.Context, tx Querier, movieID int, credit *domain.ActorMovie) error
+	DeleteFromMovie(ctx context.Context, tx Querier, movieID, actorID int) error
+	FindByID(ctx context.Context, db Querier, movieID int) (*domain.MovieActor, error)
+	FindActorAtMovieExists(ctx context.Context, db Querier, actorID int) error
+	FindMoviesByActor(ctx context.Context, db Querier, actorID int, ascending bool) ([]domain.CastCredit, error)
 }
 
 type MovieActorRepositoryaImpl struct {
@@ -23,43 +25,55 @@ func NewMovieActorRepository() MovieActorRepository {
 	return &MovieActorRepositoryaImpl{}
 }
 
-func (repository *MovieActorRepositoryaImpl) Save(ctx context.Context, tx *sql.Tx, movieID, actorID int, role string) error {
-	query := "INSERT INTO movie_actors (movie_id, actor_id, role) VALUES ($1, $2, $3)"
-	_, err := tx.ExecContext(ctx, query, movieID, actorID, role)
+func (repository *MovieActorRepositoryaImpl) Delete(ctx context.Context, tx Querier, actorID int) error {
+	query := "DELETE FROM movie_actors WHERE actor_id = $1"
+	_, err := tx.ExecContext(ctx, query, actorID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("data actors by ID at movie not found")
+		}
 		return err
 	}
 
 	return nil
 }
 
-func (repository *MovieActorRepositoryaImpl) Update(ctx context.Context, tx *sql.Tx, movieID, actorID int, role string) error {
-	query := "UPDATE movie_actors SET movie_id = $1, actor_id = $2, role = $3 WHERE actor_id = $4"
-	_, err := tx.ExecContext(ctx, query, movieID, actorID, role, actorID)
+func (repository *MovieActorRepositoryaImpl) SaveCredit(ctx context.Context, tx Querier, movieID int, credit *domain.ActorMovie) error {
+	query := `
+		INSERT INTO
+		    movie_actors (movie_id, actor_id, role, character_name, credit_type, billing_order, notes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := tx.ExecContext(ctx, query, movieID, credit.ID, credit.Role, credit.CharacterName, credit.CreditType, credit.BillingOrder, credit.Notes)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return errors.New("data actors by ID at movie not found")
-		}
+		return err
 	}
 
 	return nil
 }
 
-func (repository *MovieActorRepositoryaImpl) Delete(ctx context.Context, tx *sql.Tx, actorID int) error {
-	query := "DELETE FROM movie_actors WHERE actor_id = $1"
-	_, err := tx.ExecContext(ctx, query, actorID)
+func (repository *MovieActorRepositoryaImpl) UpdateCredit(ctx context.Context, tx Querier, movieID int, credit *domain.ActorMovie) error {
+	query := "UPDATE movie_actors SET role = $1, character_name = $2, credit_type = $3, billing_order = $4, notes = $5 WHERE movie_id = $6 AND actor_id = $7"
+	_, err := tx.ExecContext(ctx, query, credit.Role, credit.CharacterName, credit.CreditType, credit.BillingOrder, credit.Notes, movieID, credit.ID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return errors.New("data actors by ID at movie not found")
-		}
 		return err
 	}
 
 	return nil
 }
 
-func (repository *MovieActorRepositoryaImpl) FindByID(ctx context.Context, db *sql.DB, movieID int) (*domain.MovieActor, error) {
+func (repository *MovieActorRepositoryaImpl) DeleteFromMovie(ctx context.Context, tx Querier, movieID, actorID int) error {
+	query := "DELETE FROM movie_actors WHERE movie_id = $1 AND actor_id = $2"
+	_, err := tx.ExecContext(ctx, query, movieID, actorID)
+	if err != nil {
+		return errors.New("failed deleted actors at movie")
+	}
+
+	return nil
+}
+
+func (repository *MovieActorRepositoryaImpl) FindByID(ctx context.Context, db Querier, movieID int) (*domain.MovieActor, error) {
 
+	// Billed credits come first in billing order, unbilled ones (0) follow by name
 	query := `
 		SELECT
 			m.id AS movie_id,
@@ -68,11 +82,16 @@ func (repository *MovieActorRepositoryaImpl) FindByID(ctx context.Context, db *s
 			a.id AS actor_id,
 			a.name AS actor_name,
 			a.date_of_birth AS actor_dob,
-			ma.role AS actor_role
+			ma.role AS actor_role,
+			ma.character_name AS actor_character_name,
+			ma.credit_type AS actor_credit_type,
+			ma.billing_order AS actor_billing_order,
+			ma.notes AS actor_notes
 		FROM movies m
 		LEFT JOIN movie_actors ma ON m.id = ma.movie_id
-		LEFT JOIN actors a ON ma.actor_id = a.id
-		WHERE m.id = $1;
+		LEFT JOIN people a ON ma.actor_id = a.id
+		WHERE m.id = $1
+		ORDER BY ma.billing_order = 0, ma.billing_order, a.name;
 	`
 
 	rows, err := db.QueryContext(ctx, query, movieID)
@@ -89,7 +108,11 @@ func (repository *MovieActorRepositoryaImpl) FindByID(ctx context.Context, db *s
 		var actorID sql.NullInt64
 		var actorName sql.NullString
 		var actorDOB sql.NullTime
-		var actorRole string
+		var actorRole sql.NullString
+		var actorCharacterName sql.NullString
+		var actorCreditType sql.NullString
+		var actorBillingOrder sql.NullInt64
+		var actorNotes sql.NullString
 
 		rows.Scan(
 			&movieID,
@@ -99,6 +122,10 @@ func (repository *MovieActorRepositoryaImpl) FindByID(ctx context.Context, db *s
 			&actorName,
 			&actorDOB,
 			&actorRole,
+			&actorCharacterName,
+			&actorCreditType,
+			&actorBillingOrder,
+			&actorNotes,
 		)
 
 		actorMovie.Movie = domain.Movie{
@@ -109,10 +136,14 @@ func (repository *MovieActorRepositoryaImpl) FindByID(ctx context.Context, db *s
 
 		if actorID.Valid {
 			actor := domain.ActorMovie{
-				ID:          int(actorID.Int64),
-				Name:        actorName.String,
-				DateOfBirth: actorDOB.Time,
-				Role:        actorRole,
+				ID:            int(actorID.Int64),
+				Name:          actorName.String,
+				DateOfBirth:   actorDOB.Time,
+				Role:          actorRole.String,
+				CharacterName: actorCharacterName.String,
+				CreditType:    actorCreditType.String,
+				BillingOrder:  int(actorBillingOrder.Int64),
+				Notes:         actorNotes.String,
 			}
 			actorMovie.Actors = append(actorMovie.Actors, actor)
 		} else {
@@ -127,9 +158,9 @@ func (repository *MovieActorRepositoryaImpl) FindByID(ctx context.Context, db *s
 	return &actorMovie, nil
 }
 
-func (repository *MovieActorRepositoryaImpl) FindActorAtMovieExists(ctx context.Context, db *sql.DB, actorID int) error {
-	query := "SELECT id FROM movie_actors WHERE actor_id = $1"
-	err := db.QueryRowContext(ctx, query, actorID).Scan()
+func (repository *MovieActorRepositoryaImpl) FindActorAtMovieExists(ctx context.Context, db Querier, actorID int) error {
+	query := "SELECT actor_id FROM movie_actors WHERE actor_id = $1"
+	err := db.QueryRowContext(ctx, query, actorID).Scan(&actorID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return errors.New("actors ID at movie not found")
@@ -138,3 +169,52 @@ func (repository *MovieActorRepositoryaImpl) FindActorAtMovieExists(ctx context.
 	}
 	return nil
 }
+
+// FindMoviesByActor returns every movie the actor is cast in, ordered by release date.
+func (repository *MovieActorRepositoryaImpl) FindMoviesByActor(ctx context.Context, db Querier, actorID int, ascending bool) ([]domain.CastCredit, error) {
+	direction := "DESC"
+	if ascending {
+		direction = "ASC"
+	}
+
+	query := `
+		SELECT
+			m.id AS movie_id,
+			m.title AS title,
+			m.release_date AS release_date,
+			ma.role AS role,
+			ma.character_name AS character_name,
+			ma.credit_type AS credit_type,
+			ma.billing_order AS billing_order
+		FROM movie_actors ma
+		JOIN movies m ON ma.movie_id = m.id
+		WHERE ma.actor_id = $1
+		ORDER BY m.release_date ` + direction + `, m.id;
+	`
+
+	rows, err := db.QueryContext(ctx, query, actorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var credits []domain.CastCredit
+	for rows.Next() {
+		var credit domain.CastCredit
+		err := rows.Scan(
+			&credit.Movie.ID,
+			&credit.Movie.Title,
+			&credit.Movie.ReleaseDate,
+			&credit.Role,
+			&credit.CharacterName,
+			&credit.CreditType,
+			&credit.BillingOrder,
+		)
+		if err != nil {
+			return nil, err
+		}
+		credits = append(credits, credit)
+	}
+
+	return credits, nil
+}
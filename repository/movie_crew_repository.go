@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+)
+
+// ErrCrewNotFound is returned when a person does not have a job on a movie.
+var ErrCrewNotFound = errors.New("crew ID at movie not found")
+
+type MovieCrewRepository interface {
+	Save(ctx context.Context, tx Querier, movieID, personID int, job string) error
+	Delete(ctx context.Context, tx Querier, movieID, personID int, job string) error
+	DeleteByMovie(ctx context.Context, tx Querier, movieID int) error
+	FindByID(ctx context.Context, db Querier, movieID int, job string) (*domain.MovieCrew, error)
+	FindByPerson(ctx context.Context, db Querier, personID int) (*domain.PersonCrew, error)
+	FindCrewAtMovie(ctx context.Context, db Querier, movieID, personID int, job string) (exists bool, err error)
+	FindJob(ctx context.Context, db Querier, job string) (*domain.CrewJob, error)
+	FindAllJobs(ctx context.Context, db Querier) ([]*domain.CrewJob, error)
+}
+
+type MovieCrewRepositoryImpl struct {
+}
+
+func NewMovieCrewRepository() MovieCrewRepository {
+	return &MovieCrewRepositoryImpl{}
+}
+
+func (repository *MovieCrewRepositoryImpl) Save(ctx context.Context, tx Querier, movieID, personID int, job string) error {
+	query := "INSERT INTO movie_crew (movie_id, person_id, job) VALUES ($1, $2, $3)"
+	_, err := tx.ExecContext(ctx, query, movieID, personID, job)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *MovieCrewRepositoryImpl) Delete(ctx context.Context, tx Querier, movieID, personID int, job string) error {
+	query := "DELETE FROM movie_crew WHERE movie_id = $1 AND person_id = $2 AND job = $3"
+	_, err := tx.ExecContext(ctx, query, movieID, personID, job)
+	if err != nil {
+		return errors.New("failed deleted crew at movie")
+	}
+
+	return nil
+}
+
+func (repository *MovieCrewRepositoryImpl) DeleteByMovie(ctx context.Context, tx Querier, movieID int) error {
+	query := "DELETE FROM movie_crew WHERE movie_id = $1"
+	_, err := tx.ExecContext(ctx, query, movieID)
+	if err != nil {
+		return errors.New("failed deleted crew at movie")
+	}
+
+	return nil
+}
+
+// FindByID returns the crew of a movie, limited to one job when job is not empty.
+func (repository *MovieCrewRepositoryImpl) FindByID(ctx context.Context, db Querier, movieID int, job string) (*domain.MovieCrew, error) {
+
+	query := `
+		SELECT
+			m.id AS movie_id,
+			m.title AS title,
+			m.release_date AS release_date,
+			p.id AS person_id,
+			p.name AS person_name,
+			p.date_of_birth AS person_dob,
+			mc.job AS job,
+			cj.department AS department
+		FROM movies m
+		LEFT JOIN movie_crew mc ON m.id = mc.movie_id AND ($2 = '' OR mc.job = $2)
+		LEFT JOIN crew_jobs cj ON mc.job = cj.job
+		LEFT JOIN people p ON mc.person_id = p.id
+		WHERE m.id = $1
+		ORDER BY cj.department, p.name;
+	`
+
+	rows, err := db.QueryContext(ctx, query, movieID, job)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var movieCrew domain.MovieCrew
+	for rows.Next() {
+		var movieID int
+		var title string
+		var releaseDate time.Time
+		var personID sql.NullInt64
+		var personName sql.NullString
+		var personDOB sql.NullTime
+		var personJob sql.NullString
+		var department sql.NullString
+
+		err := rows.Scan(
+			&movieID,
+			&title,
+			&releaseDate,
+			&personID,
+			&personName,
+			&personDOB,
+			&personJob,
+			&department,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		movieCrew.Movie = domain.Movie{
+			ID:          movieID,
+			Title:       title,
+			ReleaseDate: releaseDate,
+		}
+
+		if personID.Valid {
+			movieCrew.Crew = append(movieCrew.Crew, domain.CrewMember{
+				ID:          int(personID.Int64),
+				Name:        personName.String,
+				DateOfBirth: personDOB.Time,
+				Job:         personJob.String,
+				Department:  department.String,
+			})
+		}
+	}
+
+	if movieCrew.Movie.ID == 0 {
+		return nil, errors.New("movie not found")
+	}
+
+	return &movieCrew, nil
+}
+
+func (repository *MovieCrewRepositoryImpl) FindByPerson(ctx context.Context, db Querier, personID int) (*domain.PersonCrew, error) {
+
+	query := `
+		SELECT
+			p.id AS person_id,
+			p.name AS person_name,
+			m.id AS movie_id,
+			m.title AS title,
+			m.release_date AS release_date,
+			mc.job AS job,
+			cj.department AS department
+		FROM people p
+		LEFT JOIN movie_crew mc ON p.id = mc.person_id
+		LEFT JOIN crew_jobs cj ON mc.job = cj.job
+		LEFT JOIN movies m ON mc.movie_id = m.id
+		WHERE p.id = $1
+		ORDER BY m.release_date DESC, cj.department;
+	`
+
+	rows, err := db.QueryContext(ctx, query, personID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var personCrew domain.PersonCrew
+	for rows.Next() {
+		var movieID sql.NullInt64
+		var title sql.NullString
+		var releaseDate sql.NullTime
+		var job sql.NullString
+		var department sql.NullString
+
+		err := rows.Scan(
+			&personCrew.Person.ID,
+			&personCrew.Person.Name,
+			&movieID,
+			&title,
+			&releaseDate,
+			&job,
+			&department,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if movieID.Valid {
+			personCrew.Credits = append(personCrew.Credits, domain.CrewCredit{
+				Movie: domain.Movie{
+					ID:          int(movieID.Int64),
+					Title:       title.String,
+					ReleaseDate: releaseDate.Time,
+				},
+				Job:        job.String,
+				Department: department.String,
+			})
+		}
+	}
+
+	if personCrew.Person.ID == 0 {
+		return nil, fmt.Errorf("person with ID %d not found", personID)
+	}
+
+	return &personCrew, nil
+}
+
+func (repository *MovieCrewRepositoryImpl) FindCrewAtMovie(ctx context.Context, db Querier, movieID, personID int, job string) (bool, error) {
+	query := "SELECT movie_id FROM movie_crew WHERE movie_id = $1 AND person_id = $2 AND job = $3"
+	err := db.QueryRowContext(ctx, query, movieID, personID, job).Scan(&movieID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, ErrCrewNotFound
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (repository *MovieCrewRepositoryImpl) FindJob(ctx context.Context, db Querier, job string) (*domain.CrewJob, error) {
+	var crewJob domain.CrewJob
+	err := db.QueryRowContext(ctx, "SELECT job, department FROM crew_jobs WHERE job = $1", job).Scan(&crewJob.Job, &crewJob.Department)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("job %s is not a known crew job", job)
+		}
+		return nil, err
+	}
+
+	return &crewJob, nil
+}
+
+func (repository *MovieCrewRepositoryImpl) FindAllJobs(ctx context.Context, db Querier) ([]*domain.CrewJob, error) {
+	rows, err := db.QueryContext(ctx, "SELECT job, department FROM crew_jobs ORDER BY department, job")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var crewJobs []*domain.CrewJob
+	for rows.Next() {
+		var crewJob domain.CrewJob
+		err := rows.Scan(&crewJob.Job, &crewJob.Department)
+		if err != nil {
+			return nil, err
+		}
+		crewJobs = append(crewJobs, &crewJob)
+	}
+
+	return crewJobs, nil
+}
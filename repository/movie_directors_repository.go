@@ -8,11 +8,14 @@ import (
 	"time"
 )
 
+// MovieDirectorRepository is the director-only view over movie_crew. Directors
+// are identified by their director ID, as in the directors view.
 type MovieDirectorRepository interface {
-	Save(ctx context.Context, tx *sql.Tx, movieID, directorID int) error
-	Delete(ctx context.Context, tx *sql.Tx, movieID int, directorID int) error
-	FindByID(ctx context.Context, db *sql.DB, movieID int) (*domain.MovieDirector, error)
-	FindDirectorAtMovie(ctx context.Context, db *sql.DB, movieID, directorID int) (exists bool, err error)
+	Save(ctx context.Context, tx Querier, movieID, directorID int) error
+	Delete(ctx context.Context, tx Querier, movieID int, directorID int) error
+	FindByID(ctx context.Context, db Querier, movieID int) (*domain.MovieDirector, error)
+	FindDirectorAtMovie(ctx context.Context, db Querier, movieID, directorID int) (exists bool, err error)
+	FindMoviesByDirector(ctx context.Context, db Querier, directorID int, ascending bool) ([]domain.Movie, error)
 }
 
 type MovieDirectorRepositoryaImpl struct {
@@ -22,9 +25,9 @@ func NewMovieDirectorRepository() MovieDirectorRepository {
 	return &MovieDirectorRepositoryaImpl{}
 }
 
-func (repository *MovieDirectorRepositoryaImpl) Save(ctx context.Context, tx *sql.Tx, movieID, directorID int) error {
-	query := "INSERT INTO movie_directors (movie_id, director_id) VALUES ($1, $2)"
-	_, err := tx.ExecContext(ctx, query, movieID, directorID)
+func (repository *MovieDirectorRepositoryaImpl) Save(ctx context.Context, tx Querier, movieID, directorID int) error {
+	query := "INSERT INTO movie_crew (movie_id, person_id, job) SELECT $1, id, $3 FROM people WHERE director_id = $2"
+	_, err := tx.ExecContext(ctx, query, movieID, directorID, domain.JobDirector)
 	if err != nil {
 		return err
 	}
@@ -32,9 +35,9 @@ func (repository *MovieDirectorRepositoryaImpl) Save(ctx context.Context, tx *sq
 	return nil
 }
 
-func (repository *MovieDirectorRepositoryaImpl) Delete(ctx context.Context, tx *sql.Tx, movieID int, directorID int) error {
-	query := "DELETE FROM movie_directors WHERE movie_id = $1 AND  director_id = $2"
-	_, err := tx.ExecContext(ctx, query, movieID, directorID)
+func (repository *MovieDirectorRepositoryaImpl) Delete(ctx context.Context, tx Querier, movieID int, directorID int) error {
+	query := "DELETE FROM movie_crew WHERE movie_id = $1 AND person_id = (SELECT id FROM people WHERE director_id = $2) AND job = $3"
+	_, err := tx.ExecContext(ctx, query, movieID, directorID, domain.JobDirector)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return errors.New("data directors by ID at movie not found")
@@ -45,23 +48,23 @@ func (repository *MovieDirectorRepositoryaImpl) Delete(ctx context.Context, tx *
 	return nil
 }
 
-func (repository *MovieDirectorRepositoryaImpl) FindByID(ctx context.Context, db *sql.DB, movieID int) (*domain.MovieDirector, error) {
+func (repository *MovieDirectorRepositoryaImpl) FindByID(ctx context.Context, db Querier, movieID int) (*domain.MovieDirector, error) {
 
 	query := `
 		SELECT
 			m.id AS movie_id,
 			m.title AS title,
 			m.release_date AS release_date,
-			d.id AS director_id,
-			d.name AS director_name,
-			d.date_of_birth AS director_dob
+			p.director_id AS director_id,
+			p.name AS director_name,
+			p.date_of_birth AS director_dob
 		FROM movies m
-		LEFT JOIN movie_directors md ON m.id = md.movie_id
-		LEFT JOIN directors d ON md.director_id = d.id
+		LEFT JOIN movie_crew mc ON m.id = mc.movie_id AND mc.job = $2
+		LEFT JOIN people p ON mc.person_id = p.id
 		WHERE m.id = $1;
 	`
 
-	rows, err := db.QueryContext(ctx, query, movieID)
+	rows, err := db.QueryContext(ctx, query, movieID, domain.JobDirector)
 	if err != nil {
 		return nil, err
 	}
@@ -111,9 +114,14 @@ func (repository *MovieDirectorRepositoryaImpl) FindByID(ctx context.Context, db
 	return &directorMovie, nil
 }
 
-func (repository *MovieDirectorRepositoryaImpl) FindDirectorAtMovie(ctx context.Context, db *sql.DB, movieID, directorID int) (bool, error) {
-	query := "SELECT movie_id FROM movie_directors WHERE movie_id = $1 AND director_id = $2"
-	err := db.QueryRowContext(ctx, query, movieID, directorID).Scan(&movieID)
+func (repository *MovieDirectorRepositoryaImpl) FindDirectorAtMovie(ctx context.Context, db Querier, movieID, directorID int) (bool, error) {
+	query := `
+		SELECT mc.movie_id
+		FROM movie_crew mc
+		JOIN people p ON mc.person_id = p.id
+		WHERE mc.movie_id = $1 AND p.director_id = $2 AND mc.job = $3
+	`
+	err := db.QueryRowContext(ctx, query, movieID, directorID, domain.JobDirector).Scan(&movieID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, errors.New("directors ID at movie not found")
@@ -122,3 +130,41 @@ func (repository *MovieDirectorRepositoryaImpl) FindDirectorAtMovie(ctx context.
 	}
 	return true, nil
 }
+
+// FindMoviesByDirector returns every movie the person directed, ordered by release date.
+func (repository *MovieDirectorRepositoryaImpl) FindMoviesByDirector(ctx context.Context, db Querier, directorID int, ascending bool) ([]domain.Movie, error) {
+	direction := "DESC"
+	if ascending {
+		direction = "ASC"
+	}
+
+	query := `
+		SELECT
+			m.id AS movie_id,
+			m.title AS title,
+			m.release_date AS release_date
+		FROM movie_crew mc
+		JOIN movies m ON mc.movie_id = m.id
+		JOIN people p ON mc.person_id = p.id
+		WHERE p.director_id = $1 AND mc.job = $2
+		ORDER BY m.release_date ` + direction + `, m.id;
+	`
+
+	rows, err := db.QueryContext(ctx, query, directorID, domain.JobDirector)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var movies []domain.Movie
+	for rows.Next() {
+		var movie domain.Movie
+		err := rows.Scan(&movie.ID, &movie.Title, &movie.ReleaseDate)
+		if err != nil {
+			return nil, err
+		}
+		movies = append(movies, movie)
+	}
+
+	return movies, nil
+}
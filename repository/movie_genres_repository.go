@@ -9,10 +9,10 @@ import (
 )
 
 type MovieGenreRepository interface {
-	Save(ctx context.Context, tx *sql.Tx, movieID, genreID int) error
-	Delete(ctx context.Context, tx *sql.Tx, movieID int, genreID int) error
-	FindByID(ctx context.Context, db *sql.DB, movieID int) (*domain.MovieGenre, error)
-	FindGenreExists(ctx context.Context, db *sql.DB, genreID int) error
+	Save(ctx context.Context, tx Querier, movieID, genreID int) error
+	Delete(ctx context.Context, tx Querier, movieID int, genreID int) error
+	FindByID(ctx context.Context, db Querier, movieID int) (*domain.MovieGenre, error)
+	FindGenreExists(ctx context.Context, db Querier, genreID int) error
 }
 
 type MovieGenreRepositoryaImpl struct {
@@ -22,7 +22,7 @@ func NewMovieGenreRepository() MovieGenreRepository {
 	return &MovieGenreRepositoryaImpl{}
 }
 
-func (repository *MovieGenreRepositoryaImpl) Save(ctx context.Context, tx *sql.Tx, movieID, genreID int) error {
+func (repository *MovieGenreRepositoryaImpl) Save(ctx context.Context, tx Querier, movieID, genreID int) error {
 	query := "INSERT INTO movie_genres (movie_id, genre_id) VALUES ($1, $2)"
 	_, err := tx.ExecContext(ctx, query, movieID, genreID)
 	if err != nil {
@@ -32,7 +32,7 @@ func (repository *MovieGenreRepositoryaImpl) Save(ctx context.Context, tx *sql.T
 	return nil
 }
 
-func (repository *MovieGenreRepositoryaImpl) Delete(ctx context.Context, tx *sql.Tx, movieID int, genreID int) error {
+func (repository *MovieGenreRepositoryaImpl) Delete(ctx context.Context, tx Querier, movieID int, genreID int) error {
 	query := "DELETE FROM movie_genres WHERE movie_id = $1 AND  genre_id = $2"
 	_, err := tx.ExecContext(ctx, query, movieID, genreID)
 	if err != nil {
@@ -45,7 +45,7 @@ func (repository *MovieGenreRepositoryaImpl) Delete(ctx context.Context, tx *sql
 	return nil
 }
 
-func (repository *MovieGenreRepositoryaImpl) FindByID(ctx context.Context, db *sql.DB, movieID int) (*domain.MovieGenre, error) {
+func (repository *MovieGenreRepositoryaImpl) FindByID(ctx context.Context, db Querier, movieID int) (*domain.MovieGenre, error) {
 
 	query := `
 		SELECT
@@ -104,7 +104,7 @@ func (repository *MovieGenreRepositoryaImpl) FindByID(ctx context.Context, db *s
 	return &genreMovie, nil
 }
 
-func (repository *MovieGenreRepositoryaImpl) FindGenreExists(ctx context.Context, db *sql.DB, genreID int) error {
+func (repository *MovieGenreRepositoryaImpl) FindGenreExists(ctx context.Context, db Querier, genreID int) error {
 	query := "SELECT genre_id FROM movie_genres WHERE genre_id = $1"
 	err := db.QueryRowContext(ctx, query, genreID).Scan(&genreID)
 	if err != nil {
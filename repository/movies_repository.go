@@ -9,13 +9,13 @@ import (
 )
 
 type MovieRepository interface {
-	Save(ctx context.Context, tx *sql.Tx, movie *domain.Movie) (movieID int, err error)
-	Update(ctx context.Context, tx *sql.Tx, movie *domain.Movie) error
-	Delete(ctx context.Context, tx *sql.Tx, ID int) error
-	FindByID(ctx context.Context, db *sql.DB, ID int) (*domain.Movie, error)
-	FindByTitle(ctx context.Context, db *sql.DB, name string) (*domain.Movie, error)
-	FindAll(ctx context.Context, db *sql.DB) ([]*domain.Movie, error)
-	FindAllMoviesByGenreID(ctx context.Context, db *sql.DB, genreID int) ([]*domain.Movie, error)
+	Save(ctx context.Context, tx Querier, movie *domain.Movie) (movieID int, err error)
+	Update(ctx context.Context, tx Querier, movie *domain.Movie) error
+	Delete(ctx context.Context, tx Querier, ID int) error
+	FindByID(ctx context.Context, db Querier, ID int) (*domain.Movie, error)
+	FindByTitle(ctx context.Context, db Querier, name string) (*domain.Movie, error)
+	FindAll(ctx context.Context, db Querier) ([]*domain.Movie, error)
+	FindAllMoviesByGenreID(ctx context.Context, db Querier, genreID int) ([]*domain.Movie, error)
 }
 
 type MovieRepositoryImpl struct {
@@ -25,7 +25,7 @@ func NewMovieRepository() MovieRepository {
 	return &MovieRepositoryImpl{}
 }
 
-func (a *MovieRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, movie *domain.Movie) (int, error) {
+func (a *MovieRepositoryImpl) Save(ctx context.Context, tx Querier, movie *domain.Movie) (int, error) {
 	var id int
 	query := `
 		INSERT INTO 
@@ -41,19 +41,18 @@ func (a *MovieRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, movie *domai
 	return id, nil
 }
 
-func (a *MovieRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, movie *domain.Movie) error {
+func (a *MovieRepositoryImpl) Update(ctx context.Context, tx Querier, movie *domain.Movie) error {
 	query := "UPDATE movies SET id = $1, title = $2, release_date = $3, duration = $4, plot = $5, poster_url = $6, trailer_url = $7, language = $8, nationality_id = $9, updated_at = CURRENT_TIMESTAMP WHERE id = $10"
 	_, err := tx.ExecContext(ctx, query, movie.ID, movie.Title, movie.ReleaseDate, movie.Duration, movie.Plot, movie.PosterUrl, movie.TrailerUrl, movie.Language, movie.NationalID, movie.ID)
 	if err != nil {
-		fmt.Println(err)
-		return errors.New("failed update data movie")
+		return fmt.Errorf("failed update data movie: %w", err)
 	}
 
 	return nil
 }
 
-func (a *MovieRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, ID int) error {
-	_, err := tx.Exec("DELETE FROM movies WHERE id = $1", ID)
+func (a *MovieRepositoryImpl) Delete(ctx context.Context, tx Querier, ID int) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM movies WHERE id = $1", ID)
 	if err != nil {
 		return err
 	}
@@ -61,7 +60,7 @@ func (a *MovieRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, ID int) er
 	return nil
 }
 
-func (a *MovieRepositoryImpl) FindByID(ctx context.Context, db *sql.DB, ID int) (*domain.Movie, error) {
+func (a *MovieRepositoryImpl) FindByID(ctx context.Context, db Querier, ID int) (*domain.Movie, error) {
 
 	query := `
 		SELECT 
@@ -94,10 +93,11 @@ func (a *MovieRepositoryImpl) FindByID(ctx context.Context, db *sql.DB, ID int)
 	return &movie, nil
 }
 
-func (a *MovieRepositoryImpl) FindByTitle(ctx context.Context, db *sql.DB, title string) (*domain.Movie, error) {
+func (a *MovieRepositoryImpl) FindByTitle(ctx context.Context, db Querier, title string) (*domain.Movie, error) {
 	var movie domain.Movie
-	err := db.QueryRow("SELECT * FROM movies WHERE title = $1", title).
-		Scan(&movie.ID, &movie.Title, &movie.ReleaseDate, &movie.Duration, &movie.Plot, &movie.PosterUrl, &movie.TrailerUrl, &movie.Language, &movie.CreatedAt, &movie.UpdatedAt)
+	query := "SELECT id, title, release_date, duration, plot, poster_url, trailer_url, language, nationality_id, created_at, updated_at FROM movies WHERE title = $1"
+	err := db.QueryRowContext(ctx, query, title).
+		Scan(&movie.ID, &movie.Title, &movie.ReleaseDate, &movie.Duration, &movie.Plot, &movie.PosterUrl, &movie.TrailerUrl, &movie.Language, &movie.NationalID, &movie.CreatedAt, &movie.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("movie with name %s not found", title)
@@ -108,7 +108,7 @@ func (a *MovieRepositoryImpl) FindByTitle(ctx context.Context, db *sql.DB, title
 	return &movie, nil
 }
 
-func (a *MovieRepositoryImpl) FindAll(ctx context.Context, db *sql.DB) ([]*domain.Movie, error) {
+func (a *MovieRepositoryImpl) FindAll(ctx context.Context, db Querier) ([]*domain.Movie, error) {
 	query := `
 		SELECT 
 		    m.id as id, 
@@ -154,7 +154,7 @@ func (a *MovieRepositoryImpl) FindAll(ctx context.Context, db *sql.DB) ([]*domai
 	return movies, nil
 }
 
-func (a *MovieRepositoryImpl) FindAllMoviesByGenreID(ctx context.Context, db *sql.DB, genreID int) ([]*domain.Movie, error) {
+func (a *MovieRepositoryImpl) FindAllMoviesByGenreID(ctx context.Context, db Querier, genreID int) ([]*domain.Movie, error) {
 	query := `SELECT movies.id as movie_id,
 		       movies.title as title,
 		       movies.release_date as release_date,
@@ -171,7 +171,7 @@ func (a *MovieRepositoryImpl) FindAllMoviesByGenreID(ctx context.Context, db *sq
 		    JOIN genres ON movie_genres.genre_id = genres.id 
 		WHERE genres.id = $1
 		`
-	rows, err := db.Query(query, genreID)
+	rows, err := db.QueryContext(ctx, query, genreID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("failed get data from database")
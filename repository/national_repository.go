@@ -9,12 +9,12 @@ import (
 )
 
 type NationalRepository interface {
-	Save(ctx context.Context, tx *sql.Tx, national *domain.National) error
-	Update(ctx context.Context, tx *sql.Tx, national *domain.National) error
-	Delete(ctx context.Context, tx *sql.Tx, ID int) error
-	FindAll(ctx context.Context, db *sql.DB) ([]*domain.National, error)
-	FindByName(ctx context.Context, db *sql.DB, name string) (*domain.National, error)
-	FindByID(ctx context.Context, db *sql.DB, ID int) (*domain.National, error)
+	Save(ctx context.Context, tx Querier, national *domain.National) error
+	Update(ctx context.Context, tx Querier, national *domain.National) error
+	Delete(ctx context.Context, tx Querier, ID int) error
+	FindAll(ctx context.Context, db Querier) ([]*domain.National, error)
+	FindByName(ctx context.Context, db Querier, name string) (*domain.National, error)
+	FindByID(ctx context.Context, db Querier, ID int) (*domain.National, error)
 }
 
 type NationalRepositoryaImpl struct {
@@ -24,7 +24,7 @@ func NewNationalRepository() NationalRepository {
 	return &NationalRepositoryaImpl{}
 }
 
-func (repository *NationalRepositoryaImpl) Save(ctx context.Context, tx *sql.Tx, national *domain.National) error {
+func (repository *NationalRepositoryaImpl) Save(ctx context.Context, tx Querier, national *domain.National) error {
 	_, err := tx.ExecContext(ctx, "INSERT INTO national (name) VALUES ($1)", national.Name)
 	if err != nil {
 		return err
@@ -33,7 +33,7 @@ func (repository *NationalRepositoryaImpl) Save(ctx context.Context, tx *sql.Tx,
 	return nil
 }
 
-func (repository *NationalRepositoryaImpl) Update(ctx context.Context, tx *sql.Tx, national *domain.National) error {
+func (repository *NationalRepositoryaImpl) Update(ctx context.Context, tx Querier, national *domain.National) error {
 	_, err := tx.ExecContext(ctx, "UPDATE national SET name = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2", national.Name, national.ID)
 	if err != nil {
 		return err
@@ -42,7 +42,7 @@ func (repository *NationalRepositoryaImpl) Update(ctx context.Context, tx *sql.T
 	return nil
 }
 
-func (repository *NationalRepositoryaImpl) Delete(ctx context.Context, tx *sql.Tx, ID int) error {
+func (repository *NationalRepositoryaImpl) Delete(ctx context.Context, tx Querier, ID int) error {
 	_, err := tx.ExecContext(ctx, "DELETE FROM national WHERE id = $1", ID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -54,7 +54,7 @@ func (repository *NationalRepositoryaImpl) Delete(ctx context.Context, tx *sql.T
 	return nil
 }
 
-func (repository *NationalRepositoryaImpl) FindAll(ctx context.Context, db *sql.DB) ([]*domain.National, error) {
+func (repository *NationalRepositoryaImpl) FindAll(ctx context.Context, db Querier) ([]*domain.National, error) {
 	rows, err := db.QueryContext(ctx, "SELECT * FROM national")
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -73,7 +73,7 @@ func (repository *NationalRepositoryaImpl) FindAll(ctx context.Context, db *sql.
 	return nationals, nil
 }
 
-func (repository *NationalRepositoryaImpl) FindByName(ctx context.Context, db *sql.DB, name string) (*domain.National, error) {
+func (repository *NationalRepositoryaImpl) FindByName(ctx context.Context, db Querier, name string) (*domain.National, error) {
 	var national domain.National
 	err := db.QueryRowContext(ctx, "SELECT * FROM national WHERE name = $1", name).Scan(&national.ID, &national.Name, &national.CreatedAt, &national.UpdatedAt)
 	if err != nil {
@@ -84,7 +84,7 @@ func (repository *NationalRepositoryaImpl) FindByName(ctx context.Context, db *s
 	}
 	return &national, nil
 }
-func (repository *NationalRepositoryaImpl) FindByID(ctx context.Context, db *sql.DB, ID int) (*domain.National, error) {
+func (repository *NationalRepositoryaImpl) FindByID(ctx context.Context, db Querier, ID int) (*domain.National, error) {
 	var national domain.National
 	err := db.QueryRowContext(ctx, "SELECT * FROM national WHERE id = $1", ID).Scan(&national.ID, &national.Name, &national.CreatedAt, &national.UpdatedAt)
 	if err != nil {
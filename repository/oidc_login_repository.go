@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+)
+
+type OIDCLoginRepository interface {
+	Save(ctx context.Context, tx Querier, login *domain.OIDCLogin) error
+	// Take deletes the login with the state hash and returns it, so that a
+	// state is only used once. It returns nil when there is none.
+	Take(ctx context.Context, tx Querier, stateHash string) (*domain.OIDCLogin, error)
+	// DeleteExpired drops the logins that expired before now.
+	DeleteExpired(ctx context.Context, tx Querier, now time.Time) error
+}
+
+type OIDCLoginRepositoryImpl struct {
+}
+
+func NewOIDCLoginRepository() OIDCLoginRepository {
+	return &OIDCLoginRepositoryImpl{}
+}
+
+func (repository *OIDCLoginRepositoryImpl) Save(ctx context.Context, tx Querier, login *domain.OIDCLogin) error {
+	query := "INSERT INTO oidc_logins (state_hash, code_verifier, nonce, link_user_id, expires_at) VALUES ($1, $2, $3, NULLIF($4, 0), $5)"
+	_, err := tx.ExecContext(ctx, query, login.StateHash, login.CodeVerifier, login.Nonce, login.LinkUserID, login.ExpiresAt)
+	return err
+}
+
+func (repository *OIDCLoginRepositoryImpl) Take(ctx context.Context, tx Querier, stateHash string) (*domain.OIDCLogin, error) {
+	query := "DELETE FROM oidc_logins WHERE state_hash = $1 RETURNING state_hash, code_verifier, nonce, COALESCE(link_user_id, 0), expires_at"
+
+	var login domain.OIDCLogin
+	err := tx.QueryRowContext(ctx, query, stateHash).Scan(&login.StateHash, &login.CodeVerifier, &login.Nonce, &login.LinkUserID, &login.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &login, nil
+}
+
+func (repository *OIDCLoginRepositoryImpl) DeleteExpired(ctx context.Context, tx Querier, now time.Time) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM oidc_logins WHERE expires_at < $1", now)
+	return err
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+)
+
+type PasswordResetRepository interface {
+	Save(ctx context.Context, tx Querier, reset *domain.PasswordReset) error
+	// FindByTokenHash returns nil when no reset has the token hash.
+	FindByTokenHash(ctx context.Context, db Querier, tokenHash string) (*domain.PasswordReset, error)
+	// DeleteByUserID drops every pending reset of a user.
+	DeleteByUserID(ctx context.Context, tx Querier, userID int) error
+}
+
+type PasswordResetRepositoryImpl struct {
+}
+
+func NewPasswordResetRepository() PasswordResetRepository {
+	return &PasswordResetRepositoryImpl{}
+}
+
+func (repository *PasswordResetRepositoryImpl) Save(ctx context.Context, tx Querier, reset *domain.PasswordReset) error {
+	query := "INSERT INTO password_resets (token_hash, user_id, expires_at) VALUES ($1, $2, $3)"
+	_, err := tx.ExecContext(ctx, query, reset.TokenHash, reset.UserID, reset.ExpiresAt)
+	return err
+}
+
+func (repository *PasswordResetRepositoryImpl) FindByTokenHash(ctx context.Context, db Querier, tokenHash string) (*domain.PasswordReset, error) {
+	query := "SELECT token_hash, user_id, expires_at FROM password_resets WHERE token_hash = $1"
+
+	var reset domain.PasswordReset
+	err := db.QueryRowContext(ctx, query, tokenHash).Scan(&reset.TokenHash, &reset.UserID, &reset.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &reset, nil
+}
+
+func (repository *PasswordResetRepositoryImpl) DeleteByUserID(ctx context.Context, tx Querier, userID int) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM password_resets WHERE user_id = $1", userID)
+	return err
+}
@@ -0,0 +1,355 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+)
+
+// ErrPersonHasCredits is returned when deleting an actor who is still credited
+// in a movie.
+var ErrPersonHasCredits = errors.New("this person is still credited as an actor in a movie")
+
+type PersonRepository interface {
+	Save(ctx context.Context, tx Querier, person *domain.Person) error
+	Update(ctx context.Context, tx Querier, person *domain.Person) error
+	Delete(ctx context.Context, tx Querier, ID int) error
+	MarkAs(ctx context.Context, tx Querier, ID int, view string) error
+	FindByID(ctx context.Context, db Querier, ID int) (*domain.Person, error)
+	FindByName(ctx context.Context, db Querier, name string) (*domain.Person, error)
+	FindByNational(ctx context.Context, db Querier, nationalityID int) ([]*domain.Person, error)
+	FindAll(ctx context.Context, db Querier) ([]*domain.Person, error)
+	FindCredits(ctx context.Context, db Querier, ID int) (*domain.PersonCredits, error)
+}
+
+// PersonRepositoryImpl reads and writes the people table. With a view set it
+// only sees the people flagged for that view, which is how the actors and
+// directors repositories are built. The directors view goes by director_id
+// instead of id, so directors kept their IDs when people were merged.
+type PersonRepositoryImpl struct {
+	view string
+}
+
+func NewPersonRepository() PersonRepository {
+	return &PersonRepositoryImpl{}
+}
+
+const personColumns = "id, name, date_of_birth, nationality_id, is_actor, is_director, COALESCE(director_id, 0), created_at, updated_at"
+
+// viewColumn returns the people column that flags membership of view.
+func viewColumn(view string) string {
+	switch view {
+	case domain.PersonViewActor:
+		return "is_actor"
+	case domain.PersonViewDirector:
+		return "is_director"
+	default:
+		return ""
+	}
+}
+
+// markColumns returns the assignments that flag a person for view, giving new
+// directors their director ID.
+func markColumns(view string) string {
+	column := viewColumn(view)
+	if view == domain.PersonViewDirector {
+		return column + " = TRUE, director_id = COALESCE(director_id, nextval('people_director_id_seq'))"
+	}
+	return column + " = TRUE"
+}
+
+// idColumn is the column the repository's view identifies people by.
+func (repository *PersonRepositoryImpl) idColumn() string {
+	if repository.view == domain.PersonViewDirector {
+		return "director_id"
+	}
+	return "id"
+}
+
+func (repository *PersonRepositoryImpl) where(condition string) string {
+	if column := viewColumn(repository.view); column != "" {
+		return fmt.Sprintf("WHERE %s AND %s", column, condition)
+	}
+	return "WHERE " + condition
+}
+
+func (repository *PersonRepositoryImpl) notFound() string {
+	if repository.view != "" {
+		return repository.view
+	}
+	return "person"
+}
+
+func (repository *PersonRepositoryImpl) scanPerson(row interface{ Scan(dest ...any) error }, person *domain.Person) error {
+	err := row.Scan(
+		&person.ID,
+		&person.Name,
+		&person.DateOfBirth,
+		&person.NationalityID,
+		&person.IsActor,
+		&person.IsDirector,
+		&person.DirectorID,
+		&person.CreatedAt,
+		&person.UpdatedAt,
+	)
+	if err == nil && repository.view == domain.PersonViewDirector {
+		person.ID = person.DirectorID
+	}
+	return err
+}
+
+// Save adds the person to the repository's view. Someone with the same name
+// and date of birth who is already known from another view is reused instead
+// of being created twice.
+func (repository *PersonRepositoryImpl) Save(ctx context.Context, tx Querier, person *domain.Person) error {
+	if repository.view != "" {
+		query := fmt.Sprintf("UPDATE people SET %s, updated_at = CURRENT_TIMESTAMP WHERE name = $1 AND date_of_birth = $2", markColumns(repository.view))
+		result, err := tx.ExecContext(ctx, query, person.Name, person.DateOfBirth)
+		if err != nil {
+			return err
+		}
+
+		if affected, _ := result.RowsAffected(); affected > 0 {
+			return nil
+		}
+	}
+
+	query := `
+		INSERT INTO people (name, date_of_birth, nationality_id, is_actor, is_director, director_id)
+		VALUES ($1, $2, $3, $4, $5, CASE WHEN $5 THEN nextval('people_director_id_seq') END)
+	`
+	_, err := tx.ExecContext(ctx, query,
+		person.Name,
+		person.DateOfBirth,
+		person.NationalityID,
+		person.IsActor || repository.view == domain.PersonViewActor,
+		person.IsDirector || repository.view == domain.PersonViewDirector)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *PersonRepositoryImpl) Update(ctx context.Context, tx Querier, person *domain.Person) error {
+	query := "UPDATE people SET name = $1, date_of_birth = $2, nationality_id = $3, updated_at = $4 WHERE " + repository.idColumn() + " = $5"
+	_, err := tx.ExecContext(ctx, query, person.Name, person.DateOfBirth, person.NationalityID, person.UpdatedAt, person.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the person from the repository's view, and only deletes the
+// person itself once they are no longer listed in any view. Someone who still
+// has acting credits stays an actor; their credits have to be removed first.
+func (repository *PersonRepositoryImpl) Delete(ctx context.Context, tx Querier, ID int) error {
+	if repository.view != domain.PersonViewDirector {
+		var credited bool
+		err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM movie_actors WHERE actor_id = $1)", ID).Scan(&credited)
+		if err != nil {
+			return err
+		}
+		if credited {
+			return ErrPersonHasCredits
+		}
+	}
+
+	if column := viewColumn(repository.view); column != "" {
+		query := fmt.Sprintf("UPDATE people SET %s = FALSE, updated_at = CURRENT_TIMESTAMP WHERE %s = $1", column, repository.idColumn())
+		_, err := tx.ExecContext(ctx, query, ID)
+		if err != nil {
+			return err
+		}
+
+		query = fmt.Sprintf("DELETE FROM people WHERE %s = $1 AND NOT is_actor AND NOT is_director", repository.idColumn())
+		_, err = tx.ExecContext(ctx, query, ID)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, "DELETE FROM people WHERE id = $1", ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *PersonRepositoryImpl) MarkAs(ctx context.Context, tx Querier, ID int, view string) error {
+	column := viewColumn(view)
+	if column == "" {
+		return fmt.Errorf("unknown people view %s", view)
+	}
+
+	query := fmt.Sprintf("UPDATE people SET %s WHERE id = $1 AND NOT %s", markColumns(view), column)
+	_, err := tx.ExecContext(ctx, query, ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repository *PersonRepositoryImpl) FindByID(ctx context.Context, db Querier, ID int) (*domain.Person, error) {
+	var person domain.Person
+	query := "SELECT " + personColumns + " FROM people " + repository.where(repository.idColumn()+" = $1")
+	err := repository.scanPerson(db.QueryRowContext(ctx, query, ID), &person)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("sorry, %s id not found", repository.notFound())
+		}
+		return nil, err
+	}
+
+	return &person, nil
+}
+
+func (repository *PersonRepositoryImpl) FindByName(ctx context.Context, db Querier, name string) (*domain.Person, error) {
+	var person domain.Person
+	query := "SELECT " + personColumns + " FROM people " + repository.where("name = $1")
+	err := repository.scanPerson(db.QueryRowContext(ctx, query, name), &person)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s with name %s not found", repository.notFound(), name)
+		}
+		return nil, err
+	}
+
+	return &person, nil
+}
+
+func (repository *PersonRepositoryImpl) FindByNational(ctx context.Context, db Querier, nationalityID int) ([]*domain.Person, error) {
+	query := "SELECT " + personColumns + " FROM people " + repository.where("nationality_id = $1") + " ORDER BY " + repository.idColumn()
+	rows, err := db.QueryContext(ctx, query, nationalityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var people []*domain.Person
+	for rows.Next() {
+		var person domain.Person
+		err := repository.scanPerson(rows, &person)
+		if err != nil {
+			return nil, err
+		}
+		people = append(people, &person)
+	}
+
+	return people, nil
+}
+
+func (repository *PersonRepositoryImpl) FindAll(ctx context.Context, db Querier) ([]*domain.Person, error) {
+	query := "SELECT " + personColumns + " FROM people " + repository.where("TRUE") + " ORDER BY " + repository.idColumn()
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var people []*domain.Person
+	for rows.Next() {
+		var person domain.Person
+		err := repository.scanPerson(rows, &person)
+		if err != nil {
+			return nil, err
+		}
+		people = append(people, &person)
+	}
+
+	return people, nil
+}
+
+// FindCredits returns the person together with every acting and crew credit
+// they have, newest movies first.
+func (repository *PersonRepositoryImpl) FindCredits(ctx context.Context, db Querier, ID int) (*domain.PersonCredits, error) {
+	person, err := repository.FindByID(ctx, db, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	credits := domain.PersonCredits{Person: *person}
+
+	castQuery := `
+		SELECT
+			m.id AS movie_id,
+			m.title AS title,
+			m.release_date AS release_date,
+			ma.role AS role,
+			ma.character_name AS character_name,
+			ma.credit_type AS credit_type,
+			ma.billing_order AS billing_order
+		FROM movie_actors ma
+		JOIN movies m ON ma.movie_id = m.id
+		WHERE ma.actor_id = $1
+		ORDER BY m.release_date DESC, m.id;
+	`
+
+	rows, err := db.QueryContext(ctx, castQuery, ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var credit domain.CastCredit
+		err := rows.Scan(
+			&credit.Movie.ID,
+			&credit.Movie.Title,
+			&credit.Movie.ReleaseDate,
+			&credit.Role,
+			&credit.CharacterName,
+			&credit.CreditType,
+			&credit.BillingOrder,
+		)
+		if err != nil {
+			return nil, err
+		}
+		credits.Cast = append(credits.Cast, credit)
+	}
+
+	crewQuery := `
+		SELECT
+			m.id AS movie_id,
+			m.title AS title,
+			m.release_date AS release_date,
+			mc.job AS job,
+			cj.department AS department
+		FROM movie_crew mc
+		JOIN movies m ON mc.movie_id = m.id
+		JOIN crew_jobs cj ON mc.job = cj.job
+		WHERE mc.person_id = $1
+		ORDER BY m.release_date DESC, m.id, cj.department;
+	`
+
+	crewRows, err := db.QueryContext(ctx, crewQuery, ID)
+	if err != nil {
+		return nil, err
+	}
+	defer crewRows.Close()
+
+	for crewRows.Next() {
+		var credit domain.CrewCredit
+		err := crewRows.Scan(
+			&credit.Movie.ID,
+			&credit.Movie.Title,
+			&credit.Movie.ReleaseDate,
+			&credit.Job,
+			&credit.Department,
+		)
+		if err != nil {
+			return nil, err
+		}
+		credits.Crew = append(credits.Crew, credit)
+	}
+
+	return &credits, nil
+}
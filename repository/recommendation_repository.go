@@ -3,15 +3,15 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
-	"log"
 )
 
 type RecommendationMovieRepository interface {
-	FindAll(ctx context.Context, tx *sql.DB) ([]*domain.RecommendationMovie, error)
-	FindByID(ctx context.Context, tx *sql.DB, movieID int) (*domain.RecommendationMovie, error)
-	Save(ctx context.Context, tx *sql.Tx, movieID int) error
-	Delete(ctx context.Context, tx *sql.Tx, movieID int) error
+	FindAll(ctx context.Context, tx Querier) ([]*domain.RecommendationMovie, error)
+	FindByID(ctx context.Context, tx Querier, movieID int) (*domain.RecommendationMovie, error)
+	Save(ctx context.Context, tx Querier, movieID int) error
+	Delete(ctx context.Context, tx Querier, movieID int) error
 }
 
 type RecommendationRepositoryImpl struct {
@@ -21,7 +21,7 @@ func NewRecommendationMovieRepositoryImpl() RecommendationMovieRepository {
 	return &RecommendationRepositoryImpl{}
 }
 
-func (repository *RecommendationRepositoryImpl) FindAll(ctx context.Context, tx *sql.DB) ([]*domain.RecommendationMovie, error) {
+func (repository *RecommendationRepositoryImpl) FindAll(ctx context.Context, tx Querier) ([]*domain.RecommendationMovie, error) {
 	query := `
 			SELECT movie_id AS id,
 				   title,
@@ -66,7 +66,7 @@ func (repository *RecommendationRepositoryImpl) FindAll(ctx context.Context, tx
 	return recommendations, nil
 }
 
-func (repository *RecommendationRepositoryImpl) FindByID(ctx context.Context, db *sql.DB, movieID int) (*domain.RecommendationMovie, error) {
+func (repository *RecommendationRepositoryImpl) FindByID(ctx context.Context, db Querier, movieID int) (*domain.RecommendationMovie, error) {
 	query := "SELECT id FROM recommendation WHERE movie_id = $1"
 	row := db.QueryRowContext(ctx, query, movieID)
 	if row.Err() != nil {
@@ -76,14 +76,16 @@ func (repository *RecommendationRepositoryImpl) FindByID(ctx context.Context, db
 	var recommendation domain.RecommendationMovie
 	err := row.Scan(&recommendation.ID)
 	if err != nil {
-		log.Fatal(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("recommendation movie not found")
+		}
 		return nil, err
 	}
 
 	return &recommendation, nil
 }
 
-func (repository *RecommendationRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, movieID int) error {
+func (repository *RecommendationRepositoryImpl) Save(ctx context.Context, tx Querier, movieID int) error {
 	query := "INSERT INTO recommendation (movie_id) VALUES ($1)"
 	_, err := tx.ExecContext(ctx, query, movieID)
 	if err != nil {
@@ -93,7 +95,7 @@ func (repository *RecommendationRepositoryImpl) Save(ctx context.Context, tx *sq
 	return nil
 }
 
-func (repository *RecommendationRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, movieID int) error {
+func (repository *RecommendationRepositoryImpl) Delete(ctx context.Context, tx Querier, movieID int) error {
 	query := "DELETE FROM recommendation WHERE movie_id = $1"
 	_, err := tx.ExecContext(ctx, query, movieID)
 	if err != nil {
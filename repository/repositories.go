@@ -0,0 +1,47 @@
+package repository
+
+// Repositories is the full set of repositories the routes are built from.
+type Repositories struct {
+	Auth           AuthRepository
+	LoginAttempt   LoginAttemptRepository
+	PasswordReset  PasswordResetRepository
+	APIKey         APIKeyRepository
+	Identity       IdentityRepository
+	OIDCLogin      OIDCLoginRepository
+	Person         PersonRepository
+	Actor          ActorRepository
+	Director       DirectorRepository
+	National       NationalRepository
+	Genre          GenreRepository
+	Movie          MovieRepository
+	MovieActor     MovieActorRepository
+	MovieDirector  MovieDirectorRepository
+	MovieCrew      MovieCrewRepository
+	MovieGenre     MovieGenreRepository
+	Recommendation RecommendationMovieRepository
+	Migration      MigrationRepository
+}
+
+// NewRepositories returns the Postgres implementation of every repository.
+func NewRepositories() Repositories {
+	return Repositories{
+		Auth:           NewAuthRepository(),
+		LoginAttempt:   NewLoginAttemptRepository(),
+		PasswordReset:  NewPasswordResetRepository(),
+		APIKey:         NewAPIKeyRepository(),
+		Identity:       NewIdentityRepository(),
+		OIDCLogin:      NewOIDCLoginRepository(),
+		Person:         NewPersonRepository(),
+		Actor:          NewActorRepository(),
+		Director:       NewDirectorRepository(),
+		National:       NewNationalRepository(),
+		Genre:          NewGenreRepository(),
+		Movie:          NewMovieRepository(),
+		MovieActor:     NewMovieActorRepository(),
+		MovieDirector:  NewMovieDirectorRepository(),
+		MovieCrew:      NewMovieCrewRepository(),
+		MovieGenre:     NewMovieGenreRepository(),
+		Recommendation: NewRecommendationMovieRepositoryImpl(),
+		Migration:      NewMigrationRepository(),
+	}
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/dimassfeb-09/efilm-api.git/repository")
+
+// Row counts are recorded under these keys; the semantic conventions have no
+// name for them yet.
+const (
+	rowsAffectedKey = attribute.Key("db.rows_affected")
+	rowsReturnedKey = attribute.Key("db.rows_returned")
+)
+
+// startStatement starts the span of one SQL statement, named after its
+// operation as the semantic conventions suggest, e.g. "SELECT".
+func startStatement(ctx context.Context, query string) (context.Context, trace.Span) {
+	operation, _, _ := strings.Cut(strings.TrimSpace(query), " ")
+	operation = strings.ToUpper(operation)
+
+	return tracer.Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBStatement(query),
+			semconv.DBOperation(operation),
+		),
+	)
+}
+
+// failStatement marks the span of a statement as failed and logs err with
+// the logger of the request, as a failing statement is the fault of the
+// server, not of the client, whatever the response ends up saying.
+func failStatement(ctx context.Context, span trace.Span, query string, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+
+	logging.FromContext(ctx).ErrorContext(ctx, "database statement failed",
+		slog.String("statement", query),
+		slog.String("error", err.Error()),
+	)
+}
+
+func tracedExec(ctx context.Context, exec func(context.Context, string, ...any) (sql.Result, error), query string, args []any) (sql.Result, error) {
+	ctx, span := startStatement(ctx, query)
+	defer span.End()
+
+	result, err := exec(ctx, query, args...)
+	if err != nil {
+		failStatement(ctx, span, query, err)
+		return nil, err
+	}
+
+	if affected, err := result.RowsAffected(); err == nil {
+		span.SetAttributes(rowsAffectedKey.Int64(affected))
+	}
+	return result, nil
+}
+
+func tracedQuery(ctx context.Context, query func(context.Context, string, ...any) (*sql.Rows, error), statement string, args []any) (Rows, error) {
+	ctx, span := startStatement(ctx, statement)
+
+	rows, err := query(ctx, statement, args...)
+	if err != nil {
+		failStatement(ctx, span, statement, err)
+		span.End()
+		return nil, err
+	}
+
+	return &tracedRows{Rows: rows, ctx: ctx, query: statement, span: span}, nil
+}
+
+func tracedQueryRow(ctx context.Context, queryRow func(context.Context, string, ...any) *sql.Row, query string, args []any) Row {
+	ctx, span := startStatement(ctx, query)
+
+	row := queryRow(ctx, query, args...)
+	if err := row.Err(); err != nil {
+		failStatement(ctx, span, query, err)
+		span.End()
+		return row
+	}
+
+	return &tracedRow{Row: row, ctx: ctx, query: query, span: span}
+}
+
+// tracedRows ends the span of its query once the rows are read or closed,
+// counting the rows read.
+type tracedRows struct {
+	*sql.Rows
+	ctx   context.Context
+	query string
+	span  trace.Span
+	count int64
+	ended bool
+}
+
+func (rows *tracedRows) Next() bool {
+	if rows.Rows.Next() {
+		rows.count++
+		return true
+	}
+	rows.end()
+	return false
+}
+
+func (rows *tracedRows) Close() error {
+	err := rows.Rows.Close()
+	rows.end()
+	return err
+}
+
+func (rows *tracedRows) end() {
+	if rows.ended {
+		return
+	}
+	rows.ended = true
+
+	if err := rows.Rows.Err(); err != nil {
+		failStatement(rows.ctx, rows.span, rows.query, err)
+	}
+	rows.span.SetAttributes(rowsReturnedKey.Int64(rows.count))
+	rows.span.End()
+}
+
+// tracedRow ends the span of its query once the row is scanned.
+type tracedRow struct {
+	*sql.Row
+	ctx   context.Context
+	query string
+	span  trace.Span
+}
+
+func (row *tracedRow) Scan(dest ...any) error {
+	defer row.span.End()
+
+	err := row.Row.Scan(dest...)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		row.span.SetAttributes(rowsReturnedKey.Int64(0))
+	case err != nil:
+		failStatement(row.ctx, row.span, row.query, err)
+	default:
+		row.span.SetAttributes(rowsReturnedKey.Int64(1))
+	}
+	return err
+}
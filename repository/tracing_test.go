@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestStatementsAreTraced(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := NewDB(conn)
+	ctx := context.Background()
+
+	mock.ExpectExec("UPDATE genres").WillReturnResult(sqlmock.NewResult(0, 3))
+	_, err = db.ExecContext(ctx, "UPDATE genres SET name = $1", "Drama")
+	assert.NoError(t, err)
+
+	mock.ExpectQuery("SELECT id, name FROM genres").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Drama").AddRow(2, "Horror"))
+	rows, err := db.QueryContext(ctx, "SELECT id, name FROM genres")
+	assert.NoError(t, err)
+	for rows.Next() {
+	}
+	assert.NoError(t, rows.Close())
+
+	mock.ExpectQuery("SELECT id FROM genres").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	var id int
+	err = db.QueryRowContext(ctx, "SELECT id FROM genres WHERE id = $1", 9).Scan(&id)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 3)
+	assert.Equal(t, "UPDATE", spans[0].Name)
+	assert.Contains(t, spans[0].Attributes, attribute.String("db.statement", "UPDATE genres SET name = $1"))
+	assert.Contains(t, spans[0].Attributes, rowsAffectedKey.Int64(3))
+	assert.Equal(t, "SELECT", spans[1].Name)
+	assert.Contains(t, spans[1].Attributes, rowsReturnedKey.Int64(2))
+	assert.Contains(t, spans[2].Attributes, rowsReturnedKey.Int64(0))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFailedStatementsAreLogged(t *testing.T) {
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := NewDB(conn)
+
+	var out bytes.Buffer
+	ctx := logging.WithLogger(logging.WithRequestID(context.Background(), "abc"), logging.New(&out, "info"))
+
+	mock.ExpectExec("INSERT INTO genres").WillReturnError(errors.New("connection reset"))
+	_, err = db.ExecContext(ctx, "INSERT INTO genres (name) VALUES ($1)", "Drama")
+	assert.Error(t, err)
+
+	// A missing row is an answer, not a failure.
+	mock.ExpectQuery("SELECT id FROM genres").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	var id int
+	err = db.QueryRowContext(ctx, "SELECT id FROM genres WHERE id = $1", 9).Scan(&id)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	var line map[string]any
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &line))
+	assert.Equal(t, "database statement failed", line["msg"])
+	assert.Equal(t, "INSERT INTO genres (name) VALUES ($1)", line["statement"])
+	assert.Equal(t, "connection reset", line["error"])
+	assert.Equal(t, "abc", line["request_id"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,7 +2,6 @@ package services
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"time"
 
@@ -20,18 +19,31 @@ type ActorService interface {
 	FindByName(ctx context.Context, name string) (*web.ActorModelResponse, error)
 	FindByNational(ctx context.Context, nationalityID int) ([]*web.ActorModelResponse, error)
 	FindAll(ctx context.Context) ([]*web.ActorModelResponse, error)
+	FindMovies(ctx context.Context, ID int, ascending bool) (*web.ActorMoviesModelResponse, error)
 }
 
 type ActorServiceImpl struct {
-	DB              *sql.DB
-	ActorRepository repository.ActorRepository
+	DB                   repository.DB
+	ActorRepository      repository.ActorRepository
+	movieActorRepository repository.MovieActorRepository
 }
 
-func NewActorService(DB *sql.DB, actorRepository repository.ActorRepository) ActorService {
-	return &ActorServiceImpl{DB: DB, ActorRepository: actorRepository}
+func NewActorService(
+	DB repository.DB,
+	actorRepository repository.ActorRepository,
+	movieActorRepository repository.MovieActorRepository,
+) ActorService {
+	return &ActorServiceImpl{
+		DB:                   DB,
+		ActorRepository:      actorRepository,
+		movieActorRepository: movieActorRepository,
+	}
 }
 
 func (a *ActorServiceImpl) Save(ctx context.Context, r *web.ActorModelRequest) error {
+	ctx, span := tracer.Start(ctx, "ActorService.Save")
+	defer span.End()
+
 	tx, err := a.DB.Begin()
 	if err != nil {
 		return err
@@ -58,6 +70,9 @@ func (a *ActorServiceImpl) Save(ctx context.Context, r *web.ActorModelRequest) e
 }
 
 func (a *ActorServiceImpl) Update(ctx context.Context, r *web.ActorModelRequest) error {
+	ctx, span := tracer.Start(ctx, "ActorService.Update")
+	defer span.End()
+
 	tx, err := a.DB.Begin()
 	if err != nil {
 		return err
@@ -83,12 +98,15 @@ func (a *ActorServiceImpl) Update(ctx context.Context, r *web.ActorModelRequest)
 	})
 }
 
-func (a *ActorServiceImpl) Delete(ctx context.Context, ID int) error {
+func (a *ActorServiceImpl) Delete(ctx context.Context, ID int) (err error) {
+	ctx, span := tracer.Start(ctx, "ActorService.Delete")
+	defer span.End()
+
 	tx, err := a.DB.Begin()
 	if err != nil {
 		return err
 	}
-	defer helpers.RollbackOrCommit(ctx, tx)
+	defer helpers.CommitOrRollback(tx, &err)
 
 	_, err = a.FindByID(ctx, ID)
 	if err != nil {
@@ -104,6 +122,9 @@ func (a *ActorServiceImpl) Delete(ctx context.Context, ID int) error {
 }
 
 func (a *ActorServiceImpl) FindByID(ctx context.Context, ID int) (*web.ActorModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "ActorService.FindByID")
+	defer span.End()
+
 	result, err := a.ActorRepository.FindByID(ctx, a.DB, ID)
 	if err != nil {
 		return nil, err
@@ -120,6 +141,8 @@ func (a *ActorServiceImpl) FindByID(ctx context.Context, ID int) (*web.ActorMode
 }
 
 func (a *ActorServiceImpl) FindByName(ctx context.Context, name string) (*web.ActorModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "ActorService.FindByName")
+	defer span.End()
 
 	result, err := a.ActorRepository.FindByName(ctx, a.DB, name)
 	if err != nil {
@@ -137,6 +160,9 @@ func (a *ActorServiceImpl) FindByName(ctx context.Context, name string) (*web.Ac
 }
 
 func (a *ActorServiceImpl) FindByNational(ctx context.Context, nationalityID int) ([]*web.ActorModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "ActorService.FindByNational")
+	defer span.End()
+
 	results, err := a.ActorRepository.FindByNational(ctx, a.DB, nationalityID)
 	if err != nil {
 		return nil, err
@@ -159,6 +185,9 @@ func (a *ActorServiceImpl) FindByNational(ctx context.Context, nationalityID int
 }
 
 func (a *ActorServiceImpl) FindAll(ctx context.Context) ([]*web.ActorModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "ActorService.FindAll")
+	defer span.End()
+
 	results, err := a.ActorRepository.FindAll(ctx, a.DB)
 	if err != nil {
 		return nil, err
@@ -180,3 +209,38 @@ func (a *ActorServiceImpl) FindAll(ctx context.Context) ([]*web.ActorModelRespon
 
 	return responses, nil
 }
+
+func (a *ActorServiceImpl) FindMovies(ctx context.Context, ID int, ascending bool) (*web.ActorMoviesModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "ActorService.FindMovies")
+	defer span.End()
+
+	actor, err := a.ActorRepository.FindByID(ctx, a.DB, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	credits, err := a.movieActorRepository.FindMoviesByActor(ctx, a.DB, ID, ascending)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &web.ActorMoviesModelResponse{
+		ActorID: actor.ID,
+		Name:    actor.Name,
+		Movies:  []web.ActorMovieModelResponse{},
+	}
+
+	for _, credit := range credits {
+		response.Movies = append(response.Movies, web.ActorMovieModelResponse{
+			MovieID:       credit.Movie.ID,
+			Title:         credit.Movie.Title,
+			ReleaseDate:   credit.Movie.ReleaseDate,
+			Role:          credit.Role,
+			CharacterName: credit.CharacterName,
+			CreditType:    credit.CreditType,
+			BillingOrder:  credit.BillingOrder,
+		})
+	}
+
+	return response, nil
+}
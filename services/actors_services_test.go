@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+	"github.com/dimassfeb-09/efilm-api.git/repository/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorServiceSaveDuplicate(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	service := &ActorServiceImpl{
+		DB:                   store,
+		ActorRepository:      memory.NewActorRepository(store),
+		movieActorRepository: memory.NewMovieActorRepository(store),
+	}
+
+	r := &web.ActorModelRequest{Name: "Lee Ji Eun", DateOfBirth: "1993-05-16", NationalityID: 1}
+	err := service.Save(ctx, r)
+	assert.NoError(t, err)
+
+	err = service.Save(ctx, r)
+	assert.EqualError(t, err, "actors name already exists")
+}
+
+func TestActorServiceSaveReusesDirector(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	people := memory.NewPersonRepository(store)
+	directors := &DirectorServiceImpl{DB: store, DirectorRepository: memory.NewDirectorRepository(store)}
+	actors := &ActorServiceImpl{DB: store, ActorRepository: memory.NewActorRepository(store)}
+
+	r := &web.ActorModelRequest{Name: "Bradley Cooper", DateOfBirth: "1975-01-05", NationalityID: 1}
+	err := directors.Save(ctx, (*web.DirectorModelRequest)(r))
+	assert.NoError(t, err)
+	err = actors.Save(ctx, r)
+	assert.NoError(t, err)
+
+	all, err := people.FindAll(ctx, store)
+	assert.NoError(t, err)
+	if assert.Len(t, all, 1) {
+		assert.True(t, all[0].IsActor)
+		assert.True(t, all[0].IsDirector)
+	}
+}
+
+func TestActorServiceDeleteCredited(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	people := memory.NewPersonRepository(store)
+	actors := &ActorServiceImpl{DB: store, ActorRepository: memory.NewActorRepository(store)}
+	directors := &DirectorServiceImpl{DB: store, DirectorRepository: memory.NewDirectorRepository(store)}
+	credits := memory.NewMovieActorRepository(store)
+
+	r := &web.ActorModelRequest{Name: "Bradley Cooper", DateOfBirth: "1975-01-05", NationalityID: 1}
+	assert.NoError(t, actors.Save(ctx, r))
+	assert.NoError(t, directors.Save(ctx, (*web.DirectorModelRequest)(r)))
+	assert.NoError(t, credits.SaveCredit(ctx, store, 1, &domain.ActorMovie{ID: 1, CharacterName: "Jackson Maine"}))
+
+	err := actors.Delete(ctx, 1)
+	assert.ErrorIs(t, err, repository.ErrPersonHasCredits)
+	err = (&PersonServiceImpl{DB: store, PersonRepository: people}).Delete(ctx, 1)
+	assert.ErrorIs(t, err, repository.ErrPersonHasCredits)
+
+	// Directing is not what the credit is for.
+	assert.NoError(t, directors.Delete(ctx, 1))
+	person, err := people.FindByID(ctx, store, 1)
+	assert.NoError(t, err)
+	assert.True(t, person.IsActor)
+	assert.False(t, person.IsDirector)
+
+	assert.NoError(t, credits.Delete(ctx, store, 1))
+	assert.NoError(t, actors.Delete(ctx, 1))
+	_, err = people.FindByID(ctx, store, 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+var ErrInvalidAPIKey = errors.New("API key is invalid or expired")
+
+const (
+	// apiKeyPrefix starts every key, so leaked keys are easy to search for.
+	apiKeyPrefix = "efk_"
+	// apiKeyShownLength is how much of a key is kept in the clear to tell
+	// keys apart.
+	apiKeyShownLength = len(apiKeyPrefix) + 8
+	// lastUsedResolution keeps busy keys from writing on every request.
+	lastUsedResolution = time.Minute
+)
+
+type APIKeyService interface {
+	// Create returns the new key, which cannot be read back afterwards.
+	Create(ctx context.Context, createdBy int, r *web.APIKeyRequest) (*web.APIKeyCreatedResponse, error)
+	FindAll(ctx context.Context) ([]*web.APIKeyResponse, error)
+	Delete(ctx context.Context, ID int) error
+	// Authenticate returns the API key key stands for, and records its use.
+	Authenticate(ctx context.Context, key string) (*domain.APIKey, error)
+}
+
+type APIKeyServiceImpl struct {
+	DB               repository.DB
+	APIKeyRepository repository.APIKeyRepository
+	now              func() time.Time
+}
+
+func NewAPIKeyService(DB repository.DB, apiKeyRepository repository.APIKeyRepository) APIKeyService {
+	return &APIKeyServiceImpl{DB: DB, APIKeyRepository: apiKeyRepository, now: time.Now}
+}
+
+func (service *APIKeyServiceImpl) Create(ctx context.Context, createdBy int, r *web.APIKeyRequest) (*web.APIKeyCreatedResponse, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.Create")
+	defer span.End()
+
+	now := service.now()
+	var expiresAt time.Time
+	if r.ExpiresAt != nil {
+		if !r.ExpiresAt.After(now) {
+			return nil, errors.New("expires_at must be in the future")
+		}
+		expiresAt = *r.ExpiresAt
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+	key := apiKeyPrefix + secret
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helpers.RollbackOrCommit(ctx, tx)
+
+	ID, err := service.APIKeyRepository.Save(ctx, tx, &domain.APIKey{
+		Name:      r.Name,
+		Prefix:    key[:apiKeyShownLength],
+		KeyHash:   helpers.HashSecret(key),
+		Scope:     r.Scope,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &web.APIKeyCreatedResponse{ID: ID, Key: key}, nil
+}
+
+func (service *APIKeyServiceImpl) FindAll(ctx context.Context) ([]*web.APIKeyResponse, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.FindAll")
+	defer span.End()
+
+	keys, err := service.APIKeyRepository.FindAll(ctx, service.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []*web.APIKeyResponse{}
+	for _, key := range keys {
+		response := &web.APIKeyResponse{
+			ID:        key.ID,
+			Name:      key.Name,
+			Prefix:    key.Prefix,
+			Scope:     key.Scope,
+			CreatedBy: key.CreatedBy,
+			CreatedAt: key.CreatedAt,
+		}
+		if !key.ExpiresAt.IsZero() {
+			response.ExpiresAt = &key.ExpiresAt
+		}
+		if !key.LastUsedAt.IsZero() {
+			response.LastUsedAt = &key.LastUsedAt
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+func (service *APIKeyServiceImpl) Delete(ctx context.Context, ID int) error {
+	ctx, span := tracer.Start(ctx, "APIKeyService.Delete")
+	defer span.End()
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helpers.RollbackOrCommit(ctx, tx)
+
+	_, err = service.APIKeyRepository.FindByID(ctx, tx, ID)
+	if err != nil {
+		return err
+	}
+
+	return service.APIKeyRepository.Delete(ctx, tx, ID)
+}
+
+func (service *APIKeyServiceImpl) Authenticate(ctx context.Context, key string) (*domain.APIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.Authenticate")
+	defer span.End()
+
+	apiKey, err := service.APIKeyRepository.FindByHash(ctx, service.DB, helpers.HashSecret(key))
+	if err != nil {
+		return nil, err
+	}
+
+	now := service.now()
+	if apiKey == nil || (!apiKey.ExpiresAt.IsZero() && !now.Before(apiKey.ExpiresAt)) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if now.Sub(apiKey.LastUsedAt) >= lastUsedResolution {
+		err = service.APIKeyRepository.UpdateLastUsed(ctx, service.DB, apiKey.ID, now)
+		if err != nil {
+			return nil, err
+		}
+		apiKey.LastUsedAt = now
+	}
+
+	return apiKey, nil
+}
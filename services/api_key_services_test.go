@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/repository/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyServiceAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	service := NewAPIKeyService(store, memory.NewAPIKeyRepository(store)).(*APIKeyServiceImpl)
+	now := time.Unix(1000, 0)
+	service.now = func() time.Time { return now }
+
+	expiresAt := now.Add(time.Hour)
+	created, err := service.Create(ctx, 1, &web.APIKeyRequest{Name: "recommendation-service", Scope: domain.APIKeyRead, ExpiresAt: &expiresAt})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Key, "efk_"))
+
+	// Only the prefix of the key is stored in the clear.
+	keys, err := service.FindAll(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, keys, 1) {
+		assert.Equal(t, created.Key[:12], keys[0].Prefix)
+		assert.Nil(t, keys[0].LastUsedAt)
+	}
+
+	key, err := service.Authenticate(ctx, created.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.APIKeyRead, key.Scope)
+	assert.Equal(t, now, key.LastUsedAt)
+
+	// Use is recorded at most once a minute.
+	now = now.Add(30 * time.Second)
+	key, err = service.Authenticate(ctx, created.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(1000, 0), key.LastUsedAt)
+
+	_, err = service.Authenticate(ctx, created.Key+"x")
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	now = expiresAt
+	_, err = service.Authenticate(ctx, created.Key)
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	_, err = service.Create(ctx, 1, &web.APIKeyRequest{Name: "late", Scope: domain.APIKeyRead, ExpiresAt: &expiresAt})
+	assert.EqualError(t, err, "expires_at must be in the future")
+
+	assert.NoError(t, service.Delete(ctx, created.ID))
+	assert.EqualError(t, service.Delete(ctx, created.ID), "API key with ID 1 not found")
+}
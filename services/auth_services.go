@@ -2,33 +2,111 @@ package services
 
 import (
 	"context"
-	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/dimassfeb-09/efilm-api.git/config"
 	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
 	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/metrics"
+	"github.com/dimassfeb-09/efilm-api.git/notify"
 	"github.com/dimassfeb-09/efilm-api.git/repository"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// ErrInvalidCredentials is the only error a failed login reports, whether
+// the username exists or not.
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+// LockedError is returned for logins tried while their username or client IP
+// is locked out after too many failures.
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (err *LockedError) Error() string {
+	return "too many failed logins, try again later"
+}
+
+var (
+	ErrUserDisabled      = errors.New("this account is disabled")
+	ErrWrongPassword     = errors.New("current password is wrong")
+	ErrInvalidResetToken = errors.New("reset token is invalid or expired")
+	ErrNoNotifier        = errors.New("password resets are off, as no notifier is configured")
+)
+
+const (
+	userKeyPrefix = "user:"
+	ipKeyPrefix   = "ip:"
+)
+
 type AuthService interface {
 	Register(ctx context.Context, r *web.AuthModelRequest) error
-	Login(ctx context.Context, r *web.AuthModelRequest) (token string, err error)
+	Login(ctx context.Context, r *web.AuthModelRequest, clientIP string) (token string, err error)
+	// Unlock forgets the failed logins of a username, a client IP or both.
+	Unlock(ctx context.Context, r *web.UnlockLoginRequest) error
+	ChangePassword(ctx context.Context, userID int, r *web.ChangePasswordRequest) error
+	// ForgotPassword sends a reset token to the user, and pretends to for
+	// unknown usernames. Without a notifier it and ResetPassword return
+	// ErrNoNotifier.
+	ForgotPassword(ctx context.Context, r *web.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, r *web.ResetPasswordRequest) error
 	findByUsername(ctx context.Context, name string) (*domain.Auth, error)
 	findByID(ctx context.Context, ID int) (*domain.Auth, error)
 }
 
 type AuthServiceImpl struct {
-	DB             *sql.DB
-	AuthRepository repository.AuthRepository
+	DB                      repository.DB
+	AuthRepository          repository.AuthRepository
+	LoginAttemptRepository  repository.LoginAttemptRepository
+	PasswordResetRepository repository.PasswordResetRepository
+	keys                    *helpers.JWTKeys
+	login                   config.Login
+	password                config.Password
+	notifier                notify.Notifier
+	metrics                 *metrics.Metrics
+	now                     func() time.Time
+
+	// dummyHash stands in for the hash of unknown usernames, so that they
+	// take as long to reject as wrong passwords.
+	dummyHash []byte
 }
 
-func NewAuthService(DB *sql.DB, authRepository repository.AuthRepository) AuthService {
-	return &AuthServiceImpl{DB: DB, AuthRepository: authRepository}
+func NewAuthService(
+	DB repository.DB,
+	authRepository repository.AuthRepository,
+	loginAttemptRepository repository.LoginAttemptRepository,
+	passwordResetRepository repository.PasswordResetRepository,
+	keys *helpers.JWTKeys,
+	login config.Login,
+	password config.Password,
+	notifier notify.Notifier,
+	metrics *metrics.Metrics,
+) AuthService {
+	dummyHash, _ := bcrypt.GenerateFromPassword([]byte("not a password"), password.HashCost)
+
+	return &AuthServiceImpl{
+		DB:                      DB,
+		AuthRepository:          authRepository,
+		LoginAttemptRepository:  loginAttemptRepository,
+		PasswordResetRepository: passwordResetRepository,
+		keys:                    keys,
+		login:                   login,
+		password:                password,
+		notifier:                notifier,
+		metrics:                 metrics,
+		now:                     time.Now,
+		dummyHash:               dummyHash,
+	}
 }
 
 func (a *AuthServiceImpl) Register(ctx context.Context, r *web.AuthModelRequest) error {
+	ctx, span := tracer.Start(ctx, "AuthService.Register")
+	defer span.End()
+
 	tx, err := a.DB.Begin()
 	if err != nil {
 		return err
@@ -40,38 +118,333 @@ func (a *AuthServiceImpl) Register(ctx context.Context, r *web.AuthModelRequest)
 		return errors.New("username already exists")
 	}
 
-	hashPassword, err := bcrypt.GenerateFromPassword([]byte(r.Password), bcrypt.MinCost)
+	if err := checkPassword(a.password, r.Username, r.Password); err != nil {
+		return err
+	}
+
+	hashPassword, err := bcrypt.GenerateFromPassword([]byte(r.Password), a.password.HashCost)
 	if err != nil {
 		return err
 	}
 
-	return a.AuthRepository.Register(ctx, tx, &domain.Auth{
+	err = a.AuthRepository.Register(ctx, tx, &domain.Auth{
 		Username: r.Username,
 		Password: string(hashPassword),
 	})
+	if err != nil {
+		return err
+	}
+
+	a.metrics.Registered()
+	return nil
 }
 
-func (a *AuthServiceImpl) Login(ctx context.Context, r *web.AuthModelRequest) (string, error) {
-	tx, err := a.DB.Begin()
+// Login checks the password of a user, refusing without checking it while the
+// username or the client IP is locked out. Every failure counts against both.
+// No transaction is open while the password is compared, which is slow on
+// purpose.
+func (a *AuthServiceImpl) Login(ctx context.Context, r *web.AuthModelRequest, clientIP string) (string, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.Login")
+	defer span.End()
+
+	now := a.now()
+	keys := []string{userKeyPrefix + r.Username, ipKeyPrefix + clientIP}
+	lockedUntil, err := a.findLockout(ctx, now, keys...)
 	if err != nil {
 		return "", err
 	}
-	if err != nil {
+	if lockedUntil.After(now) {
+		a.metrics.LoginFailed()
+		return "", &LockedError{RetryAfter: lockedUntil.Sub(now)}
+	}
+
+	result, err := a.AuthRepository.Login(ctx, a.DB, r.Username)
+	if err != nil && !errors.Is(err, repository.ErrUsernameNotFound) {
 		return "", err
 	}
-	defer helpers.RollbackOrCommit(ctx, tx)
 
-	result, err := a.AuthRepository.Login(ctx, tx, r.Username)
+	hash := a.dummyHash
+	if result != nil {
+		hash = []byte(result.Password)
+	}
+	err = bcrypt.CompareHashAndPassword(hash, []byte(r.Password))
+	if err != nil || result == nil {
+		a.metrics.LoginFailed()
+		if err := a.fail(ctx, now, keys...); err != nil {
+			return "", err
+		}
+		return "", ErrInvalidCredentials
+	}
+
+	// Only told to those who know the password.
+	if !result.DisabledAt.IsZero() {
+		return "", ErrUserDisabled
+	}
+
+	// Hashes made before the cost was changed are redone while the password
+	// is at hand.
+	var rehashed []byte
+	if cost, err := bcrypt.Cost(hash); err == nil && cost != a.password.HashCost {
+		rehashed, err = bcrypt.GenerateFromPassword([]byte(r.Password), a.password.HashCost)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = a.succeed(ctx, result, rehashed)
 	if err != nil {
 		return "", err
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(result.Password), []byte(r.Password))
+	a.metrics.LoggedIn()
+	return helpers.GenerateTokenJWT(a.keys, result.ID, result.Username, result.Role)
+}
+
+// fail counts a failed login against every key, and locks out those that had
+// too many.
+func (a *AuthServiceImpl) fail(ctx context.Context, now time.Time, keys ...string) (err error) {
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helpers.CommitOrRollback(tx, &err)
+
+	for _, key := range keys {
+		var attempt *domain.LoginAttempt
+		attempt, err = a.LoginAttemptRepository.Fail(ctx, tx, key, now, now.Add(-a.login.LockoutDuration))
+		if err != nil {
+			return err
+		}
+		if until := a.lockedUntil(attempt, now); until.After(attempt.LockedUntil) {
+			err = a.LoginAttemptRepository.Lock(ctx, tx, key, until)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// succeed forgets the failed logins of user, and stores their password hash
+// again when it was rehashed.
+func (a *AuthServiceImpl) succeed(ctx context.Context, user *domain.Auth, rehashed []byte) (err error) {
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helpers.CommitOrRollback(tx, &err)
+
+	// The client IP keeps its failures, or logging into an account of one's
+	// own would let an attacker guess on.
+	err = a.LoginAttemptRepository.Delete(ctx, tx, userKeyPrefix+user.Username)
+	if err != nil {
+		return err
+	}
+
+	if rehashed != nil {
+		return a.AuthRepository.UpdatePassword(ctx, tx, user.ID, string(rehashed))
+	}
+	return nil
+}
+
+func (a *AuthServiceImpl) Unlock(ctx context.Context, r *web.UnlockLoginRequest) (err error) {
+	ctx, span := tracer.Start(ctx, "AuthService.Unlock")
+	defer span.End()
+
+	var keys []string
+	if r.Username != "" {
+		keys = append(keys, userKeyPrefix+r.Username)
+	}
+	if r.IP != "" {
+		keys = append(keys, ipKeyPrefix+r.IP)
+	}
+	if len(keys) == 0 {
+		return errors.New("username or ip is required")
+	}
+
+	tx, err := a.DB.Begin()
 	if err != nil {
-		return "", errors.New("terjadi kesalahan, email/password salah")
+		return err
+	}
+	defer helpers.CommitOrRollback(tx, &err)
+
+	for _, key := range keys {
+		err = a.LoginAttemptRepository.Delete(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *AuthServiceImpl) ChangePassword(ctx context.Context, userID int, r *web.ChangePasswordRequest) (err error) {
+	ctx, span := tracer.Start(ctx, "AuthService.ChangePassword")
+	defer span.End()
+
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helpers.CommitOrRollback(tx, &err)
+
+	user, err := a.AuthRepository.FindByID(ctx, tx, userID)
+	if err != nil {
+		return err
 	}
 
-	return helpers.GenerateTokenJWT(result.ID, result.Username, result.Role)
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(r.CurrentPassword))
+	if err != nil {
+		return ErrWrongPassword
+	}
+
+	return a.setPassword(ctx, tx, user, r.NewPassword)
+}
+
+func (a *AuthServiceImpl) ForgotPassword(ctx context.Context, r *web.ForgotPasswordRequest) (err error) {
+	ctx, span := tracer.Start(ctx, "AuthService.ForgotPassword")
+	defer span.End()
+
+	if a.notifier == nil {
+		return ErrNoNotifier
+	}
+
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helpers.CommitOrRollback(tx, &err)
+
+	user, err := a.AuthRepository.Login(ctx, tx, r.Username)
+	if errors.Is(err, repository.ErrUsernameNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	token, err := newSecret()
+	if err != nil {
+		return err
+	}
+
+	err = a.PasswordResetRepository.Save(ctx, tx, &domain.PasswordReset{
+		TokenHash: helpers.HashSecret(token),
+		UserID:    user.ID,
+		ExpiresAt: a.now().Add(a.password.ResetTokenTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = a.notifier.Notify(ctx, notify.Message{
+		To:      user.Username,
+		Subject: "Reset your eFilm password",
+		Body:    fmt.Sprintf("Send this token with your new password to /api/auth/password/reset within %s: %s", a.password.ResetTokenTTL, token),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send the reset token: %w", err)
+	}
+
+	return nil
+}
+
+func (a *AuthServiceImpl) ResetPassword(ctx context.Context, r *web.ResetPasswordRequest) (err error) {
+	ctx, span := tracer.Start(ctx, "AuthService.ResetPassword")
+	defer span.End()
+
+	if a.notifier == nil {
+		return ErrNoNotifier
+	}
+
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helpers.CommitOrRollback(tx, &err)
+
+	reset, err := a.PasswordResetRepository.FindByTokenHash(ctx, tx, helpers.HashSecret(r.Token))
+	if err != nil {
+		return err
+	}
+	if reset == nil || !a.now().Before(reset.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	user, err := a.AuthRepository.FindByID(ctx, tx, reset.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = a.setPassword(ctx, tx, user, r.Password)
+	if err != nil {
+		return err
+	}
+
+	// Whoever reset the password holds the account, so its lockout goes too.
+	return a.LoginAttemptRepository.Delete(ctx, tx, userKeyPrefix+user.Username)
+}
+
+// setPassword checks and hashes the new password of user, and cancels the
+// pending resets of user.
+func (a *AuthServiceImpl) setPassword(ctx context.Context, tx repository.Querier, user *domain.Auth, password string) error {
+	if err := checkPassword(a.password, user.Username, password); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), a.password.HashCost)
+	if err != nil {
+		return err
+	}
+
+	err = a.AuthRepository.UpdatePassword(ctx, tx, user.ID, string(hash))
+	if err != nil {
+		return err
+	}
+
+	return a.PasswordResetRepository.DeleteByUserID(ctx, tx, user.ID)
+}
+
+// findLockout returns until when logins for any of keys are refused.
+func (a *AuthServiceImpl) findLockout(ctx context.Context, now time.Time, keys ...string) (time.Time, error) {
+	var lockedUntil time.Time
+	for _, key := range keys {
+		attempt, err := a.LoginAttemptRepository.FindByKey(ctx, a.DB, key)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if attempt == nil || now.Sub(attempt.LastFailureAt) >= a.login.LockoutDuration {
+			continue
+		}
+		if attempt.LockedUntil.After(lockedUntil) {
+			lockedUntil = attempt.LockedUntil
+		}
+	}
+	return lockedUntil, nil
+}
+
+// lockedUntil makes a username wait a second after its free failures, twice
+// as long after every further one, and locks it out after the maximum. Client
+// IPs, which may be shared, are only locked out.
+func (a *AuthServiceImpl) lockedUntil(attempt *domain.LoginAttempt, now time.Time) time.Time {
+	if strings.HasPrefix(attempt.Key, ipKeyPrefix) {
+		if attempt.Failures >= a.login.IPMaxFailures {
+			return now.Add(a.login.LockoutDuration)
+		}
+		return time.Time{}
+	}
+
+	switch {
+	case attempt.Failures >= a.login.MaxFailures:
+		return now.Add(a.login.LockoutDuration)
+	case attempt.Failures >= a.login.FreeFailures:
+		delay := a.login.LockoutDuration
+		if shift := attempt.Failures - a.login.FreeFailures; shift < 20 {
+			delay = min(time.Second<<shift, delay)
+		}
+		return now.Add(delay)
+	}
+	return time.Time{}
 }
 
 func (a *AuthServiceImpl) findByID(ctx context.Context, ID int) (*domain.Auth, error) {
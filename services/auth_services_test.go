@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/config"
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/notify"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+	"github.com/dimassfeb-09/efilm-api.git/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// notifications records the messages sent to it.
+type notifications []notify.Message
+
+func (n *notifications) Notify(ctx context.Context, message notify.Message) error {
+	*n = append(*n, message)
+	return nil
+}
+
+func newAuthService(store *memory.Store, notifier notify.Notifier) *AuthServiceImpl {
+	password := config.Default().Password
+	password.HashCost = bcrypt.MinCost
+	keys, _ := helpers.NewJWTKeys("test-secret", "", nil)
+
+	return NewAuthService(store, memory.NewAuthRepository(store), memory.NewLoginAttemptRepository(store), memory.NewPasswordResetRepository(store),
+		keys, config.Default().Login, password, notifier, nil).(*AuthServiceImpl)
+}
+
+func TestAuthServiceRegisterDuplicate(t *testing.T) {
+	ctx := context.Background()
+	service := newAuthService(memory.NewStore(), &notifications{})
+
+	err := service.Register(ctx, &web.AuthModelRequest{Username: "jieun", Password: "secret-password"})
+	assert.NoError(t, err)
+
+	err = service.Register(ctx, &web.AuthModelRequest{Username: "jieun", Password: "other-password"})
+	assert.EqualError(t, err, "username already exists")
+}
+
+func TestAuthServiceLoginLockout(t *testing.T) {
+	ctx := context.Background()
+	service := newAuthService(memory.NewStore(), &notifications{})
+	now := time.Unix(0, 0)
+	service.now = func() time.Time { return now }
+	assert.NoError(t, service.Register(ctx, &web.AuthModelRequest{Username: "jieun", Password: "secret-password"}))
+
+	wrong := &web.AuthModelRequest{Username: "jieun", Password: "wrong"}
+	right := &web.AuthModelRequest{Username: "jieun", Password: "secret-password"}
+
+	// Unknown usernames and wrong passwords fail alike.
+	_, err := service.Login(ctx, &web.AuthModelRequest{Username: "nobody", Password: "wrong"}, "10.0.0.1")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	for i := 0; i < 3; i++ {
+		_, err := service.Login(ctx, wrong, "10.0.0.1")
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	}
+
+	// The delay doubles with every failure past the free ones.
+	var locked *LockedError
+	for _, delay := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		_, err = service.Login(ctx, right, "10.0.0.2")
+		assert.ErrorAs(t, err, &locked)
+		assert.Equal(t, delay, locked.RetryAfter)
+
+		now = now.Add(delay)
+		_, err = service.Login(ctx, wrong, "10.0.0.2")
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	}
+
+	// The maximum locks the username for the lockout duration.
+	for i := 0; i < 4; i++ {
+		now = now.Add(2 * time.Minute)
+		_, err = service.Login(ctx, wrong, "10.0.0.2")
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	}
+	_, err = service.Login(ctx, right, "10.0.0.3")
+	assert.ErrorAs(t, err, &locked)
+	assert.Equal(t, 15*time.Minute, locked.RetryAfter)
+
+	assert.NoError(t, service.Unlock(ctx, &web.UnlockLoginRequest{Username: "jieun"}))
+	token, err := service.Login(ctx, right, "10.0.0.3")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+}
+
+// lockstepAttempts holds every login back after it reads the attempts of
+// its username until all of them have, so that they overlap.
+type lockstepAttempts struct {
+	repository.LoginAttemptRepository
+	mu      sync.Mutex
+	pending int
+	ready   chan struct{}
+}
+
+func (r *lockstepAttempts) FindByKey(ctx context.Context, db repository.Querier, key string) (*domain.LoginAttempt, error) {
+	attempt, err := r.LoginAttemptRepository.FindByKey(ctx, db, key)
+	if strings.HasPrefix(key, userKeyPrefix) {
+		r.mu.Lock()
+		if r.pending--; r.pending == 0 {
+			close(r.ready)
+		}
+		r.mu.Unlock()
+		<-r.ready
+	}
+	return attempt, err
+}
+
+func TestAuthServiceLoginConcurrentFailures(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	service := newAuthService(store, &notifications{})
+	service.login = config.Login{FreeFailures: 100, MaxFailures: 200, IPMaxFailures: 100, LockoutDuration: time.Minute}
+	assert.NoError(t, service.Register(ctx, &web.AuthModelRequest{Username: "jieun", Password: "secret-password"}))
+
+	const logins = 20
+	service.LoginAttemptRepository = &lockstepAttempts{
+		LoginAttemptRepository: memory.NewLoginAttemptRepository(store),
+		pending:                logins,
+		ready:                  make(chan struct{}),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < logins; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := service.Login(ctx, &web.AuthModelRequest{Username: "jieun", Password: "wrong-password"}, fmt.Sprintf("10.0.0.%d", i))
+			assert.ErrorIs(t, err, ErrInvalidCredentials)
+		}(i)
+	}
+	wg.Wait()
+
+	attempt, err := memory.NewLoginAttemptRepository(store).FindByKey(ctx, store, userKeyPrefix+"jieun")
+	assert.NoError(t, err)
+	if assert.NotNil(t, attempt) {
+		assert.Equal(t, logins, attempt.Failures)
+	}
+}
+
+// failingUnlocks fails to forget the failures of client IPs.
+type failingUnlocks struct {
+	repository.LoginAttemptRepository
+}
+
+func (r failingUnlocks) Delete(ctx context.Context, tx repository.Querier, key string) error {
+	if strings.HasPrefix(key, ipKeyPrefix) {
+		return errors.New("connection reset")
+	}
+	return r.LoginAttemptRepository.Delete(ctx, tx, key)
+}
+
+func TestAuthServiceUnlockRollsBack(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	service := newAuthService(store, &notifications{})
+	assert.NoError(t, service.Register(ctx, &web.AuthModelRequest{Username: "jieun", Password: "secret-password"}))
+	_, err := service.Login(ctx, &web.AuthModelRequest{Username: "jieun", Password: "wrong"}, "10.0.0.1")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	attempts := memory.NewLoginAttemptRepository(store)
+	service.LoginAttemptRepository = failingUnlocks{attempts}
+	err = service.Unlock(ctx, &web.UnlockLoginRequest{Username: "jieun", IP: "10.0.0.1"})
+	assert.EqualError(t, err, "connection reset")
+
+	// The user keeps their failures too.
+	attempt, err := attempts.FindByKey(ctx, store, userKeyPrefix+"jieun")
+	assert.NoError(t, err)
+	assert.NotNil(t, attempt)
+}
+
+func TestAuthServicePasswordPolicy(t *testing.T) {
+	service := newAuthService(memory.NewStore(), &notifications{})
+
+	for password, message := range map[string]string{
+		"short-1":                "password must be at least 10 characters long",
+		"jieun-account":          "",
+		"Jieun-Account":          "",
+		"JIEUNJIEUNJIEUN":        "password must mix at least 2 of lowercase letters, uppercase letters, digits and symbols",
+		strings.Repeat("a1", 40): "password must be at most 72 bytes long",
+	} {
+		err := checkPassword(service.password, "jieun", password)
+		if message == "" {
+			assert.NoError(t, err, password)
+		} else {
+			assert.EqualError(t, err, message, password)
+		}
+	}
+	assert.EqualError(t, checkPassword(service.password, "jieun-account", "Jieun-Account"), "password must not be the username")
+
+	err := service.Register(context.Background(), &web.AuthModelRequest{Username: "jieun", Password: "secret"})
+	assert.EqualError(t, err, "password must be at least 10 characters long")
+}
+
+func TestAuthServiceLoginRehashes(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	service := newAuthService(store, &notifications{})
+	assert.NoError(t, service.Register(ctx, &web.AuthModelRequest{Username: "jieun", Password: "secret-password"}))
+
+	service.password.HashCost = bcrypt.MinCost + 1
+	_, err := service.Login(ctx, &web.AuthModelRequest{Username: "jieun", Password: "secret-password"}, "10.0.0.1")
+	assert.NoError(t, err)
+
+	user, err := service.AuthRepository.FindByUsername(ctx, store, "jieun")
+	assert.NoError(t, err)
+	cost, err := bcrypt.Cost([]byte(user.Password))
+	assert.NoError(t, err)
+	assert.Equal(t, bcrypt.MinCost+1, cost)
+}
+
+func TestAuthServicePasswordReset(t *testing.T) {
+	ctx := context.Background()
+	sent := &notifications{}
+	service := newAuthService(memory.NewStore(), sent)
+	now := time.Unix(0, 0)
+	service.now = func() time.Time { return now }
+	assert.NoError(t, service.Register(ctx, &web.AuthModelRequest{Username: "jieun", Password: "secret-password"}))
+
+	// Unknown usernames are not told apart, but get nothing.
+	assert.NoError(t, service.ForgotPassword(ctx, &web.ForgotPasswordRequest{Username: "nobody"}))
+	assert.Empty(t, *sent)
+
+	assert.NoError(t, service.ForgotPassword(ctx, &web.ForgotPasswordRequest{Username: "jieun"}))
+	if !assert.Len(t, *sent, 1) {
+		return
+	}
+	assert.Equal(t, "jieun", (*sent)[0].To)
+	fields := strings.Fields((*sent)[0].Body)
+	token := fields[len(fields)-1]
+
+	err := service.ResetPassword(ctx, &web.ResetPasswordRequest{Token: token, Password: "short"})
+	assert.EqualError(t, err, "password must be at least 10 characters long")
+
+	assert.NoError(t, service.ResetPassword(ctx, &web.ResetPasswordRequest{Token: token, Password: "new-password-1"}))
+	_, err = service.Login(ctx, &web.AuthModelRequest{Username: "jieun", Password: "new-password-1"}, "10.0.0.1")
+	assert.NoError(t, err)
+
+	// Tokens work once, and not after they expire.
+	err = service.ResetPassword(ctx, &web.ResetPasswordRequest{Token: token, Password: "new-password-2"})
+	assert.ErrorIs(t, err, ErrInvalidResetToken)
+
+	assert.NoError(t, service.ForgotPassword(ctx, &web.ForgotPasswordRequest{Username: "jieun"}))
+	fields = strings.Fields((*sent)[1].Body)
+	now = now.Add(time.Hour)
+	err = service.ResetPassword(ctx, &web.ResetPasswordRequest{Token: fields[len(fields)-1], Password: "new-password-2"})
+	assert.ErrorIs(t, err, ErrInvalidResetToken)
+}
+
+// undeliverable records the messages sent to it, and fails to deliver them.
+type undeliverable struct {
+	notifications
+}
+
+func (n *undeliverable) Notify(ctx context.Context, message notify.Message) error {
+	n.notifications.Notify(ctx, message)
+	return errors.New("mail server is down")
+}
+
+func TestAuthServicePasswordResetDelivery(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	service := newAuthService(store, nil)
+	assert.NoError(t, service.Register(ctx, &web.AuthModelRequest{Username: "jieun", Password: "secret-password"}))
+
+	err := service.ForgotPassword(ctx, &web.ForgotPasswordRequest{Username: "jieun"})
+	assert.ErrorIs(t, err, ErrNoNotifier)
+	err = service.ResetPassword(ctx, &web.ResetPasswordRequest{Token: "token", Password: "new-password-1"})
+	assert.ErrorIs(t, err, ErrNoNotifier)
+
+	// A token that was not delivered is not kept either.
+	sent := &undeliverable{}
+	service = newAuthService(store, sent)
+	err = service.ForgotPassword(ctx, &web.ForgotPasswordRequest{Username: "jieun"})
+	assert.ErrorContains(t, err, "mail server is down")
+	if !assert.Len(t, sent.notifications, 1) {
+		return
+	}
+	fields := strings.Fields(sent.notifications[0].Body)
+	err = service.ResetPassword(ctx, &web.ResetPasswordRequest{Token: fields[len(fields)-1], Password: "new-password-1"})
+	assert.ErrorIs(t, err, ErrInvalidResetToken)
+}
+
+func TestAuthServiceChangePassword(t *testing.T) {
+	ctx := context.Background()
+	service := newAuthService(memory.NewStore(), &notifications{})
+	assert.NoError(t, service.Register(ctx, &web.AuthModelRequest{Username: "jieun", Password: "secret-password"}))
+	user, err := service.AuthRepository.FindByUsername(ctx, service.DB, "jieun")
+	assert.NoError(t, err)
+
+	err = service.ChangePassword(ctx, user.ID, &web.ChangePasswordRequest{CurrentPassword: "wrong-password", NewPassword: "new-password-1"})
+	assert.ErrorIs(t, err, ErrWrongPassword)
+
+	assert.NoError(t, service.ChangePassword(ctx, user.ID, &web.ChangePasswordRequest{CurrentPassword: "secret-password", NewPassword: "new-password-1"}))
+	_, err = service.Login(ctx, &web.AuthModelRequest{Username: "jieun", Password: "new-password-1"}, "10.0.0.1")
+	assert.NoError(t, err)
+}
+
+type failingResets struct {
+	repository.PasswordResetRepository
+}
+
+func (failingResets) DeleteByUserID(ctx context.Context, tx repository.Querier, userID int) error {
+	return errors.New("connection reset")
+}
+
+func TestAuthServiceChangePasswordRollsBack(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	service := newAuthService(store, &notifications{})
+	assert.NoError(t, service.Register(ctx, &web.AuthModelRequest{Username: "jieun", Password: "secret-password"}))
+	user, err := service.AuthRepository.FindByUsername(ctx, service.DB, "jieun")
+	assert.NoError(t, err)
+
+	service.PasswordResetRepository = failingResets{memory.NewPasswordResetRepository(store)}
+	err = service.ChangePassword(ctx, user.ID, &web.ChangePasswordRequest{CurrentPassword: "secret-password", NewPassword: "new-password-1"})
+	assert.EqualError(t, err, "connection reset")
+
+	// The password is as it was.
+	after, err := service.AuthRepository.FindByID(ctx, service.DB, user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, user.Password, after.Password)
+}
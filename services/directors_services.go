@@ -2,7 +2,6 @@ package services
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
@@ -19,18 +18,31 @@ type DirectorService interface {
 	FindByName(ctx context.Context, name string) (*web.DirectorModelResponse, error)
 	FindByNational(ctx context.Context, nationalityID int) ([]*web.DirectorModelResponse, error)
 	FindAll(ctx context.Context) ([]*web.DirectorModelResponse, error)
+	FindMovies(ctx context.Context, ID int, ascending bool) (*web.DirectorMoviesModelResponse, error)
 }
 
 type DirectorServiceImpl struct {
-	DB                 *sql.DB
-	DirectorRepository repository.DirectorRepository
+	DB                      repository.DB
+	DirectorRepository      repository.DirectorRepository
+	movieDirectorRepository repository.MovieDirectorRepository
 }
 
-func NewDirectorService(DB *sql.DB, directorRepository repository.DirectorRepository) DirectorService {
-	return &DirectorServiceImpl{DB: DB, DirectorRepository: directorRepository}
+func NewDirectorService(
+	DB repository.DB,
+	directorRepository repository.DirectorRepository,
+	movieDirectorRepository repository.MovieDirectorRepository,
+) DirectorService {
+	return &DirectorServiceImpl{
+		DB:                      DB,
+		DirectorRepository:      directorRepository,
+		movieDirectorRepository: movieDirectorRepository,
+	}
 }
 
 func (a *DirectorServiceImpl) Save(ctx context.Context, r *web.DirectorModelRequest) error {
+	ctx, span := tracer.Start(ctx, "DirectorService.Save")
+	defer span.End()
+
 	tx, err := a.DB.Begin()
 	if err != nil {
 		return err
@@ -54,11 +66,12 @@ func (a *DirectorServiceImpl) Save(ctx context.Context, r *web.DirectorModelRequ
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 	})
-
-	return nil
 }
 
 func (a *DirectorServiceImpl) Update(ctx context.Context, r *web.DirectorModelRequest) error {
+	ctx, span := tracer.Start(ctx, "DirectorService.Update")
+	defer span.End()
+
 	tx, err := a.DB.Begin()
 	if err != nil {
 		return err
@@ -84,12 +97,15 @@ func (a *DirectorServiceImpl) Update(ctx context.Context, r *web.DirectorModelRe
 	})
 }
 
-func (a *DirectorServiceImpl) Delete(ctx context.Context, ID int) error {
+func (a *DirectorServiceImpl) Delete(ctx context.Context, ID int) (err error) {
+	ctx, span := tracer.Start(ctx, "DirectorService.Delete")
+	defer span.End()
+
 	tx, err := a.DB.Begin()
 	if err != nil {
 		return err
 	}
-	defer helpers.RollbackOrCommit(ctx, tx)
+	defer helpers.CommitOrRollback(tx, &err)
 
 	_, err = a.FindByID(ctx, ID)
 	if err != nil {
@@ -105,6 +121,9 @@ func (a *DirectorServiceImpl) Delete(ctx context.Context, ID int) error {
 }
 
 func (a *DirectorServiceImpl) FindByID(ctx context.Context, ID int) (*web.DirectorModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "DirectorService.FindByID")
+	defer span.End()
+
 	result, err := a.DirectorRepository.FindByID(ctx, a.DB, ID)
 	if err != nil {
 		return nil, err
@@ -121,6 +140,8 @@ func (a *DirectorServiceImpl) FindByID(ctx context.Context, ID int) (*web.Direct
 }
 
 func (a *DirectorServiceImpl) FindByName(ctx context.Context, name string) (*web.DirectorModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "DirectorService.FindByName")
+	defer span.End()
 
 	result, err := a.DirectorRepository.FindByName(ctx, a.DB, name)
 	if err != nil {
@@ -138,6 +159,9 @@ func (a *DirectorServiceImpl) FindByName(ctx context.Context, name string) (*web
 }
 
 func (a *DirectorServiceImpl) FindByNational(ctx context.Context, nationalityID int) ([]*web.DirectorModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "DirectorService.FindByNational")
+	defer span.End()
+
 	results, err := a.DirectorRepository.FindByNational(ctx, a.DB, nationalityID)
 	if err != nil {
 		return nil, err
@@ -160,6 +184,9 @@ func (a *DirectorServiceImpl) FindByNational(ctx context.Context, nationalityID
 }
 
 func (a *DirectorServiceImpl) FindAll(ctx context.Context) ([]*web.DirectorModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "DirectorService.FindAll")
+	defer span.End()
+
 	results, err := a.DirectorRepository.FindAll(ctx, a.DB)
 	if err != nil {
 		return nil, err
@@ -181,3 +208,35 @@ func (a *DirectorServiceImpl) FindAll(ctx context.Context) ([]*web.DirectorModel
 
 	return responses, nil
 }
+
+func (a *DirectorServiceImpl) FindMovies(ctx context.Context, ID int, ascending bool) (*web.DirectorMoviesModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "DirectorService.FindMovies")
+	defer span.End()
+
+	director, err := a.DirectorRepository.FindByID(ctx, a.DB, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	movies, err := a.movieDirectorRepository.FindMoviesByDirector(ctx, a.DB, ID, ascending)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &web.DirectorMoviesModelResponse{
+		DirectorID: director.ID,
+		Name:       director.Name,
+		Movies:     []web.DirectorMovieModelResponse{},
+	}
+
+	for _, movie := range movies {
+		response.Movies = append(response.Movies, web.DirectorMovieModelResponse{
+			MovieID:     movie.ID,
+			Title:       movie.Title,
+			ReleaseDate: movie.ReleaseDate,
+			Role:        domain.JobDirector,
+		})
+	}
+
+	return response, nil
+}
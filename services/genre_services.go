@@ -2,7 +2,6 @@ package services
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
@@ -21,16 +20,19 @@ type GenreService interface {
 }
 
 type GenreServiceImpl struct {
-	DB              *sql.DB
+	DB              repository.DB
 	GenreRepository repository.GenreRepository
 	MovieService    MovieService
 }
 
-func NewGenreService(DB *sql.DB, genreRepository repository.GenreRepository, movieService MovieService) GenreService {
+func NewGenreService(DB repository.DB, genreRepository repository.GenreRepository, movieService MovieService) GenreService {
 	return &GenreServiceImpl{DB: DB, GenreRepository: genreRepository, MovieService: movieService}
 }
 
 func (service *GenreServiceImpl) Save(ctx context.Context, r *web.GenreModelRequest) error {
+	ctx, span := tracer.Start(ctx, "GenreService.Save")
+	defer span.End()
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return err
@@ -48,6 +50,9 @@ func (service *GenreServiceImpl) Save(ctx context.Context, r *web.GenreModelRequ
 }
 
 func (service *GenreServiceImpl) Update(ctx context.Context, r *web.GenreModelRequest) error {
+	ctx, span := tracer.Start(ctx, "GenreService.Update")
+	defer span.End()
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return err
@@ -66,6 +71,9 @@ func (service *GenreServiceImpl) Update(ctx context.Context, r *web.GenreModelRe
 }
 
 func (service *GenreServiceImpl) Delete(ctx context.Context, ID int) error {
+	ctx, span := tracer.Start(ctx, "GenreService.Delete")
+	defer span.End()
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return err
@@ -86,6 +94,9 @@ func (service *GenreServiceImpl) Delete(ctx context.Context, ID int) error {
 }
 
 func (service *GenreServiceImpl) FindByID(ctx context.Context, ID int) (*web.GenreModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "GenreService.FindByID")
+	defer span.End()
+
 	result, err := service.GenreRepository.FindByID(ctx, service.DB, ID)
 	if err != nil {
 		return nil, err
@@ -98,6 +109,8 @@ func (service *GenreServiceImpl) FindByID(ctx context.Context, ID int) (*web.Gen
 }
 
 func (service *GenreServiceImpl) FindByName(ctx context.Context, name string) (*web.GenreModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "GenreService.FindByName")
+	defer span.End()
 
 	result, err := service.GenreRepository.FindByName(ctx, service.DB, name)
 	if err != nil {
@@ -111,6 +124,9 @@ func (service *GenreServiceImpl) FindByName(ctx context.Context, name string) (*
 }
 
 func (service *GenreServiceImpl) FindAll(ctx context.Context) ([]*web.GenreModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "GenreService.FindAll")
+	defer span.End()
+
 	results, err := service.GenreRepository.FindAll(ctx, service.DB)
 	if err != nil {
 		return nil, err
@@ -130,6 +146,9 @@ func (service *GenreServiceImpl) FindAll(ctx context.Context) ([]*web.GenreModel
 }
 
 func (service *GenreServiceImpl) FindAllMoviesByID(ctx context.Context, ID int) (*web.MoviesGenreResponse, error) {
+	ctx, span := tracer.Start(ctx, "GenreService.FindAllMoviesByID")
+	defer span.End()
+
 	_, err := service.GenreRepository.FindByID(ctx, service.DB, ID)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/repository/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenreServiceSaveDuplicate(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	service := NewGenreService(store, memory.NewGenreRepository(store), newTestMovieService(store))
+
+	err := service.Save(ctx, &web.GenreModelRequest{Name: "Drama"})
+	assert.NoError(t, err)
+
+	err = service.Save(ctx, &web.GenreModelRequest{Name: "Drama"})
+	assert.EqualError(t, err, "genre name already exists")
+}
+
+func TestGenreServiceUpdateUnknown(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	service := NewGenreService(store, memory.NewGenreRepository(store), newTestMovieService(store))
+
+	err := service.Update(ctx, &web.GenreModelRequest{ID: 42, Name: "Drama"})
+	assert.EqualError(t, err, "genre with id 42 not found")
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/dimassfeb-09/efilm-api.git/config"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/migrations"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+const (
+	HealthOK       = "ok"
+	HealthFailing  = "failing"
+	HealthDisabled = "disabled"
+)
+
+// errCheckDisabled is returned by checks of optional dependencies that are
+// not configured.
+var errCheckDisabled = errors.New("not configured")
+
+// checkTimeout bounds every readiness check, so a hung dependency fails the
+// probe instead of outlasting it.
+const checkTimeout = 2 * time.Second
+
+type HealthService interface {
+	// Readiness checks every dependency; ready is false when any of them fails.
+	Readiness(ctx context.Context) (response *web.ReadinessResponse, ready bool)
+}
+
+type HealthServiceImpl struct {
+	DB                  repository.DB
+	MigrationRepository repository.MigrationRepository
+	firebase            config.Firebase
+	logger              *slog.Logger
+
+	mu     sync.Mutex
+	bucket *storage.BucketHandle
+}
+
+func NewHealthService(DB repository.DB, migrationRepository repository.MigrationRepository, firebase config.Firebase, logger *slog.Logger) HealthService {
+	return &HealthServiceImpl{DB: DB, MigrationRepository: migrationRepository, firebase: firebase, logger: logger}
+}
+
+func (service *HealthServiceImpl) Readiness(ctx context.Context) (*web.ReadinessResponse, bool) {
+	checks := map[string]func(ctx context.Context) (message string, err error){
+		"database":   service.checkDatabase,
+		"migrations": service.checkMigrations,
+		"storage":    service.checkStorage,
+	}
+
+	response := &web.ReadinessResponse{Status: HealthOK, Checks: map[string]web.HealthCheckResponse{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check func(ctx context.Context) (string, error)) {
+			defer wg.Done()
+			result := service.runCheck(ctx, name, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			response.Checks[name] = result
+			if result.Status == HealthFailing {
+				response.Status = HealthFailing
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	return response, response.Status == HealthOK
+}
+
+func (service *HealthServiceImpl) runCheck(ctx context.Context, name string, check func(ctx context.Context) (string, error)) web.HealthCheckResponse {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	message, err := check(ctx)
+	result := web.HealthCheckResponse{
+		Status:    HealthOK,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+		Message:   message,
+	}
+
+	switch {
+	case errors.Is(err, errCheckDisabled):
+		result.Status = HealthDisabled
+	case err != nil:
+		service.logger.WarnContext(ctx, "readiness check failed", slog.String("check", name), slog.String("error", err.Error()))
+		result.Status = HealthFailing
+	}
+
+	return result
+}
+
+func (service *HealthServiceImpl) checkDatabase(ctx context.Context) (string, error) {
+	if err := service.DB.PingContext(ctx); err != nil {
+		return "ping failed", err
+	}
+	return "", nil
+}
+
+func (service *HealthServiceImpl) checkMigrations(ctx context.Context) (string, error) {
+	latest, err := migrations.Latest()
+	if err != nil {
+		return "embedded migrations are unreadable", err
+	}
+
+	version, dirty, err := service.MigrationRepository.Version(ctx, service.DB)
+	if err != nil {
+		return "schema version is unreadable", err
+	}
+
+	message := fmt.Sprintf("schema version %d, expected %d", version, latest)
+	switch {
+	case dirty:
+		message = fmt.Sprintf("schema version %d is dirty, a migration failed halfway", version)
+		return message, errors.New(message)
+	case version != latest:
+		return message, errors.New(message)
+	}
+
+	return message, nil
+}
+
+// checkStorage reads the attributes of the poster bucket. Without a bucket
+// configured uploads are off, which does not make the server unready.
+func (service *HealthServiceImpl) checkStorage(ctx context.Context) (string, error) {
+	if service.firebase.Bucket == "" {
+		return "", errCheckDisabled
+	}
+
+	bucket, err := service.storageBucket()
+	if err != nil {
+		return "client unavailable", err
+	}
+
+	if _, err := bucket.Attrs(ctx); err != nil {
+		return "bucket unreachable", err
+	}
+	return "", nil
+}
+
+// storageBucket creates the storage client on first use and keeps it, so
+// probes do not open a new client every few seconds. The client outlives the
+// probe, so it must not be bound to the probe's context.
+func (service *HealthServiceImpl) storageBucket() (*storage.BucketHandle, error) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	if service.bucket == nil {
+		bucket, err := helpers.NewFirebaseStorageClient(context.Background(), service.firebase.CredentialsFile, service.firebase.Bucket)
+		if err != nil {
+			return nil, err
+		}
+		service.bucket = bucket
+	}
+
+	return service.bucket, nil
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimassfeb-09/efilm-api.git/config"
+	"github.com/dimassfeb-09/efilm-api.git/logging"
+	"github.com/dimassfeb-09/efilm-api.git/migrations"
+	"github.com/dimassfeb-09/efilm-api.git/repository/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthServiceReadiness(t *testing.T) {
+	ctx := context.Background()
+	latest, err := migrations.Latest()
+	assert.NoError(t, err)
+
+	store := memory.NewStore()
+	service := NewHealthService(store, memory.NewMigrationRepository(store), config.Firebase{}, logging.Discard())
+
+	result, ready := service.Readiness(ctx)
+	assert.True(t, ready)
+	assert.Equal(t, HealthOK, result.Status)
+	assert.Equal(t, HealthOK, result.Checks["database"].Status)
+	assert.Equal(t, HealthOK, result.Checks["migrations"].Status)
+	assert.Equal(t, HealthDisabled, result.Checks["storage"].Status)
+
+	store.SetSchemaVersion(latest-1, false)
+	result, ready = service.Readiness(ctx)
+	assert.False(t, ready)
+	assert.Equal(t, HealthFailing, result.Status)
+	assert.Equal(t, HealthFailing, result.Checks["migrations"].Status)
+
+	store.SetSchemaVersion(latest, true)
+	result, ready = service.Readiness(ctx)
+	assert.False(t, ready)
+	assert.Contains(t, result.Checks["migrations"].Message, "dirty")
+}
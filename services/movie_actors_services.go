@@ -2,7 +2,8 @@ package services
 
 import (
 	"context"
-	"database/sql"
+	"fmt"
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
 	"github.com/dimassfeb-09/efilm-api.git/helpers"
 	"github.com/dimassfeb-09/efilm-api.git/repository"
@@ -12,34 +13,58 @@ type MovieActorService interface {
 	Save(ctx context.Context, r *web.MovieActorModelRequestPost) error
 	Update(ctx context.Context, r *web.MovieActorModelRequestPut) error
 	Delete(ctx context.Context, actorID int) error
+	ReplaceCast(ctx context.Context, r *web.MovieActorModelRequestBatch) (*web.MovieActorModelResponse, error)
 	FindByID(ctx context.Context, movieID int) (*web.MovieActorModelResponse, error)
 }
 
 type MovieActorServiceImpl struct {
-	DB                   *sql.DB
+	DB                   repository.DB
 	MovieActorRepository repository.MovieActorRepository
+	personRepository     repository.PersonRepository
+	movieRepository      repository.MovieRepository
 }
 
-func NewMovieActorService(DB *sql.DB, actorRepository repository.MovieActorRepository) MovieActorService {
-	return &MovieActorServiceImpl{DB: DB, MovieActorRepository: actorRepository}
+func NewMovieActorService(
+	DB repository.DB,
+	actorRepository repository.MovieActorRepository,
+	personRepository repository.PersonRepository,
+	movieRepository repository.MovieRepository,
+) MovieActorService {
+	return &MovieActorServiceImpl{
+		DB:                   DB,
+		MovieActorRepository: actorRepository,
+		personRepository:     personRepository,
+		movieRepository:      movieRepository,
+	}
 }
 
 func (service *MovieActorServiceImpl) Save(ctx context.Context, r *web.MovieActorModelRequestPost) error {
+	ctx, span := tracer.Start(ctx, "MovieActorService.Save")
+	defer span.End()
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return err
 	}
 	defer helpers.RollbackOrCommit(ctx, tx)
 
-	err = service.MovieActorRepository.Save(ctx, tx, r.MovieID, r.ActorID, r.Role)
+	_, err = service.personRepository.FindByID(ctx, service.DB, r.ActorID)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	err = service.MovieActorRepository.SaveCredit(ctx, tx, r.MovieID, newActorCredit(r.ActorID, r.Role, r.CharacterName, r.CreditType, r.BillingOrder, r.Notes))
+	if err != nil {
+		return err
+	}
+
+	return service.personRepository.MarkAs(ctx, tx, r.ActorID, domain.PersonViewActor)
 }
 
 func (service *MovieActorServiceImpl) Update(ctx context.Context, r *web.MovieActorModelRequestPut) error {
+	ctx, span := tracer.Start(ctx, "MovieActorService.Update")
+	defer span.End()
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return err
@@ -51,7 +76,7 @@ func (service *MovieActorServiceImpl) Update(ctx context.Context, r *web.MovieAc
 		return err
 	}
 
-	err = service.MovieActorRepository.Update(ctx, tx, r.MovieID, r.ActorID, r.Role)
+	err = service.MovieActorRepository.UpdateCredit(ctx, tx, r.MovieID, newActorCredit(r.ActorID, r.Role, r.CharacterName, r.CreditType, r.BillingOrder, r.Notes))
 	if err != nil {
 		return err
 	}
@@ -60,6 +85,9 @@ func (service *MovieActorServiceImpl) Update(ctx context.Context, r *web.MovieAc
 }
 
 func (service *MovieActorServiceImpl) Delete(ctx context.Context, actorID int) error {
+	ctx, span := tracer.Start(ctx, "MovieActorService.Delete")
+	defer span.End()
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return err
@@ -79,7 +107,103 @@ func (service *MovieActorServiceImpl) Delete(ctx context.Context, actorID int) e
 	return nil
 }
 
+// ReplaceCast makes the movie's cast exactly the list in the request. Actors
+// missing from the request are removed, new ones are added and the rest get
+// their role and billing order updated, all inside a single transaction.
+func (service *MovieActorServiceImpl) ReplaceCast(ctx context.Context, r *web.MovieActorModelRequestBatch) (*web.MovieActorModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "MovieActorService.ReplaceCast")
+	defer span.End()
+
+	_, err := service.movieRepository.FindByID(ctx, service.DB, r.MovieID)
+	if err != nil {
+		return nil, err
+	}
+
+	requested := make(map[int]*domain.ActorMovie, len(r.Actors))
+	for _, actor := range r.Actors {
+		if _, ok := requested[actor.ActorID]; ok {
+			return nil, fmt.Errorf("actor with ID %d is listed more than once", actor.ActorID)
+		}
+
+		_, err := service.personRepository.FindByID(ctx, service.DB, actor.ActorID)
+		if err != nil {
+			return nil, fmt.Errorf("actor with ID %d not found", actor.ActorID)
+		}
+
+		requested[actor.ActorID] = newActorCredit(actor.ActorID, actor.Role, actor.CharacterName, actor.CreditType, actor.BillingOrder, actor.Notes)
+	}
+
+	current, err := service.MovieActorRepository.FindByID(ctx, service.DB, r.MovieID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = service.replaceCast(ctx, r.MovieID, current.Actors, requested)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.FindByID(ctx, r.MovieID)
+}
+
+func (service *MovieActorServiceImpl) replaceCast(ctx context.Context, movieID int, current []domain.ActorMovie, requested map[int]*domain.ActorMovie) (err error) {
+	tx, err := service.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer helpers.CommitOrRollback(tx, &err)
+
+	existing := make(map[int]bool, len(current))
+	for _, actor := range current {
+		existing[actor.ID] = true
+
+		credit, ok := requested[actor.ID]
+		if !ok {
+			err = service.MovieActorRepository.DeleteFromMovie(ctx, tx, movieID, actor.ID)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if *credit != (domain.ActorMovie{
+			ID:            actor.ID,
+			Role:          actor.Role,
+			CharacterName: actor.CharacterName,
+			CreditType:    actor.CreditType,
+			BillingOrder:  actor.BillingOrder,
+			Notes:         actor.Notes,
+		}) {
+			err = service.MovieActorRepository.UpdateCredit(ctx, tx, movieID, credit)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for actorID, credit := range requested {
+		if existing[actorID] {
+			continue
+		}
+
+		err = service.MovieActorRepository.SaveCredit(ctx, tx, movieID, credit)
+		if err != nil {
+			return err
+		}
+
+		err = service.personRepository.MarkAs(ctx, tx, actorID, domain.PersonViewActor)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (service *MovieActorServiceImpl) FindByID(ctx context.Context, movieID int) (*web.MovieActorModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "MovieActorService.FindByID")
+	defer span.End()
+
 	result, err := service.MovieActorRepository.FindByID(ctx, service.DB, movieID)
 	if err != nil {
 		return nil, err
@@ -95,12 +219,36 @@ func (service *MovieActorServiceImpl) FindByID(ctx context.Context, movieID int)
 
 	for _, actor := range result.Actors {
 		movieActor.Actors = append(movieActor.Actors, web.Actor{
-			ActorID:     actor.ID,
-			Name:        actor.Name,
-			DateOfBirth: actor.DateOfBirth,
-			Role:        actor.Role,
+			ActorID:       actor.ID,
+			Name:          actor.Name,
+			DateOfBirth:   actor.DateOfBirth,
+			Role:          actor.Role,
+			CharacterName: actor.CharacterName,
+			CreditType:    actor.CreditType,
+			BillingOrder:  actor.BillingOrder,
+			Notes:         actor.Notes,
 		})
 	}
 
 	return movieActor, nil
 }
+
+// newActorCredit fills in the defaults for a credit: the character name falls
+// back to the role and the credit type to supporting.
+func newActorCredit(actorID int, role, characterName, creditType string, billingOrder int, notes string) *domain.ActorMovie {
+	if characterName == "" {
+		characterName = role
+	}
+	if creditType == "" {
+		creditType = domain.CreditTypeSupporting
+	}
+
+	return &domain.ActorMovie{
+		ID:            actorID,
+		Role:          role,
+		CharacterName: characterName,
+		CreditType:    creditType,
+		BillingOrder:  billingOrder,
+		Notes:         notes,
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/repository/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMovieActorService(t *testing.T) (*MovieActorServiceImpl, int) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	service := &MovieActorServiceImpl{
+		DB:                   store,
+		MovieActorRepository: memory.NewMovieActorRepository(store),
+		personRepository:     memory.NewPersonRepository(store),
+		movieRepository:      memory.NewMovieRepository(store),
+	}
+
+	for _, name := range []string{"Park Seo-joon", "Lee Ji Eun", "Kim Jong-soo"} {
+		err := service.personRepository.Save(ctx, store, &domain.Person{Name: name, DateOfBirth: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)})
+		assert.NoError(t, err)
+	}
+
+	movieID, err := service.movieRepository.Save(ctx, store, &domain.Movie{Title: "Dream"})
+	assert.NoError(t, err)
+
+	return service, movieID
+}
+
+func TestMovieActorServiceReplaceCast(t *testing.T) {
+	ctx := context.Background()
+	service, movieID := newTestMovieActorService(t)
+
+	_, err := service.ReplaceCast(ctx, &web.MovieActorModelRequestBatch{
+		MovieID: movieID,
+		Actors: []web.MovieActorCastRequest{
+			{ActorID: 1, Role: "Hong-dae", BillingOrder: 1},
+			{ActorID: 2, Role: "So-min", BillingOrder: 2},
+		},
+	})
+	assert.NoError(t, err)
+
+	cast, err := service.ReplaceCast(ctx, &web.MovieActorModelRequestBatch{
+		MovieID: movieID,
+		Actors: []web.MovieActorCastRequest{
+			{ActorID: 3, Role: "In-sik"},
+			{ActorID: 2, Role: "So-min", CreditType: domain.CreditTypeLead, BillingOrder: 1},
+		},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, cast.Actors, 2) {
+		assert.Equal(t, 2, cast.Actors[0].ActorID)
+		assert.Equal(t, domain.CreditTypeLead, cast.Actors[0].CreditType)
+		assert.Equal(t, 3, cast.Actors[1].ActorID)
+		assert.Equal(t, "In-sik", cast.Actors[1].CharacterName)
+		assert.Equal(t, domain.CreditTypeSupporting, cast.Actors[1].CreditType)
+	}
+
+	person, err := service.personRepository.FindByID(ctx, service.DB, 3)
+	assert.NoError(t, err)
+	assert.True(t, person.IsActor)
+}
+
+func TestMovieActorServiceReplaceCastRejectsInvalid(t *testing.T) {
+	ctx := context.Background()
+	service, movieID := newTestMovieActorService(t)
+
+	_, err := service.ReplaceCast(ctx, &web.MovieActorModelRequestBatch{
+		MovieID: movieID,
+		Actors: []web.MovieActorCastRequest{
+			{ActorID: 1, Role: "Hong-dae"},
+			{ActorID: 1, Role: "Hong-dae"},
+		},
+	})
+	assert.EqualError(t, err, "actor with ID 1 is listed more than once")
+
+	_, err = service.ReplaceCast(ctx, &web.MovieActorModelRequestBatch{
+		MovieID: movieID,
+		Actors:  []web.MovieActorCastRequest{{ActorID: 9, Role: "Nobody"}},
+	})
+	assert.EqualError(t, err, "actor with ID 9 not found")
+
+	cast, err := service.FindByID(ctx, movieID)
+	assert.NoError(t, err)
+	assert.Empty(t, cast.Actors)
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+type MovieCrewService interface {
+	Save(ctx context.Context, r *web.MovieCrewModelRequestPost) error
+	Delete(ctx context.Context, movieID, personID int, job string) error
+	FindByID(ctx context.Context, movieID int, job string) (*web.MovieCrewModelResponse, error)
+	FindByPerson(ctx context.Context, personID int) (*web.PersonCrewModelResponse, error)
+	FindAllJobs(ctx context.Context) ([]*web.CrewJobResponse, error)
+}
+
+type MovieCrewServiceImpl struct {
+	DB                  repository.DB
+	MovieCrewRepository repository.MovieCrewRepository
+	personRepository    repository.PersonRepository
+	movieRepository     repository.MovieRepository
+}
+
+func NewMovieCrewService(
+	DB repository.DB,
+	movieCrewRepository repository.MovieCrewRepository,
+	personRepository repository.PersonRepository,
+	movieRepository repository.MovieRepository,
+) MovieCrewService {
+	return &MovieCrewServiceImpl{
+		DB:                  DB,
+		MovieCrewRepository: movieCrewRepository,
+		personRepository:    personRepository,
+		movieRepository:     movieRepository,
+	}
+}
+
+func (service *MovieCrewServiceImpl) Save(ctx context.Context, r *web.MovieCrewModelRequestPost) (err error) {
+	ctx, span := tracer.Start(ctx, "MovieCrewService.Save")
+	defer span.End()
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helpers.CommitOrRollback(tx, &err)
+
+	_, err = service.MovieCrewRepository.FindJob(ctx, service.DB, r.Job)
+	if err != nil {
+		return err
+	}
+
+	_, err = service.personRepository.FindByID(ctx, service.DB, r.PersonID)
+	if err != nil {
+		return err
+	}
+
+	_, err = service.movieRepository.FindByID(ctx, service.DB, r.MovieID)
+	if err != nil {
+		return err
+	}
+
+	isExists, err := service.MovieCrewRepository.FindCrewAtMovie(ctx, service.DB, r.MovieID, r.PersonID, r.Job)
+	if err != nil && !errors.Is(err, repository.ErrCrewNotFound) {
+		return err
+	}
+	if isExists {
+		return errors.New("the person already has this job on film")
+	}
+
+	err = service.MovieCrewRepository.Save(ctx, tx, r.MovieID, r.PersonID, r.Job)
+	if err != nil {
+		return err
+	}
+
+	if r.Job == domain.JobDirector {
+		return service.personRepository.MarkAs(ctx, tx, r.PersonID, domain.PersonViewDirector)
+	}
+
+	return nil
+}
+
+func (service *MovieCrewServiceImpl) Delete(ctx context.Context, movieID, personID int, job string) error {
+	ctx, span := tracer.Start(ctx, "MovieCrewService.Delete")
+	defer span.End()
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helpers.RollbackOrCommit(ctx, tx)
+
+	_, err = service.MovieCrewRepository.FindCrewAtMovie(ctx, service.DB, movieID, personID, job)
+	if err != nil {
+		return err
+	}
+
+	return service.MovieCrewRepository.Delete(ctx, tx, movieID, personID, job)
+}
+
+func (service *MovieCrewServiceImpl) FindByID(ctx context.Context, movieID int, job string) (*web.MovieCrewModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "MovieCrewService.FindByID")
+	defer span.End()
+
+	if job != "" {
+		_, err := service.MovieCrewRepository.FindJob(ctx, service.DB, job)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := service.MovieCrewRepository.FindByID(ctx, service.DB, movieID, job)
+	if err != nil {
+		return nil, err
+	}
+
+	movieCrew := &web.MovieCrewModelResponse{
+		Movie: web.Movie{
+			MovieID:     result.Movie.ID,
+			Title:       result.Movie.Title,
+			ReleaseDate: result.Movie.ReleaseDate,
+		},
+	}
+
+	for _, member := range result.Crew {
+		movieCrew.Crew = append(movieCrew.Crew, web.CrewMember{
+			PersonID:    member.ID,
+			Name:        member.Name,
+			DateOfBirth: member.DateOfBirth,
+			Job:         member.Job,
+			Department:  member.Department,
+		})
+	}
+
+	return movieCrew, nil
+}
+
+func (service *MovieCrewServiceImpl) FindByPerson(ctx context.Context, personID int) (*web.PersonCrewModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "MovieCrewService.FindByPerson")
+	defer span.End()
+
+	result, err := service.MovieCrewRepository.FindByPerson(ctx, service.DB, personID)
+	if err != nil {
+		return nil, err
+	}
+
+	personCrew := &web.PersonCrewModelResponse{
+		PersonID: result.Person.ID,
+		Name:     result.Person.Name,
+	}
+
+	for _, credit := range result.Credits {
+		personCrew.Credits = append(personCrew.Credits, web.CrewCredit{
+			Movie: web.Movie{
+				MovieID:     credit.Movie.ID,
+				Title:       credit.Movie.Title,
+				ReleaseDate: credit.Movie.ReleaseDate,
+			},
+			Job:        credit.Job,
+			Department: credit.Department,
+		})
+	}
+
+	return personCrew, nil
+}
+
+func (service *MovieCrewServiceImpl) FindAllJobs(ctx context.Context) ([]*web.CrewJobResponse, error) {
+	ctx, span := tracer.Start(ctx, "MovieCrewService.FindAllJobs")
+	defer span.End()
+
+	results, err := service.MovieCrewRepository.FindAllJobs(ctx, service.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []*web.CrewJobResponse
+	for _, result := range results {
+		responses = append(responses, &web.CrewJobResponse{
+			Job:        result.Job,
+			Department: result.Department,
+		})
+	}
+
+	return responses, nil
+}
@@ -2,7 +2,6 @@ package services
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
 	"github.com/dimassfeb-09/efilm-api.git/helpers"
@@ -17,25 +16,30 @@ type MovieDirectorService interface {
 }
 
 type MovieDirectorServiceImpl struct {
-	DB                      *sql.DB
+	DB                      repository.DB
 	MovieDirectorRepository repository.MovieDirectorRepository
 	directorRepository      repository.DirectorRepository
 	movieRepository         repository.MovieRepository
 }
 
 func NewMovieDirectorService(
-	DB *sql.DB,
+	DB repository.DB,
 	movieDirectorRepository repository.MovieDirectorRepository,
+	directorRepository repository.DirectorRepository,
+	movieRepository repository.MovieRepository,
 ) MovieDirectorService {
 	return &MovieDirectorServiceImpl{
 		DB:                      DB,
 		MovieDirectorRepository: movieDirectorRepository,
-		directorRepository:      repository.NewDirectorRepository(),
-		movieRepository:         repository.NewMovieRepository(),
+		directorRepository:      directorRepository,
+		movieRepository:         movieRepository,
 	}
 }
 
 func (service *MovieDirectorServiceImpl) Save(ctx context.Context, r *web.MovieDirectorModelRequestPost) error {
+	ctx, span := tracer.Start(ctx, "MovieDirectorService.Save")
+	defer span.End()
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return err
@@ -62,6 +66,9 @@ func (service *MovieDirectorServiceImpl) Save(ctx context.Context, r *web.MovieD
 }
 
 func (service *MovieDirectorServiceImpl) Delete(ctx context.Context, movieID int, directorID int) error {
+	ctx, span := tracer.Start(ctx, "MovieDirectorService.Delete")
+	defer span.End()
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return err
@@ -82,6 +89,8 @@ func (service *MovieDirectorServiceImpl) Delete(ctx context.Context, movieID int
 }
 
 func (service *MovieDirectorServiceImpl) FindByID(ctx context.Context, movieID int) (*web.MovieDirectorModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "MovieDirectorService.FindByID")
+	defer span.End()
 
 	result, err := service.MovieDirectorRepository.FindByID(ctx, service.DB, movieID)
 	if err != nil {
@@ -108,5 +117,8 @@ func (service *MovieDirectorServiceImpl) FindByID(ctx context.Context, movieID i
 }
 
 func (service *MovieDirectorServiceImpl) FindDirectorAtMovie(ctx context.Context, movieID, directorID int) (bool, error) {
+	ctx, span := tracer.Start(ctx, "MovieDirectorService.FindDirectorAtMovie")
+	defer span.End()
+
 	return service.MovieDirectorRepository.FindDirectorAtMovie(ctx, service.DB, movieID, directorID)
 }
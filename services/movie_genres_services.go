@@ -2,7 +2,6 @@ package services
 
 import (
 	"context"
-	"database/sql"
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
 	"github.com/dimassfeb-09/efilm-api.git/helpers"
 	"github.com/dimassfeb-09/efilm-api.git/repository"
@@ -16,15 +15,18 @@ type MovieGenreService interface {
 }
 
 type MovieGenreServiceImpl struct {
-	DB                   *sql.DB
+	DB                   repository.DB
 	MovieGenreRepository repository.MovieGenreRepository
 }
 
-func NewMovieGenreService(DB *sql.DB, genreRepository repository.MovieGenreRepository) MovieGenreService {
+func NewMovieGenreService(DB repository.DB, genreRepository repository.MovieGenreRepository) MovieGenreService {
 	return &MovieGenreServiceImpl{DB: DB, MovieGenreRepository: genreRepository}
 }
 
 func (service *MovieGenreServiceImpl) Save(ctx context.Context, r *web.MovieGenreModelRequestPost) error {
+	ctx, span := tracer.Start(ctx, "MovieGenreService.Save")
+	defer span.End()
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return err
@@ -42,6 +44,9 @@ func (service *MovieGenreServiceImpl) Save(ctx context.Context, r *web.MovieGenr
 }
 
 func (service *MovieGenreServiceImpl) Delete(ctx context.Context, movieID int, genreID int) error {
+	ctx, span := tracer.Start(ctx, "MovieGenreService.Delete")
+	defer span.End()
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return err
@@ -62,6 +67,8 @@ func (service *MovieGenreServiceImpl) Delete(ctx context.Context, movieID int, g
 }
 
 func (service *MovieGenreServiceImpl) FindByID(ctx context.Context, movieID int) (*web.MovieGenreModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "MovieGenreService.FindByID")
+	defer span.End()
 
 	result, err := service.MovieGenreRepository.FindByID(ctx, service.DB, movieID)
 	if err != nil {
@@ -87,5 +94,8 @@ func (service *MovieGenreServiceImpl) FindByID(ctx context.Context, movieID int)
 }
 
 func (service *MovieGenreServiceImpl) FindGenreExists(ctx context.Context, genreID int) error {
+	ctx, span := tracer.Start(ctx, "MovieGenreService.FindGenreExists")
+	defer span.End()
+
 	return service.MovieGenreRepository.FindGenreExists(ctx, service.DB, genreID)
 }
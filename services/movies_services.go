@@ -2,12 +2,15 @@ package services
 
 import (
 	"context"
-	"database/sql"
 	"errors"
+	"github.com/dimassfeb-09/efilm-api.git/config"
 	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
 	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/metrics"
 	"github.com/dimassfeb-09/efilm-api.git/repository"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"io"
 	"mime/multipart"
 	"strings"
@@ -19,29 +22,48 @@ type MovieService interface {
 	Update(ctx context.Context, r *web.MovieModelRequest) error
 	UploadFile(ctx context.Context, movieID int, fileHeader *multipart.FileHeader) error
 	Delete(ctx context.Context, ID int) error
-	FindByID(ctx context.Context, ID int) (*web.MovieModelResponse, error)
+	// FindByID and FindAll fill in the fields that depend on who asks for
+	// the user of userID, and leave them out for anonymous callers, with 0.
+	FindByID(ctx context.Context, ID int, userID int) (*web.MovieModelResponse, error)
 	FindByTitle(ctx context.Context, name string) (*web.MovieModelResponse, error)
-	FindAll(ctx context.Context) ([]*web.MovieModelResponse, error)
+	FindAll(ctx context.Context, userID int) ([]*web.MovieModelResponse, error)
 	FindAllMoviesByGenreID(ctx context.Context, genreID int) ([]*web.MovieModelResponse, error)
 }
 
 type MovieServiceImpl struct {
-	DB                      *sql.DB
-	MovieRepository         repository.MovieRepository
-	movieGenreRepository    repository.MovieGenreRepository
-	movieDirectorRepository repository.MovieDirectorRepository
+	DB                   repository.DB
+	MovieRepository      repository.MovieRepository
+	movieGenreRepository repository.MovieGenreRepository
+	movieCrewRepository  repository.MovieCrewRepository
+	authRepository       repository.AuthRepository
+	firebase             config.Firebase
+	metrics              *metrics.Metrics
 }
 
-func NewMovieService(DB *sql.DB, movieRepository repository.MovieRepository) MovieService {
+func NewMovieService(
+	DB repository.DB,
+	movieRepository repository.MovieRepository,
+	movieGenreRepository repository.MovieGenreRepository,
+	movieCrewRepository repository.MovieCrewRepository,
+	authRepository repository.AuthRepository,
+	firebase config.Firebase,
+	metrics *metrics.Metrics,
+) MovieService {
 	return &MovieServiceImpl{
-		DB:                      DB,
-		MovieRepository:         movieRepository,
-		movieGenreRepository:    repository.NewMovieGenreRepository(),
-		movieDirectorRepository: repository.NewMovieDirectorRepository(),
+		DB:                   DB,
+		MovieRepository:      movieRepository,
+		movieGenreRepository: movieGenreRepository,
+		movieCrewRepository:  movieCrewRepository,
+		authRepository:       authRepository,
+		firebase:             firebase,
+		metrics:              metrics,
 	}
 }
 
 func (service *MovieServiceImpl) Save(ctx context.Context, r *web.MovieModelRequest) (int, error) {
+	ctx, span := tracer.Start(ctx, "MovieService.Save")
+	defer span.End()
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return 0, err
@@ -84,13 +106,16 @@ func (service *MovieServiceImpl) Save(ctx context.Context, r *web.MovieModelRequ
 }
 
 func (service *MovieServiceImpl) Update(ctx context.Context, r *web.MovieModelRequest) error {
+	ctx, span := tracer.Start(ctx, "MovieService.Update")
+	defer span.End()
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return err
 	}
 	defer helpers.RollbackOrCommit(ctx, tx)
 
-	_, err = service.FindByID(ctx, r.ID)
+	_, err = service.FindByID(ctx, r.ID, 0)
 	if err != nil {
 		return err
 	}
@@ -158,6 +183,9 @@ func (service *MovieServiceImpl) Update(ctx context.Context, r *web.MovieModelRe
 }
 
 func (service *MovieServiceImpl) UploadFile(ctx context.Context, movieID int, fileHeader *multipart.FileHeader) error {
+	ctx, span := tracer.Start(ctx, "MovieService.UploadFile")
+	defer span.End()
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return err
@@ -166,12 +194,14 @@ func (service *MovieServiceImpl) UploadFile(ctx context.Context, movieID int, fi
 
 	file, err := fileHeader.Open()
 	if err != nil {
+		service.metrics.PosterUploadFailed(metrics.UploadInvalidFile)
 		return err
 	}
 	defer file.Close()
 
 	movie, err := service.MovieRepository.FindByID(ctx, service.DB, movieID)
 	if err != nil {
+		service.metrics.PosterUploadFailed(metrics.UploadNoMovie)
 		return err
 	}
 
@@ -180,36 +210,59 @@ func (service *MovieServiceImpl) UploadFile(ctx context.Context, movieID int, fi
 	ext := strings.Split(contentType, "/")[1]
 	movie.PosterUrl = movie.Title + "." + ext
 
-	bucket := helpers.NewFirebaseStorageClient(ctx)
+	bucket, err := helpers.NewFirebaseStorageClient(ctx, service.firebase.CredentialsFile, service.firebase.Bucket)
+	if err != nil {
+		service.metrics.PosterUploadFailed(metrics.UploadStorage)
+		return err
+	}
 	obj := bucket.Object("images/movies/" + movie.PosterUrl)
 	wc := obj.NewWriter(ctx)
-	defer wc.Close()
 
-	if _, err := io.Copy(wc, file); err != nil {
+	object := attribute.String("storage.object", obj.ObjectName())
+	span.AddEvent("storage.upload.started", trace.WithAttributes(object, attribute.String("storage.content_type", contentType)))
+
+	size, err := io.Copy(wc, file)
+	if err == nil {
+		// The object is only written once the writer is closed.
+		err = wc.Close()
+	} else {
+		wc.Close()
+	}
+	if err != nil {
+		span.AddEvent("storage.upload.failed", trace.WithAttributes(object, attribute.String("error", err.Error())))
+		service.metrics.PosterUploadFailed(metrics.UploadStorage)
 		return errors.New("Failed upload file")
 	}
+	span.AddEvent("storage.upload.finished", trace.WithAttributes(object, attribute.Int64("storage.bytes", size)))
+
+	err = service.MovieRepository.Update(ctx, tx, movie)
+	if err != nil {
+		service.metrics.PosterUploadFailed(metrics.UploadDatabase)
+		return err
+	}
 
-	return service.MovieRepository.Update(ctx, tx, movie)
+	service.metrics.PosterUploaded(size)
+	return nil
 }
 
 func (service *MovieServiceImpl) Delete(ctx context.Context, ID int) error {
+	ctx, span := tracer.Start(ctx, "MovieService.Delete")
+	defer span.End()
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return err
 	}
 	defer helpers.RollbackOrCommit(ctx, tx)
 
-	_, err = service.FindByID(ctx, ID)
+	_, err = service.FindByID(ctx, ID, 0)
 	if err != nil {
 		return err
 	}
 
-	directors, _ := service.movieDirectorRepository.FindByID(ctx, service.DB, ID)
-	for _, director := range directors.Directors {
-		err := service.movieDirectorRepository.Delete(ctx, tx, ID, director.ID)
-		if err != nil {
-			return err
-		}
+	err = service.movieCrewRepository.DeleteByMovie(ctx, tx, ID)
+	if err != nil {
+		return err
 	}
 
 	genres, _ := service.movieGenreRepository.FindByID(ctx, service.DB, ID)
@@ -228,7 +281,15 @@ func (service *MovieServiceImpl) Delete(ctx context.Context, ID int) error {
 	return nil
 }
 
-func (service *MovieServiceImpl) FindByID(ctx context.Context, ID int) (*web.MovieModelResponse, error) {
+func (service *MovieServiceImpl) FindByID(ctx context.Context, ID int, userID int) (*web.MovieModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "MovieService.FindByID")
+	defer span.End()
+
+	preferred, err := service.preferredGenres(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	movieDetail, err := service.MovieRepository.FindByID(ctx, service.DB, ID)
 	if err != nil {
 		return nil, err
@@ -259,10 +320,13 @@ func (service *MovieServiceImpl) FindByID(ctx context.Context, ID int) (*web.Mov
 		NationalID:  movieDetail.NationalID,
 		CreatedAt:   movieDetail.CreatedAt,
 		UpdatedAt:   movieDetail.UpdatedAt,
+		Preferred:   isPreferred(preferred, genreIDS),
 	}, nil
 }
 
 func (service *MovieServiceImpl) FindByTitle(ctx context.Context, name string) (*web.MovieModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "MovieService.FindByTitle")
+	defer span.End()
 
 	movieDetail, err := service.MovieRepository.FindByTitle(ctx, service.DB, name)
 	if err != nil {
@@ -285,7 +349,15 @@ func (service *MovieServiceImpl) FindByTitle(ctx context.Context, name string) (
 	}, nil
 }
 
-func (service *MovieServiceImpl) FindAll(ctx context.Context) ([]*web.MovieModelResponse, error) {
+func (service *MovieServiceImpl) FindAll(ctx context.Context, userID int) ([]*web.MovieModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "MovieService.FindAll")
+	defer span.End()
+
+	preferred, err := service.preferredGenres(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	moviesDetail, err := service.MovieRepository.FindAll(ctx, service.DB)
 	if err != nil {
 		return nil, err
@@ -315,6 +387,7 @@ func (service *MovieServiceImpl) FindAll(ctx context.Context) ([]*web.MovieModel
 			NationalID:  movieDetail.NationalID,
 			CreatedAt:   movieDetail.CreatedAt,
 			UpdatedAt:   movieDetail.UpdatedAt,
+			Preferred:   isPreferred(preferred, genreIDS),
 		}
 
 		responses = append(responses, &response)
@@ -323,7 +396,46 @@ func (service *MovieServiceImpl) FindAll(ctx context.Context) ([]*web.MovieModel
 	return responses, nil
 }
 
+// preferredGenres returns the genres the user of userID prefers, or nil for
+// an anonymous caller.
+func (service *MovieServiceImpl) preferredGenres(ctx context.Context, userID int) (map[int]bool, error) {
+	if userID == 0 {
+		return nil, nil
+	}
+
+	user, err := service.authRepository.FindByID(ctx, service.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferred := map[int]bool{}
+	for _, genreID := range user.PreferredGenres {
+		preferred[genreID] = true
+	}
+	return preferred, nil
+}
+
+// isPreferred tells whether a movie of genreIDs is in one of the preferred
+// genres, or nothing without a user to prefer any.
+func isPreferred(preferred map[int]bool, genreIDs []int) *bool {
+	if preferred == nil {
+		return nil
+	}
+
+	result := false
+	for _, genreID := range genreIDs {
+		if preferred[genreID] {
+			result = true
+			break
+		}
+	}
+	return &result
+}
+
 func (service *MovieServiceImpl) FindAllMoviesByGenreID(ctx context.Context, genreID int) ([]*web.MovieModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "MovieService.FindAllMoviesByGenreID")
+	defer span.End()
+
 	moviesDetail, err := service.MovieRepository.FindAllMoviesByGenreID(ctx, service.DB, genreID)
 	if err != nil {
 		return nil, err
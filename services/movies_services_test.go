@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/repository/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMovieService(store *memory.Store) *MovieServiceImpl {
+	return &MovieServiceImpl{
+		DB:                   store,
+		MovieRepository:      memory.NewMovieRepository(store),
+		movieGenreRepository: memory.NewMovieGenreRepository(store),
+		movieCrewRepository:  memory.NewMovieCrewRepository(store),
+		authRepository:       memory.NewAuthRepository(store),
+	}
+}
+
+func saveTestGenres(t *testing.T, store *memory.Store, names ...string) {
+	genres := memory.NewGenreRepository(store)
+	for _, name := range names {
+		err := genres.Save(context.Background(), store, &domain.Genre{Name: name})
+		assert.NoError(t, err)
+	}
+}
+
+func TestMovieServiceSaveDuplicate(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	service := newTestMovieService(store)
+
+	r := &web.MovieModelRequest{Title: "Dream", ReleaseDate: "2023-04-26", NationalID: 1}
+	_, err := service.Save(ctx, r)
+	assert.NoError(t, err)
+
+	_, err = service.Save(ctx, r)
+	assert.EqualError(t, err, "movie title already exists")
+}
+
+func TestMovieServiceUpdateGenres(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	service := newTestMovieService(store)
+	saveTestGenres(t, store, "Drama", "Comedy", "Sport")
+
+	movieID, err := service.Save(ctx, &web.MovieModelRequest{
+		Title:       "Dream",
+		ReleaseDate: "2023-04-26",
+		NationalID:  1,
+		GenreIDS:    []int{1, 2},
+	})
+	assert.NoError(t, err)
+
+	err = service.Update(ctx, &web.MovieModelRequest{
+		ID:          movieID,
+		Title:       "Dream",
+		ReleaseDate: "2023-04-26",
+		NationalID:  1,
+		GenreIDS:    []int{2, 3},
+	})
+	assert.NoError(t, err)
+
+	movie, err := service.FindByID(ctx, movieID, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, movie.GenreIDS)
+
+	err = service.Update(ctx, &web.MovieModelRequest{
+		ID:          movieID,
+		Title:       "Dream",
+		ReleaseDate: "2023-04-26",
+		NationalID:  1,
+	})
+	assert.NoError(t, err)
+
+	movie, err = service.FindByID(ctx, movieID, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, movie.GenreIDS)
+}
+
+func TestMovieServiceDeleteCascades(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	service := newTestMovieService(store)
+	saveTestGenres(t, store, "Drama")
+
+	err := memory.NewPersonRepository(store).Save(ctx, store, &domain.Person{Name: "Lee Byeong-heon"})
+	assert.NoError(t, err)
+
+	movieID, err := service.Save(ctx, &web.MovieModelRequest{
+		Title:       "Dream",
+		ReleaseDate: "2023-04-26",
+		NationalID:  1,
+		GenreIDS:    []int{1},
+	})
+	assert.NoError(t, err)
+
+	err = service.movieCrewRepository.Save(ctx, store, movieID, 1, domain.JobDirector)
+	assert.NoError(t, err)
+
+	err = service.Delete(ctx, movieID)
+	assert.NoError(t, err)
+
+	_, err = service.FindByID(ctx, movieID, 0)
+	assert.EqualError(t, err, "sorry, movie id not found")
+
+	err = service.movieGenreRepository.FindGenreExists(ctx, store, 1)
+	assert.EqualError(t, err, "genres ID at movie not found")
+
+	crew, err := service.movieCrewRepository.FindByPerson(ctx, store, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, crew.Credits)
+}
+
+func TestMovieServicePreferred(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	service := newTestMovieService(store)
+	saveTestGenres(t, store, "Drama", "Comedy")
+
+	users := memory.NewAuthRepository(store)
+	assert.NoError(t, users.Register(ctx, store, &domain.Auth{Username: "hyejin", Role: domain.RoleUser}))
+	assert.NoError(t, users.UpdateProfile(ctx, store, &domain.Auth{ID: 1, PreferredGenres: []int{2}}))
+
+	drama, err := service.Save(ctx, &web.MovieModelRequest{Title: "Dream", ReleaseDate: "2023-04-26", NationalID: 1, GenreIDS: []int{1}})
+	assert.NoError(t, err)
+	comedy, err := service.Save(ctx, &web.MovieModelRequest{Title: "Extreme Job", ReleaseDate: "2019-01-23", NationalID: 1, GenreIDS: []int{1, 2}})
+	assert.NoError(t, err)
+
+	movie, err := service.FindByID(ctx, comedy, 1)
+	assert.NoError(t, err)
+	assert.True(t, *movie.Preferred)
+
+	movies, err := service.FindAll(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, drama, movies[0].ID)
+	assert.False(t, *movies[0].Preferred)
+	assert.True(t, *movies[1].Preferred)
+
+	// Anonymous callers get no per-user fields.
+	movie, err = service.FindByID(ctx, comedy, 0)
+	assert.NoError(t, err)
+	assert.Nil(t, movie.Preferred)
+}
@@ -2,7 +2,6 @@ package services
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
@@ -20,15 +19,18 @@ type NationalService interface {
 }
 
 type NationalServiceImpl struct {
-	DB                 *sql.DB
+	DB                 repository.DB
 	NationalRepository repository.NationalRepository
 }
 
-func NewNationalService(DB *sql.DB, nationalRepository repository.NationalRepository) NationalService {
+func NewNationalService(DB repository.DB, nationalRepository repository.NationalRepository) NationalService {
 	return &NationalServiceImpl{DB: DB, NationalRepository: nationalRepository}
 }
 
 func (a *NationalServiceImpl) Save(ctx context.Context, r *web.NationalModelRequest) error {
+	ctx, span := tracer.Start(ctx, "NationalService.Save")
+	defer span.End()
+
 	tx, err := a.DB.Begin()
 	if err != nil {
 		return err
@@ -46,6 +48,9 @@ func (a *NationalServiceImpl) Save(ctx context.Context, r *web.NationalModelRequ
 }
 
 func (a *NationalServiceImpl) Update(ctx context.Context, r *web.NationalModelRequest) error {
+	ctx, span := tracer.Start(ctx, "NationalService.Update")
+	defer span.End()
+
 	tx, err := a.DB.Begin()
 	if err != nil {
 		return err
@@ -64,6 +69,9 @@ func (a *NationalServiceImpl) Update(ctx context.Context, r *web.NationalModelRe
 }
 
 func (a *NationalServiceImpl) Delete(ctx context.Context, ID int) error {
+	ctx, span := tracer.Start(ctx, "NationalService.Delete")
+	defer span.End()
+
 	tx, err := a.DB.Begin()
 	if err != nil {
 		return err
@@ -84,6 +92,9 @@ func (a *NationalServiceImpl) Delete(ctx context.Context, ID int) error {
 }
 
 func (a *NationalServiceImpl) FindByID(ctx context.Context, ID int) (*web.NationalModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "NationalService.FindByID")
+	defer span.End()
+
 	result, err := a.NationalRepository.FindByID(ctx, a.DB, ID)
 	if err != nil {
 		return nil, err
@@ -98,6 +109,8 @@ func (a *NationalServiceImpl) FindByID(ctx context.Context, ID int) (*web.Nation
 }
 
 func (a *NationalServiceImpl) FindByName(ctx context.Context, name string) (*web.NationalModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "NationalService.FindByName")
+	defer span.End()
 
 	result, err := a.NationalRepository.FindByName(ctx, a.DB, name)
 	if err != nil {
@@ -113,6 +126,9 @@ func (a *NationalServiceImpl) FindByName(ctx context.Context, name string) (*web
 }
 
 func (a *NationalServiceImpl) FindAll(ctx context.Context) ([]*web.NationalModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "NationalService.FindAll")
+	defer span.End()
+
 	results, err := a.NationalRepository.FindAll(ctx, a.DB)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/repository/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNationalServiceSaveDuplicate(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	service := NewNationalService(store, memory.NewNationalRepository(store))
+
+	err := service.Save(ctx, &web.NationalModelRequest{Name: "Korea"})
+	assert.NoError(t, err)
+
+	err = service.Save(ctx, &web.NationalModelRequest{Name: "Korea"})
+	assert.EqualError(t, err, "national name already exists")
+
+	nationals, err := service.FindAll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, nationals, 1)
+}
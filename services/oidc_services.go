@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/config"
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/metrics"
+	"github.com/dimassfeb-09/efilm-api.git/oidc"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrOIDCNotConfigured = errors.New("OIDC login is not configured")
+	ErrInvalidOIDCState  = errors.New("login state is invalid or expired")
+	ErrIdentityLinked    = errors.New("this identity is linked to another user")
+)
+
+const maxUsernameLength = 30
+
+// usernameUnsafe matches what is left out of the usernames made for new users.
+var usernameUnsafe = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+type OIDCService interface {
+	// Start begins a login at the provider and returns where to send the
+	// user, and the state the provider sends them back with, which only the
+	// browser that started the login is to know. The identity they log in
+	// with is linked to the user linkUserID, unless it is zero.
+	Start(ctx context.Context, linkUserID int) (authorizationURL, state string, err error)
+	// Finish exchanges the code the provider sent back with state, and
+	// returns a token for the user of the identity, who is created on their
+	// first login.
+	Finish(ctx context.Context, state, code string) (token string, err error)
+}
+
+type OIDCServiceImpl struct {
+	DB                  repository.DB
+	AuthRepository      repository.AuthRepository
+	IdentityRepository  repository.IdentityRepository
+	OIDCLoginRepository repository.OIDCLoginRepository
+	// provider is nil when OIDC is not configured.
+	provider *oidc.Provider
+	keys     *helpers.JWTKeys
+	config   config.OIDC
+	password config.Password
+	metrics  *metrics.Metrics
+	now      func() time.Time
+}
+
+func NewOIDCService(
+	DB repository.DB,
+	authRepository repository.AuthRepository,
+	identityRepository repository.IdentityRepository,
+	oidcLoginRepository repository.OIDCLoginRepository,
+	provider *oidc.Provider,
+	keys *helpers.JWTKeys,
+	config config.OIDC,
+	password config.Password,
+	metrics *metrics.Metrics,
+) OIDCService {
+	return &OIDCServiceImpl{
+		DB:                  DB,
+		AuthRepository:      authRepository,
+		IdentityRepository:  identityRepository,
+		OIDCLoginRepository: oidcLoginRepository,
+		provider:            provider,
+		keys:                keys,
+		config:              config,
+		password:            password,
+		metrics:             metrics,
+		now:                 time.Now,
+	}
+}
+
+func (service *OIDCServiceImpl) Start(ctx context.Context, linkUserID int) (string, string, error) {
+	ctx, span := tracer.Start(ctx, "OIDCService.Start")
+	defer span.End()
+
+	if service.provider == nil {
+		return "", "", ErrOIDCNotConfigured
+	}
+
+	state, err := newSecret()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := newSecret()
+	if err != nil {
+		return "", "", err
+	}
+	verifier, err := oidc.NewVerifier()
+	if err != nil {
+		return "", "", err
+	}
+
+	authorizationURL, err := service.provider.AuthCodeURL(ctx, state, oidc.Challenge(verifier), nonce)
+	if err != nil {
+		return "", "", err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return "", "", err
+	}
+	defer helpers.RollbackOrCommit(ctx, tx)
+
+	now := service.now()
+	err = service.OIDCLoginRepository.DeleteExpired(ctx, tx, now)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = service.OIDCLoginRepository.Save(ctx, tx, &domain.OIDCLogin{
+		StateHash:    helpers.HashSecret(state),
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		LinkUserID:   linkUserID,
+		ExpiresAt:    now.Add(service.config.LoginTTL),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return authorizationURL, state, nil
+}
+
+func (service *OIDCServiceImpl) Finish(ctx context.Context, state, code string) (token string, err error) {
+	ctx, span := tracer.Start(ctx, "OIDCService.Finish")
+	defer span.End()
+
+	if service.provider == nil {
+		return "", ErrOIDCNotConfigured
+	}
+
+	login, err := service.takeLogin(ctx, state)
+	if err != nil {
+		return "", err
+	}
+
+	// Outside of any transaction, as the provider may be slow to answer.
+	claims, err := service.provider.Exchange(ctx, code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer helpers.CommitOrRollback(tx, &err)
+
+	user, err := service.findUser(ctx, tx, login, claims)
+	if err != nil {
+		return "", err
+	}
+	if !user.DisabledAt.IsZero() {
+		return "", ErrUserDisabled
+	}
+
+	service.metrics.LoggedIn()
+	return helpers.GenerateTokenJWT(service.keys, user.ID, user.Username, user.Role)
+}
+
+// takeLogin uses up the login started with state.
+func (service *OIDCServiceImpl) takeLogin(ctx context.Context, state string) (*domain.OIDCLogin, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helpers.RollbackOrCommit(ctx, tx)
+
+	login, err := service.OIDCLoginRepository.Take(ctx, tx, helpers.HashSecret(state))
+	if err != nil {
+		return nil, err
+	}
+	if login == nil || !service.now().Before(login.ExpiresAt) {
+		return nil, ErrInvalidOIDCState
+	}
+	return login, nil
+}
+
+// findUser returns the user the identity of claims is linked to, linking it
+// to the user the login is for, or to a new user, the first time.
+func (service *OIDCServiceImpl) findUser(ctx context.Context, tx repository.Querier, login *domain.OIDCLogin, claims *oidc.Claims) (*domain.Auth, error) {
+	issuer := service.provider.Issuer()
+	identity, err := service.IdentityRepository.FindBySubject(ctx, tx, issuer, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case login.LinkUserID != 0 && identity != nil && identity.UserID != login.LinkUserID:
+		return nil, ErrIdentityLinked
+	case identity != nil:
+		return service.AuthRepository.FindByID(ctx, tx, identity.UserID)
+	}
+
+	userID := login.LinkUserID
+	if userID == 0 {
+		user, err := service.createUser(ctx, tx, claims)
+		if err != nil {
+			return nil, err
+		}
+		userID = user.ID
+	}
+
+	err = service.IdentityRepository.Save(ctx, tx, &domain.Identity{
+		Issuer:    issuer,
+		Subject:   claims.Subject,
+		UserID:    userID,
+		CreatedAt: service.now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return service.AuthRepository.FindByID(ctx, tx, userID)
+}
+
+// createUser registers a user named after claims. Their password is random
+// and thrown away, so they log in with the provider until they reset it.
+func (service *OIDCServiceImpl) createUser(ctx context.Context, tx repository.Querier, claims *oidc.Claims) (*domain.Auth, error) {
+	username, err := service.freeUsername(ctx, tx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), service.password.HashCost)
+	if err != nil {
+		return nil, err
+	}
+
+	err = service.AuthRepository.Register(ctx, tx, &domain.Auth{Username: username, Password: string(hash), Role: domain.RoleUser})
+	if err != nil {
+		return nil, err
+	}
+	user, err := service.AuthRepository.FindByUsername(ctx, tx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := []rune(strings.TrimSpace(claims.Name)); len(name) > 0 {
+		if len(name) > maxDisplayNameLength {
+			name = name[:maxDisplayNameLength]
+		}
+		user.DisplayName = string(name)
+		err = service.AuthRepository.UpdateProfile(ctx, tx, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	service.metrics.Registered()
+	return user, nil
+}
+
+// freeUsername makes a username nobody has from the preferred username or
+// the email of claims, numbering it when it is taken.
+func (service *OIDCServiceImpl) freeUsername(ctx context.Context, tx repository.Querier, claims *oidc.Claims) (string, error) {
+	base, _, _ := strings.Cut(claims.Email, "@")
+	if claims.PreferredUsername != "" {
+		base = claims.PreferredUsername
+	}
+	base = strings.Trim(usernameUnsafe.ReplaceAllString(strings.ToLower(base), ""), "._-")
+	if len(base) > maxUsernameLength-3 {
+		base = base[:maxUsernameLength-3]
+	}
+	if base == "" {
+		base = "user"
+	}
+
+	for i := 1; i < 100; i++ {
+		username := base
+		if i > 1 {
+			username += strconv.Itoa(i)
+		}
+		_, err := service.AuthRepository.Login(ctx, tx, username)
+		if errors.Is(err, repository.ErrUsernameNotFound) {
+			return username, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("every username like %s is taken", base)
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+type PersonService interface {
+	Save(ctx context.Context, r *web.PersonModelRequest) error
+	Update(ctx context.Context, r *web.PersonModelRequest) error
+	Delete(ctx context.Context, ID int) error
+	FindByID(ctx context.Context, ID int) (*web.PersonModelResponse, error)
+	FindByName(ctx context.Context, name string) (*web.PersonModelResponse, error)
+	FindAll(ctx context.Context) ([]*web.PersonModelResponse, error)
+	FindCredits(ctx context.Context, ID int) (*web.PersonCreditsModelResponse, error)
+}
+
+type PersonServiceImpl struct {
+	DB               repository.DB
+	PersonRepository repository.PersonRepository
+}
+
+func NewPersonService(DB repository.DB, personRepository repository.PersonRepository) PersonService {
+	return &PersonServiceImpl{DB: DB, PersonRepository: personRepository}
+}
+
+func (service *PersonServiceImpl) Save(ctx context.Context, r *web.PersonModelRequest) error {
+	ctx, span := tracer.Start(ctx, "PersonService.Save")
+	defer span.End()
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helpers.RollbackOrCommit(ctx, tx)
+
+	_, err = service.FindByName(ctx, r.Name)
+	if err == nil {
+		return errors.New("person name already exists")
+	}
+
+	date, err := time.Parse(time.DateOnly, r.DateOfBirth)
+	if err != nil {
+		return errors.New("incorrect date format yyyy-mm-dd")
+	}
+
+	return service.PersonRepository.Save(ctx, tx, &domain.Person{
+		Name:          r.Name,
+		DateOfBirth:   date,
+		NationalityID: r.NationalityID,
+		IsActor:       r.IsActor,
+		IsDirector:    r.IsDirector,
+	})
+}
+
+func (service *PersonServiceImpl) Update(ctx context.Context, r *web.PersonModelRequest) error {
+	ctx, span := tracer.Start(ctx, "PersonService.Update")
+	defer span.End()
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helpers.RollbackOrCommit(ctx, tx)
+
+	date, err := time.Parse(time.DateOnly, r.DateOfBirth)
+	if err != nil {
+		return errors.New("incorrect date format yyyy-mm-dd")
+	}
+
+	_, err = service.FindByID(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+
+	return service.PersonRepository.Update(ctx, tx, &domain.Person{
+		ID:            r.ID,
+		Name:          r.Name,
+		DateOfBirth:   date,
+		NationalityID: r.NationalityID,
+		UpdatedAt:     time.Now(),
+	})
+}
+
+func (service *PersonServiceImpl) Delete(ctx context.Context, ID int) (err error) {
+	ctx, span := tracer.Start(ctx, "PersonService.Delete")
+	defer span.End()
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helpers.CommitOrRollback(tx, &err)
+
+	_, err = service.FindByID(ctx, ID)
+	if err != nil {
+		return err
+	}
+
+	return service.PersonRepository.Delete(ctx, tx, ID)
+}
+
+func (service *PersonServiceImpl) FindByID(ctx context.Context, ID int) (*web.PersonModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "PersonService.FindByID")
+	defer span.End()
+
+	result, err := service.PersonRepository.FindByID(ctx, service.DB, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPersonResponse(result), nil
+}
+
+func (service *PersonServiceImpl) FindByName(ctx context.Context, name string) (*web.PersonModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "PersonService.FindByName")
+	defer span.End()
+
+	result, err := service.PersonRepository.FindByName(ctx, service.DB, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPersonResponse(result), nil
+}
+
+func (service *PersonServiceImpl) FindAll(ctx context.Context) ([]*web.PersonModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "PersonService.FindAll")
+	defer span.End()
+
+	results, err := service.PersonRepository.FindAll(ctx, service.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []*web.PersonModelResponse
+	for _, result := range results {
+		responses = append(responses, toPersonResponse(result))
+	}
+
+	return responses, nil
+}
+
+func (service *PersonServiceImpl) FindCredits(ctx context.Context, ID int) (*web.PersonCreditsModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "PersonService.FindCredits")
+	defer span.End()
+
+	result, err := service.PersonRepository.FindCredits(ctx, service.DB, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	credits := &web.PersonCreditsModelResponse{
+		Person: *toPersonResponse(&result.Person),
+		Cast:   []web.CastCredit{},
+		Crew:   []web.CrewCredit{},
+	}
+
+	for _, credit := range result.Cast {
+		credits.Cast = append(credits.Cast, web.CastCredit{
+			Movie: web.Movie{
+				MovieID:     credit.Movie.ID,
+				Title:       credit.Movie.Title,
+				ReleaseDate: credit.Movie.ReleaseDate,
+			},
+			Role:          credit.Role,
+			CharacterName: credit.CharacterName,
+			CreditType:    credit.CreditType,
+			BillingOrder:  credit.BillingOrder,
+		})
+	}
+
+	for _, credit := range result.Crew {
+		credits.Crew = append(credits.Crew, web.CrewCredit{
+			Movie: web.Movie{
+				MovieID:     credit.Movie.ID,
+				Title:       credit.Movie.Title,
+				ReleaseDate: credit.Movie.ReleaseDate,
+			},
+			Job:        credit.Job,
+			Department: credit.Department,
+		})
+	}
+
+	return credits, nil
+}
+
+func toPersonResponse(person *domain.Person) *web.PersonModelResponse {
+	return &web.PersonModelResponse{
+		ID:            person.ID,
+		Name:          person.Name,
+		DateOfBirth:   person.DateOfBirth,
+		NationalityID: person.NationalityID,
+		IsActor:       person.IsActor,
+		IsDirector:    person.IsDirector,
+		DirectorID:    person.DirectorID,
+		CreatedAt:     person.CreatedAt,
+		UpdatedAt:     person.UpdatedAt,
+	}
+}
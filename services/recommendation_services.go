@@ -2,7 +2,6 @@ package services
 
 import (
 	"context"
-	"database/sql"
 	"github.com/dimassfeb-09/efilm-api.git/entity/web"
 	"github.com/dimassfeb-09/efilm-api.git/helpers"
 	"github.com/dimassfeb-09/efilm-api.git/repository"
@@ -16,11 +15,11 @@ type RecommendationMovieService interface {
 }
 
 type RecommendationMovieServiceImpl struct {
-	DB                            *sql.DB
+	DB                            repository.DB
 	RecommendationMovieRepository repository.RecommendationMovieRepository
 }
 
-func NewRecommendationMovieService(DB *sql.DB, recommendationRepository repository.RecommendationMovieRepository) RecommendationMovieService {
+func NewRecommendationMovieService(DB repository.DB, recommendationRepository repository.RecommendationMovieRepository) RecommendationMovieService {
 	return &RecommendationMovieServiceImpl{
 		DB:                            DB,
 		RecommendationMovieRepository: recommendationRepository,
@@ -28,6 +27,9 @@ func NewRecommendationMovieService(DB *sql.DB, recommendationRepository reposito
 }
 
 func (a *RecommendationMovieServiceImpl) Save(ctx context.Context, movieID int) error {
+	ctx, span := tracer.Start(ctx, "RecommendationMovieService.Save")
+	defer span.End()
+
 	tx, err := a.DB.Begin()
 	if err != nil {
 		return err
@@ -38,6 +40,9 @@ func (a *RecommendationMovieServiceImpl) Save(ctx context.Context, movieID int)
 }
 
 func (a *RecommendationMovieServiceImpl) Delete(ctx context.Context, movieID int) error {
+	ctx, span := tracer.Start(ctx, "RecommendationMovieService.Delete")
+	defer span.End()
+
 	tx, err := a.DB.Begin()
 	if err != nil {
 		return err
@@ -58,6 +63,9 @@ func (a *RecommendationMovieServiceImpl) Delete(ctx context.Context, movieID int
 }
 
 func (a *RecommendationMovieServiceImpl) FindByID(ctx context.Context, MovieID int) (*web.RecommendationMovieModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "RecommendationMovieService.FindByID")
+	defer span.End()
+
 	result, err := a.RecommendationMovieRepository.FindByID(ctx, a.DB, MovieID)
 	if err != nil {
 		return nil, err
@@ -79,6 +87,9 @@ func (a *RecommendationMovieServiceImpl) FindByID(ctx context.Context, MovieID i
 }
 
 func (a *RecommendationMovieServiceImpl) FindAll(ctx context.Context) ([]*web.RecommendationMovieModelResponse, error) {
+	ctx, span := tracer.Start(ctx, "RecommendationMovieService.FindAll")
+	defer span.End()
+
 	results, err := a.RecommendationMovieRepository.FindAll(ctx, a.DB)
 	if err != nil {
 		return nil, err
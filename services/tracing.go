@@ -0,0 +1,7 @@
+package services
+
+import "go.opentelemetry.io/otel"
+
+// tracer starts a span for every service method, named after the interface
+// and the method, e.g. MovieService.UploadFile.
+var tracer = otel.Tracer("github.com/dimassfeb-09/efilm-api.git/services")
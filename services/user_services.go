@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/helpers"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+)
+
+var (
+	// ErrOwnAccount keeps admins from locking themselves out.
+	ErrOwnAccount  = errors.New("admins cannot disable, delete or demote themselves")
+	ErrUserDeleted = errors.New("this account no longer exists")
+)
+
+const (
+	maxDisplayNameLength = 50
+	maxAvatarURLLength   = 2048
+	maxPreferences       = 10
+)
+
+// languageTag matches ISO 639 language codes with an optional region, such as
+// "ko" or "pt-BR".
+var languageTag = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
+type UserService interface {
+	// Authenticate returns the user a token was issued to, refusing users
+	// deleted or disabled since.
+	Authenticate(ctx context.Context, ID int) (*domain.Auth, error)
+	FindByID(ctx context.Context, ID int) (*web.UserResponse, error)
+	UpdateProfile(ctx context.Context, ID int, r *web.UserProfileRequest) (*web.UserResponse, error)
+	// FindAll returns the users whose username or display name contains
+	// search, or every user when it is empty.
+	FindAll(ctx context.Context, search string) ([]*web.UserResponse, error)
+	// The methods below are for admins, adminID being the one calling.
+	ChangeRole(ctx context.Context, adminID int, ID int, r *web.UserRoleRequest) error
+	Disable(ctx context.Context, adminID int, ID int) error
+	Enable(ctx context.Context, ID int) error
+	Delete(ctx context.Context, adminID int, ID int) error
+}
+
+type UserServiceImpl struct {
+	DB                      repository.DB
+	AuthRepository          repository.AuthRepository
+	GenreRepository         repository.GenreRepository
+	PasswordResetRepository repository.PasswordResetRepository
+	LoginAttemptRepository  repository.LoginAttemptRepository
+	IdentityRepository      repository.IdentityRepository
+	now                     func() time.Time
+}
+
+func NewUserService(
+	DB repository.DB,
+	authRepository repository.AuthRepository,
+	genreRepository repository.GenreRepository,
+	passwordResetRepository repository.PasswordResetRepository,
+	loginAttemptRepository repository.LoginAttemptRepository,
+	identityRepository repository.IdentityRepository,
+) UserService {
+	return &UserServiceImpl{
+		DB:                      DB,
+		AuthRepository:          authRepository,
+		GenreRepository:         genreRepository,
+		PasswordResetRepository: passwordResetRepository,
+		LoginAttemptRepository:  loginAttemptRepository,
+		IdentityRepository:      identityRepository,
+		now:                     time.Now,
+	}
+}
+
+func (service *UserServiceImpl) Authenticate(ctx context.Context, ID int) (*domain.Auth, error) {
+	ctx, span := tracer.Start(ctx, "UserService.Authenticate")
+	defer span.End()
+
+	user, err := service.AuthRepository.FindByID(ctx, service.DB, ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrUserDeleted
+		}
+		return nil, err
+	}
+	if !user.DisabledAt.IsZero() {
+		return nil, ErrUserDisabled
+	}
+
+	return user, nil
+}
+
+func (service *UserServiceImpl) FindByID(ctx context.Context, ID int) (*web.UserResponse, error) {
+	ctx, span := tracer.Start(ctx, "UserService.FindByID")
+	defer span.End()
+
+	user, err := service.AuthRepository.FindByID(ctx, service.DB, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toUserResponse(user), nil
+}
+
+func (service *UserServiceImpl) UpdateProfile(ctx context.Context, ID int, r *web.UserProfileRequest) (response *web.UserResponse, err error) {
+	ctx, span := tracer.Start(ctx, "UserService.UpdateProfile")
+	defer span.End()
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helpers.CommitOrRollback(tx, &err)
+
+	user, err := service.AuthRepository.FindByID(ctx, tx, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.DisplayName != nil {
+		name := strings.TrimSpace(*r.DisplayName)
+		if utf8.RuneCountInString(name) > maxDisplayNameLength {
+			return nil, fmt.Errorf("display_name must be at most %d characters", maxDisplayNameLength)
+		}
+		user.DisplayName = name
+	}
+
+	if r.AvatarURL != nil {
+		avatar := strings.TrimSpace(*r.AvatarURL)
+		if avatar != "" {
+			parsed, err := url.Parse(avatar)
+			if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" || len(avatar) > maxAvatarURLLength {
+				return nil, errors.New("avatar_url must be an http or https URL")
+			}
+		}
+		user.AvatarURL = avatar
+	}
+
+	if r.PreferredLanguages != nil {
+		languages := unique(r.PreferredLanguages)
+		if len(languages) > maxPreferences {
+			return nil, fmt.Errorf("preferred_languages may list at most %d languages", maxPreferences)
+		}
+		for _, language := range languages {
+			if !languageTag.MatchString(language) {
+				return nil, fmt.Errorf("preferred language %q is not a language code such as ko or pt-BR", language)
+			}
+		}
+		user.PreferredLanguages = languages
+	}
+
+	if r.PreferredGenres != nil {
+		genres := unique(r.PreferredGenres)
+		if len(genres) > maxPreferences {
+			return nil, fmt.Errorf("preferred_genres may list at most %d genres", maxPreferences)
+		}
+		for _, genre := range genres {
+			if _, err := service.GenreRepository.FindByID(ctx, tx, genre); err != nil {
+				return nil, err
+			}
+		}
+		user.PreferredGenres = genres
+	}
+
+	err = service.AuthRepository.UpdateProfile(ctx, tx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return toUserResponse(user), nil
+}
+
+func (service *UserServiceImpl) FindAll(ctx context.Context, search string) ([]*web.UserResponse, error) {
+	ctx, span := tracer.Start(ctx, "UserService.FindAll")
+	defer span.End()
+
+	users, err := service.AuthRepository.FindAll(ctx, service.DB, strings.TrimSpace(search))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []*web.UserResponse{}
+	for _, user := range users {
+		responses = append(responses, toUserResponse(user))
+	}
+
+	return responses, nil
+}
+
+func (service *UserServiceImpl) ChangeRole(ctx context.Context, adminID int, ID int, r *web.UserRoleRequest) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.ChangeRole")
+	defer span.End()
+
+	if ID == adminID && r.Role != domain.RoleAdmin {
+		return ErrOwnAccount
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helpers.CommitOrRollback(tx, &err)
+
+	return service.AuthRepository.UpdateRole(ctx, tx, ID, r.Role)
+}
+
+func (service *UserServiceImpl) Disable(ctx context.Context, adminID int, ID int) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.Disable")
+	defer span.End()
+
+	if ID == adminID {
+		return ErrOwnAccount
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helpers.CommitOrRollback(tx, &err)
+
+	user, err := service.AuthRepository.FindByID(ctx, tx, ID)
+	if err != nil {
+		return err
+	}
+	if !user.DisabledAt.IsZero() {
+		return nil
+	}
+
+	err = service.AuthRepository.UpdateDisabledAt(ctx, tx, ID, service.now())
+	if err != nil {
+		return err
+	}
+
+	// A disabled user has no use for a pending reset.
+	return service.PasswordResetRepository.DeleteByUserID(ctx, tx, ID)
+}
+
+func (service *UserServiceImpl) Enable(ctx context.Context, ID int) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.Enable")
+	defer span.End()
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helpers.CommitOrRollback(tx, &err)
+
+	return service.AuthRepository.UpdateDisabledAt(ctx, tx, ID, time.Time{})
+}
+
+func (service *UserServiceImpl) Delete(ctx context.Context, adminID int, ID int) (err error) {
+	ctx, span := tracer.Start(ctx, "UserService.Delete")
+	defer span.End()
+
+	if ID == adminID {
+		return ErrOwnAccount
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helpers.CommitOrRollback(tx, &err)
+
+	user, err := service.AuthRepository.FindByID(ctx, tx, ID)
+	if err != nil {
+		return err
+	}
+
+	err = service.PasswordResetRepository.DeleteByUserID(ctx, tx, ID)
+	if err != nil {
+		return err
+	}
+	err = service.LoginAttemptRepository.Delete(ctx, tx, userKeyPrefix+user.Username)
+	if err != nil {
+		return err
+	}
+	err = service.IdentityRepository.DeleteByUserID(ctx, tx, ID)
+	if err != nil {
+		return err
+	}
+
+	return service.AuthRepository.Delete(ctx, tx, ID)
+}
+
+func toUserResponse(user *domain.Auth) *web.UserResponse {
+	response := &web.UserResponse{
+		UserID:             user.ID,
+		Username:           user.Username,
+		Role:               user.Role,
+		DisplayName:        user.DisplayName,
+		AvatarURL:          user.AvatarURL,
+		PreferredLanguages: append([]string{}, user.PreferredLanguages...),
+		PreferredGenres:    append([]int{}, user.PreferredGenres...),
+	}
+	if !user.DisabledAt.IsZero() {
+		disabledAt := user.DisabledAt
+		response.DisabledAt = &disabledAt
+	}
+	return response
+}
+
+// unique drops the repeats from values, keeping their order.
+func unique[T comparable](values []T) []T {
+	seen := map[T]bool{}
+	result := []T{}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
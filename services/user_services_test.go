@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dimassfeb-09/efilm-api.git/entity/domain"
+	"github.com/dimassfeb-09/efilm-api.git/entity/web"
+	"github.com/dimassfeb-09/efilm-api.git/repository"
+	"github.com/dimassfeb-09/efilm-api.git/repository/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func newUserService(store *memory.Store) UserService {
+	return NewUserService(store, memory.NewAuthRepository(store), memory.NewGenreRepository(store),
+		memory.NewPasswordResetRepository(store), memory.NewLoginAttemptRepository(store), memory.NewIdentityRepository(store))
+}
+
+func TestUserServiceUpdateProfile(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	assert.NoError(t, memory.NewGenreRepository(store).Save(ctx, store, &domain.Genre{Name: "Drama"}))
+	assert.NoError(t, newAuthService(store, &notifications{}).Register(ctx, &web.AuthModelRequest{Username: "jieun", Password: "secret-password"}))
+	service := newUserService(store)
+
+	name := " Lee Ji Eun "
+	user, err := service.UpdateProfile(ctx, 1, &web.UserProfileRequest{DisplayName: &name, PreferredLanguages: []string{"ko", "en", "ko"}, PreferredGenres: []int{1}})
+	assert.NoError(t, err)
+	assert.Equal(t, "Lee Ji Eun", user.DisplayName)
+	assert.Equal(t, []string{"ko", "en"}, user.PreferredLanguages)
+
+	// Fields left out are kept, empty ones cleared.
+	user, err = service.UpdateProfile(ctx, 1, &web.UserProfileRequest{PreferredGenres: []int{}})
+	assert.NoError(t, err)
+	assert.Equal(t, "Lee Ji Eun", user.DisplayName)
+	assert.Equal(t, []string{"ko", "en"}, user.PreferredLanguages)
+	assert.Empty(t, user.PreferredGenres)
+
+	_, err = service.UpdateProfile(ctx, 1, &web.UserProfileRequest{PreferredLanguages: []string{"Korean"}})
+	assert.Error(t, err)
+	_, err = service.UpdateProfile(ctx, 1, &web.UserProfileRequest{PreferredGenres: []int{2}})
+	assert.Error(t, err)
+}
+
+func TestUserServiceDisable(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	auth := newAuthService(store, &notifications{})
+	assert.NoError(t, auth.Register(ctx, &web.AuthModelRequest{Username: "admin", Password: "secret-password"}))
+	assert.NoError(t, auth.Register(ctx, &web.AuthModelRequest{Username: "jieun", Password: "secret-password"}))
+	service := newUserService(store)
+
+	assert.ErrorIs(t, service.Disable(ctx, 1, 1), ErrOwnAccount)
+	assert.ErrorIs(t, service.ChangeRole(ctx, 1, 1, &web.UserRoleRequest{Role: domain.RoleUser}), ErrOwnAccount)
+	assert.ErrorIs(t, service.Delete(ctx, 1, 1), ErrOwnAccount)
+
+	login := &web.AuthModelRequest{Username: "jieun", Password: "secret-password"}
+	assert.NoError(t, service.Disable(ctx, 1, 2))
+	_, err := auth.Login(ctx, login, "10.0.0.1")
+	assert.ErrorIs(t, err, ErrUserDisabled)
+
+	// A wrong password does not tell the account is disabled.
+	_, err = auth.Login(ctx, &web.AuthModelRequest{Username: "jieun", Password: "wrong"}, "10.0.0.1")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	assert.NoError(t, service.Enable(ctx, 2))
+	_, err = auth.Login(ctx, login, "10.0.0.1")
+	assert.NoError(t, err)
+}
+
+type failingIdentities struct {
+	repository.IdentityRepository
+}
+
+func (failingIdentities) DeleteByUserID(ctx context.Context, tx repository.Querier, userID int) error {
+	return errors.New("connection reset")
+}
+
+func TestUserServiceDeleteRollsBack(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	auth := newAuthService(store, &notifications{})
+	assert.NoError(t, auth.Register(ctx, &web.AuthModelRequest{Username: "admin", Password: "secret-password"}))
+	assert.NoError(t, auth.Register(ctx, &web.AuthModelRequest{Username: "jieun", Password: "secret-password"}))
+	resets := memory.NewPasswordResetRepository(store)
+	assert.NoError(t, resets.Save(ctx, store, &domain.PasswordReset{TokenHash: "hash", UserID: 2, ExpiresAt: time.Now().Add(time.Hour)}))
+
+	service := NewUserService(store, memory.NewAuthRepository(store), memory.NewGenreRepository(store),
+		resets, memory.NewLoginAttemptRepository(store), failingIdentities{})
+	assert.EqualError(t, service.Delete(ctx, 1, 2), "connection reset")
+
+	// Nothing of the user is gone.
+	reset, err := resets.FindByTokenHash(ctx, store, "hash")
+	assert.NoError(t, err)
+	assert.NotNil(t, reset)
+	_, err = service.FindByID(ctx, 2)
+	assert.NoError(t, err)
+}